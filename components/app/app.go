@@ -7,7 +7,9 @@ import (
 	"github.com/iotaledger/inx-api-core-v1/components/coreapi"
 	"github.com/iotaledger/inx-api-core-v1/components/database"
 	"github.com/iotaledger/inx-api-core-v1/components/inx"
+	"github.com/iotaledger/inx-api-core-v1/components/labels"
 	"github.com/iotaledger/inx-api-core-v1/components/prometheus"
+	"github.com/iotaledger/inx-api-core-v1/pkg/toolset"
 )
 
 var (
@@ -24,6 +26,7 @@ func App() *app.App {
 		app.WithComponents(
 			shutdown.Component,
 			database.Component,
+			labels.Component,
 			coreapi.Component,
 			inx.Component,
 			profiling.Component,
@@ -46,5 +49,15 @@ func init() {
 			"help",
 			"version",
 		},
+		Init: initialize,
 	}
 }
+
+func initialize(_ *app.App) error {
+	if toolset.ShouldHandleTools() {
+		toolset.HandleTools()
+		// HandleTools will call os.Exit
+	}
+
+	return nil
+}
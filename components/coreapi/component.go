@@ -7,6 +7,7 @@ import (
 	"net"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/labstack/echo/v4"
@@ -16,6 +17,7 @@ import (
 	"github.com/iotaledger/hive.go/app"
 	"github.com/iotaledger/inx-api-core-v1/pkg/daemon"
 	"github.com/iotaledger/inx-api-core-v1/pkg/database"
+	"github.com/iotaledger/inx-api-core-v1/pkg/labels"
 	"github.com/iotaledger/inx-api-core-v1/pkg/restapi"
 	"github.com/iotaledger/inx-api-core-v1/pkg/server"
 	"github.com/iotaledger/inx-app/pkg/httpserver"
@@ -37,6 +39,8 @@ type dependencies struct {
 	dig.In
 	AppInfo       *app.Info
 	Database      *database.Database
+	LabelManager  *labels.Manager
+	LedgerStats   *database.LedgerStatsCache
 	Echo          *echo.Echo
 	NetworkIDName string               `name:"networkIdName"`
 	Bech32HRP     iotago.NetworkPrefix `name:"bech32HRP"`
@@ -75,6 +79,26 @@ func initConfigParams(c *dig.Container) error {
 
 func provide(c *dig.Container) error {
 
+	type ledgerStatsDeps struct {
+		dig.In
+		Database *database.Database
+	}
+
+	if err := c.Provide(func(deps ledgerStatsDeps) (*database.LedgerStatsCache, error) {
+		histogramUpperBounds := make([]uint64, len(ParamsRestAPI.LedgerStats.HistogramBuckets))
+		for i, bucket := range ParamsRestAPI.LedgerStats.HistogramBuckets {
+			upperBound, err := strconv.ParseUint(strings.TrimSpace(bucket), 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid ledger stats histogram bucket: %s, error: %w", bucket, err)
+			}
+			histogramUpperBounds[i] = upperBound
+		}
+
+		return database.NewLedgerStatsCache(deps.Database, histogramUpperBounds), nil
+	}); err != nil {
+		return err
+	}
+
 	if err := c.Provide(func() *echo.Echo {
 		e := httpserver.NewEcho(
 			Component.Logger(),
@@ -119,16 +143,29 @@ func run() error {
 
 		swagger := server.CreateEchoSwagger(deps.Echo, deps.AppInfo.Version, ParamsRestAPI.SwaggerEnabled)
 
-		//nolint:contextcheck //false positive
 		_ = server.NewDatabaseServer(
+			ctx,
 			swagger,
 			deps.AppInfo,
 			deps.Database,
 			deps.Database.UTXOManager(),
+			deps.LabelManager,
+			deps.LedgerStats,
 			deps.NetworkIDName,
 			deps.Bech32HRP,
-			ParamsRestAPI.Limits.MaxResults,
-			ParamsRestAPI.Caches.TransactionHistorySize,
+			&server.ServerLimits{
+				MaxResults:                       ParamsRestAPI.Limits.MaxResults,
+				TransactionHistoryCacheSize:      ParamsRestAPI.Caches.TransactionHistorySize,
+				TransactionHistoryWorkers:        ParamsRestAPI.Limits.TransactionHistoryWorkers,
+				TransactionHistoryQueueSize:      ParamsRestAPI.Limits.TransactionHistoryQueueSize,
+				MilestoneRangeWorkers:            ParamsRestAPI.Limits.MilestoneRangeWorkers,
+				MilestoneRangeQueueSize:          ParamsRestAPI.Limits.MilestoneRangeQueueSize,
+				LargestTransactionsCacheSize:     ParamsRestAPI.Caches.LargestTransactionsSize,
+				LargestTransactionsMaxMilestones: ParamsRestAPI.Limits.LargestTransactionsMaxMilestones,
+				LedgerDiffCacheSize:              ParamsRestAPI.Caches.LedgerDiffSize,
+				LedgerDiffMaxMilestones:          ParamsRestAPI.Limits.LedgerDiffMaxMilestones,
+				AttestationMaxOutputs:            ParamsRestAPI.Limits.AttestationMaxOutputs,
+			},
 		)
 
 		deps.Echo.Server.BaseContext = func(l net.Listener) context.Context {
@@ -161,5 +198,18 @@ func run() error {
 		Component.LogPanicf("failed to start worker: %s", err)
 	}
 
+	// the ledger statistics are computed in the background, so that they are available for the first request
+	if err := Component.Daemon().BackgroundWorker("Ledger statistics", func(ctx context.Context) {
+		Component.LogInfo("Computing ledger statistics ...")
+		if err := deps.LedgerStats.Compute(ctx); err != nil {
+			Component.LogWarnf("Computing ledger statistics ... failed: %s", err)
+
+			return
+		}
+		Component.LogInfo("Computing ledger statistics ... done")
+	}, daemon.PriorityStopDatabaseAPI); err != nil {
+		Component.LogPanicf("failed to start worker: %s", err)
+	}
+
 	return nil
 }
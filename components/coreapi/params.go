@@ -17,11 +17,34 @@ type ParametersRestAPI struct {
 		MaxBodyLength string `default:"1M" usage:"the maximum number of characters that the body of an API call may contain"`
 		// the maximum number of results that may be returned by an endpoint
 		MaxResults int `default:"1000" usage:"the maximum number of results that may be returned by an endpoint (0 for disabled)"`
+		// the maximum number of transaction histories that are computed in parallel
+		TransactionHistoryWorkers int `default:"4" usage:"the maximum number of transaction histories that are computed in parallel"`
+		// the maximum number of transaction history requests that wait for a free worker
+		TransactionHistoryQueueSize int `default:"100" usage:"the maximum number of transaction history requests that wait for a free worker before requests are rejected"`
+		// the maximum number of computations over milestone ranges that run in parallel
+		MilestoneRangeWorkers int `default:"2" usage:"the maximum number of computations over milestone ranges (largest transactions, ledger diffs and dormant outputs) that run in parallel"`
+		// the maximum number of computations over milestone ranges that wait for a free worker
+		MilestoneRangeQueueSize int `default:"20" usage:"the maximum number of computations over milestone ranges that wait for a free worker before requests are rejected"`
+		// the maximum number of milestones that may be covered by a largest transactions request
+		LargestTransactionsMaxMilestones int `default:"5000" usage:"the maximum number of milestones that may be covered by a largest transactions request"`
+		// the maximum number of milestones that may be covered by a ledger diff request
+		LedgerDiffMaxMilestones int `default:"5000" usage:"the maximum number of milestones that may be covered by a ledger diff request"`
+		// the maximum number of unspent outputs that may be contained in an attestation bundle
+		AttestationMaxOutputs int `default:"10000" usage:"the maximum number of unspent outputs that may be contained in an attestation bundle (0 for disabled)"`
+	}
+
+	LedgerStats struct {
+		// the upper bounds of the buckets of the balance distribution histogram
+		HistogramBuckets []string `default:"1000000,10000000,100000000,1000000000,10000000000,100000000000,1000000000000,10000000000000,100000000000000" usage:"the exclusive upper bounds of the buckets of the balance distribution histogram (a last bucket without upper bound is always added)"`
 	}
 
 	Caches struct {
-		// the maximum number of entries in the transaction history LRU cache
-		TransactionHistorySize int `default:"10000" usage:"the maximum number of entries in the transaction history LRU cache"`
+		// the maximum number of transaction history items in the LRU cache
+		TransactionHistorySize int `default:"1000000" usage:"the maximum number of transaction history items (summed over all addresses) in the LRU cache"`
+		// the maximum number of largest transactions in the LRU cache
+		LargestTransactionsSize int `default:"100000" usage:"the maximum number of largest transactions (summed over all milestone range buckets) in the LRU cache"`
+		// the maximum number of ledger diff items in the LRU cache
+		LedgerDiffSize int `default:"1000000" usage:"the maximum number of ledger diff items (created and consumed outputs and balance changes, summed over all ranges) in the LRU cache"`
 	}
 
 	// SwaggerEnabled defines whether to provide swagger API documentation under endpoint "/swagger"
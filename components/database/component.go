@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"errors"
 
 	"github.com/labstack/echo/v4"
 	"go.uber.org/dig"
@@ -10,6 +11,7 @@ import (
 	"github.com/iotaledger/hive.go/app/shutdown"
 	"github.com/iotaledger/inx-api-core-v1/pkg/daemon"
 	"github.com/iotaledger/inx-api-core-v1/pkg/database"
+	"github.com/iotaledger/inx-api-core-v1/pkg/milestone"
 )
 
 const (
@@ -48,7 +50,13 @@ func provide(c *dig.Container) error {
 	return c.Provide(func(deps storageDeps) (*database.Database, error) {
 		Component.LogInfo("Setting up database ...")
 
-		store, err := database.New(Component.Daemon().ContextStopped(), Component.Logger(), ParamsDatabase.Tangle.Path, ParamsDatabase.UTXO.Path, deps.NetworkID, ParamsDatabase.Debug)
+		indexOptions := &database.IndexOptions{
+			DataSearch:             ParamsDatabase.Indexes.DataSearch,
+			ClusterChangeHeuristic: ParamsDatabase.Indexes.ClusterChangeHeuristic,
+			CommitmentAnchorIndex:  milestone.Index(ParamsDatabase.Indexes.CommitmentAnchorIndex),
+		}
+
+		store, err := database.New(Component.Daemon().ContextStopped(), Component.Logger(), ParamsDatabase.Tangle.Path, ParamsDatabase.UTXO.Path, ParamsDatabase.Indexes.Path, deps.NetworkID, indexOptions, ParamsDatabase.Debug)
 		if err != nil {
 			return nil, err
 		}
@@ -73,5 +81,26 @@ func run() error {
 		Component.LogPanicf("failed to start worker: %s", err)
 	}
 
+	// the indexes are built in the background, so that the startup is not blocked.
+	// the endpoints of an index are unavailable until it is built.
+	if err := Component.Daemon().BackgroundWorker("Indexes", func(ctx context.Context) {
+		Component.LogInfo("Building indexes ...")
+		if err := deps.Database.BuildIndexes(ctx); err != nil {
+			if errors.Is(err, database.ErrOperationAborted) {
+				Component.LogInfo("Building indexes ... aborted")
+
+				return
+			}
+
+			// the failed indexes stay unavailable, the other indexes and endpoints keep working
+			Component.LogErrorf("Building indexes ... failed: %s", err)
+
+			return
+		}
+		Component.LogInfo("Building indexes ... done")
+	}, daemon.PriorityStopDatabaseIndexes); err != nil {
+		Component.LogPanicf("failed to start worker: %s", err)
+	}
+
 	return nil
 }
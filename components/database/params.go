@@ -16,6 +16,17 @@ type ParametersDatabase struct {
 		Path string `default:"database/utxo" usage:"the path to the UTXO database folder"`
 	}
 
+	Indexes struct {
+		// Path defines the path to the indexes database folder.
+		Path string `default:"database/indexes" usage:"the path to the indexes database folder"`
+		// DataSearch defines whether to build the full-text search index over the UTF-8 text in indexation payloads.
+		DataSearch bool `default:"false" usage:"whether to build the full-text search index over the UTF-8 text in indexation payloads"`
+		// ClusterChangeHeuristic defines whether to add change addresses to the address clusters in addition to the common-input-ownership heuristic.
+		ClusterChangeHeuristic bool `default:"false" usage:"whether to add change addresses to the address clusters in addition to the common-input-ownership heuristic"`
+		// CommitmentAnchorIndex defines the well-known milestone index at which the milestone commitment chain is anchored.
+		CommitmentAnchorIndex uint32 `default:"0" usage:"the well-known milestone index at which the milestone commitment chain is anchored (all nodes must use the same anchor to get comparable commitments)"`
+	}
+
 	// Debug defines whether to ignore the check for corrupted databases (should only be used for debug reasons).
 	Debug bool `default:"false" usage:"ignore the check for corrupted databases (should only be used for debug reasons)"`
 }
@@ -0,0 +1,71 @@
+package labels
+
+import (
+	"context"
+
+	"go.uber.org/dig"
+
+	"github.com/iotaledger/hive.go/app"
+	"github.com/iotaledger/inx-api-core-v1/pkg/daemon"
+	"github.com/iotaledger/inx-api-core-v1/pkg/labels"
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+func init() {
+	Component = &app.Component{
+		Name:      "Labels",
+		DepsFunc:  func(cDeps dependencies) { deps = cDeps },
+		Params:    params,
+		Provide:   provide,
+		Configure: configure,
+		Run:       run,
+	}
+}
+
+type dependencies struct {
+	dig.In
+	LabelManager *labels.Manager
+}
+
+var (
+	Component *app.Component
+	deps      dependencies
+)
+
+func provide(c *dig.Container) error {
+
+	type labelsDeps struct {
+		dig.In
+		Bech32HRP iotago.NetworkPrefix `name:"bech32HRP"`
+	}
+
+	return c.Provide(func(deps labelsDeps) *labels.Manager {
+		return labels.NewManager(Component.Logger(), ParamsLabels.Directory, deps.Bech32HRP)
+	})
+}
+
+func configure() error {
+	if err := deps.LabelManager.Load(); err != nil {
+		Component.LogPanic(err)
+	}
+
+	return nil
+}
+
+func run() error {
+	if !deps.LabelManager.Enabled() {
+		return nil
+	}
+
+	if err := Component.Daemon().BackgroundWorker("Labels", func(ctx context.Context) {
+		Component.LogInfo("Watching label files for changes ...")
+		if err := deps.LabelManager.WatchChanges(ctx); err != nil {
+			Component.LogWarn(err)
+		}
+		Component.LogInfo("Stopping label file watcher ... done")
+	}, daemon.PriorityStopLabels); err != nil {
+		Component.LogPanicf("failed to start worker: %s", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package labels
+
+import (
+	"github.com/iotaledger/hive.go/app"
+)
+
+// ParametersLabels contains the definition of the parameters used by the labels.
+type ParametersLabels struct {
+	// Directory defines the path to the directory that contains the label files.
+	Directory string `default:"" usage:"the path to the directory that contains the CSV label files (address,name,category,note) which are reloaded on change (empty to disable)"`
+}
+
+var ParamsLabels = &ParametersLabels{}
+
+var params = &app.ComponentParams{
+	Params: map[string]any{
+		"labels": ParamsLabels,
+	},
+	Masked: nil,
+}
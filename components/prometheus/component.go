@@ -17,6 +17,7 @@ import (
 
 	"github.com/iotaledger/hive.go/app"
 	"github.com/iotaledger/inx-api-core-v1/pkg/daemon"
+	"github.com/iotaledger/inx-api-core-v1/pkg/database"
 )
 
 func init() {
@@ -34,6 +35,7 @@ func init() {
 type dependencies struct {
 	dig.In
 	Echo           *echo.Echo
+	LedgerStats    *database.LedgerStatsCache
 	PrometheusEcho *echo.Echo `name:"prometheusEcho"`
 }
 
@@ -127,6 +129,10 @@ func registerMetrics() *prometheus.Registry {
 		registry.MustRegister(grpcprometheus.DefaultClientMetrics)
 	}
 
+	if ParamsPrometheus.LedgerMetrics {
+		registry.MustRegister(newLedgerStatsCollector(deps.LedgerStats))
+	}
+
 	if ParamsPrometheus.RestAPIMetrics {
 		p := echoprometheus.NewPrometheus("iota_restapi", nil)
 		for _, m := range p.MetricsList {
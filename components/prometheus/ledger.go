@@ -0,0 +1,91 @@
+package prometheus
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotaledger/inx-api-core-v1/pkg/database"
+)
+
+const (
+	ledgerNamespace = "iota"
+	ledgerSubsystem = "ledger"
+)
+
+// ledgerStatsCollector exports the cached ledger statistics as gauges.
+// No metrics are exported until the statistics were computed.
+type ledgerStatsCollector struct {
+	ledgerStats *database.LedgerStatsCache
+
+	ledgerIndex                  *prometheus.Desc
+	totalSupply                  *prometheus.Desc
+	supplyInOutputs              *prometheus.Desc
+	treasuryAmount               *prometheus.Desc
+	addressesWithBalance         *prometheus.Desc
+	unspentOutputs               *prometheus.Desc
+	dustOutputs                  *prometheus.Desc
+	dustAllowanceOutputs         *prometheus.Desc
+	balanceHistogramAddresses    *prometheus.Desc
+	balanceHistogramTotalBalance *prometheus.Desc
+}
+
+func newLedgerStatsCollector(ledgerStats *database.LedgerStatsCache) *ledgerStatsCollector {
+	newDesc := func(name string, help string, variableLabels ...string) *prometheus.Desc {
+		return prometheus.NewDesc(prometheus.BuildFQName(ledgerNamespace, ledgerSubsystem, name), help, variableLabels, nil)
+	}
+
+	return &ledgerStatsCollector{
+		ledgerStats:                  ledgerStats,
+		ledgerIndex:                  newDesc("index", "The ledger index at which the statistics were computed."),
+		totalSupply:                  newDesc("total_supply", "The sum of the supply in outputs and the treasury."),
+		supplyInOutputs:              newDesc("supply_in_outputs", "The sum of the amounts of all unspent outputs."),
+		treasuryAmount:               newDesc("treasury_amount", "The amount of the unspent treasury output."),
+		addressesWithBalance:         newDesc("addresses_with_balance", "The amount of addresses with a non-zero balance."),
+		unspentOutputs:               newDesc("unspent_outputs", "The amount of unspent outputs."),
+		dustOutputs:                  newDesc("dust_outputs", "The amount of unspent outputs with an amount below the dust threshold."),
+		dustAllowanceOutputs:         newDesc("dust_allowance_outputs", "The amount of unspent dust allowance outputs."),
+		balanceHistogramAddresses:    newDesc("balance_histogram_addresses", "The amount of addresses with a balance in the bucket.", "lower_bound", "upper_bound"),
+		balanceHistogramTotalBalance: newDesc("balance_histogram_total_balance", "The sum of the balances of the addresses in the bucket.", "lower_bound", "upper_bound"),
+	}
+}
+
+func (c *ledgerStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.ledgerIndex
+	ch <- c.totalSupply
+	ch <- c.supplyInOutputs
+	ch <- c.treasuryAmount
+	ch <- c.addressesWithBalance
+	ch <- c.unspentOutputs
+	ch <- c.dustOutputs
+	ch <- c.dustAllowanceOutputs
+	ch <- c.balanceHistogramAddresses
+	ch <- c.balanceHistogramTotalBalance
+}
+
+func (c *ledgerStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := c.ledgerStats.CachedStats()
+	if stats == nil {
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(c.ledgerIndex, prometheus.GaugeValue, float64(stats.LedgerIndex))
+	ch <- prometheus.MustNewConstMetric(c.totalSupply, prometheus.GaugeValue, float64(stats.TotalSupply))
+	ch <- prometheus.MustNewConstMetric(c.supplyInOutputs, prometheus.GaugeValue, float64(stats.SupplyInOutputs))
+	ch <- prometheus.MustNewConstMetric(c.treasuryAmount, prometheus.GaugeValue, float64(stats.TreasuryAmount))
+	ch <- prometheus.MustNewConstMetric(c.addressesWithBalance, prometheus.GaugeValue, float64(stats.AddressesWithBalanceCount))
+	ch <- prometheus.MustNewConstMetric(c.unspentOutputs, prometheus.GaugeValue, float64(stats.UnspentOutputCount))
+	ch <- prometheus.MustNewConstMetric(c.dustOutputs, prometheus.GaugeValue, float64(stats.DustOutputCount))
+	ch <- prometheus.MustNewConstMetric(c.dustAllowanceOutputs, prometheus.GaugeValue, float64(stats.DustAllowanceOutputCount))
+
+	for _, bucket := range stats.BalanceHistogram {
+		lowerBound := strconv.FormatUint(bucket.LowerBound, 10)
+		upperBound := "+Inf"
+		if bucket.UpperBound != 0 {
+			upperBound = strconv.FormatUint(bucket.UpperBound, 10)
+		}
+
+		ch <- prometheus.MustNewConstMetric(c.balanceHistogramAddresses, prometheus.GaugeValue, float64(bucket.AddressCount), lowerBound, upperBound)
+		ch <- prometheus.MustNewConstMetric(c.balanceHistogramTotalBalance, prometheus.GaugeValue, float64(bucket.TotalBalance), lowerBound, upperBound)
+	}
+}
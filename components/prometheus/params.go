@@ -18,6 +18,8 @@ type ParametersPrometheus struct {
 	RestAPIMetrics bool `default:"true" usage:"whether to include restAPI metrics"`
 	// INXMetrics defines whether to include INXMetrics metrics.
 	INXMetrics bool `name:"inxMetrics" default:"true" usage:"whether to include INX metrics"`
+	// LedgerMetrics defines whether to include the ledger statistics.
+	LedgerMetrics bool `default:"true" usage:"whether to include the ledger statistics"`
 	// PromhttpMetrics defines whether to include promhttp metrics.
 	PromhttpMetrics bool `default:"false" usage:"whether to include promhttp metrics"`
 }
@@ -0,0 +1,95 @@
+package attestation
+
+import (
+	"github.com/iotaledger/inx-api-core-v1/pkg/milestone"
+)
+
+const (
+	// BundleVersion is the version of the layout of the attestation bundle.
+	BundleVersion = 1
+)
+
+// Bundle is a self-contained proof that the unspent outputs of a set of addresses
+// were created by transactions or receipts that were confirmed by signed milestones.
+// It can be verified offline with the public keys of the coordinator.
+// Whether the outputs were still unspent at the ledger index is stated by the issuer of the bundle,
+// the ledger commitment can be compared with independently computed commitments.
+type Bundle struct {
+	// The version of the layout of the bundle.
+	Version int `json:"version"`
+	// The decimal network ID of the messages.
+	NetworkID string `json:"networkId"`
+	// The ledger index at which the outputs were unspent.
+	LedgerIndex milestone.Index `json:"ledgerIndex"`
+	// The hex encoded commitment over the unspent outputs and the treasury at the ledger index.
+	LedgerCommitment string `json:"ledgerCommitment"`
+	// The attested addresses.
+	Addresses []*BundleAddress `json:"addresses"`
+	// The messages that created the outputs.
+	Messages []*BundleMessage `json:"messages"`
+	// The milestones that confirmed the messages.
+	Milestones []*BundleMilestone `json:"milestones"`
+}
+
+// BundleAddress is an attested address of the Bundle.
+type BundleAddress struct {
+	// The type of the address (0=Ed25519).
+	AddressType byte `json:"addressType"`
+	// The hex encoded address.
+	Address string `json:"address"`
+	// The sum of the amounts of the unspent outputs of the address.
+	Balance uint64 `json:"balance"`
+	// The unspent outputs of the address.
+	Outputs []*BundleOutput `json:"outputs"`
+}
+
+// BundleOutput is an unspent output of a BundleAddress.
+type BundleOutput struct {
+	// The hex encoded output ID.
+	OutputID string `json:"outputId"`
+	// The type of the output.
+	OutputType byte `json:"outputType"`
+	// The amount of the output.
+	Amount uint64 `json:"amount"`
+	// The hex encoded ID of the message that created the output.
+	MessageID string `json:"messageId"`
+	// Whether the output was created by a receipt of the migration from the legacy network.
+	// The creating message is the milestone message in that case.
+	Migrated bool `json:"migrated"`
+	// The proof that the creating message was included by the milestone. Omitted if the creating message is not available.
+	Inclusion *InclusionProof `json:"inclusion,omitempty"`
+}
+
+// InclusionProof proves that a message with a transaction was included in the ledger by a milestone.
+type InclusionProof struct {
+	// The index of the milestone that included the message.
+	MilestoneIndex milestone.Index `json:"milestoneIndex"`
+	// The position of the message in the white-flag order of the included messages of the milestone.
+	LeafIndex uint32 `json:"leafIndex"`
+	// The amount of messages with a transaction that were included by the milestone.
+	LeafCount uint32 `json:"leafCount"`
+	// The hex encoded hashes of the audit path from the message to the inclusion merkle proof of the milestone.
+	AuditPath []string `json:"auditPath"`
+}
+
+// BundleMessage is a message of the Bundle.
+type BundleMessage struct {
+	// The hex encoded message ID.
+	MessageID string `json:"messageId"`
+	// The hex encoded raw bytes of the message.
+	Message string `json:"message"`
+	// The index of the milestone that referenced the message.
+	ReferencedByMilestoneIndex milestone.Index `json:"referencedByMilestoneIndex"`
+	// The ledger inclusion state of the transaction of the message.
+	LedgerInclusionState string `json:"ledgerInclusionState"`
+}
+
+// BundleMilestone is a milestone of the Bundle.
+type BundleMilestone struct {
+	// The index of the milestone.
+	MilestoneIndex milestone.Index `json:"milestoneIndex"`
+	// The hex encoded ID of the milestone message.
+	MessageID string `json:"messageId"`
+	// The hex encoded raw bytes of the milestone message.
+	Message string `json:"message"`
+}
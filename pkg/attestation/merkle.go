@@ -0,0 +1,136 @@
+package attestation
+
+import (
+	"errors"
+	"math/bits"
+
+	"golang.org/x/crypto/blake2b"
+
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+const (
+	// leafHashPrefix is the domain separation prefix of the hash of a leaf.
+	leafHashPrefix = 0x00
+	// nodeHashPrefix is the domain separation prefix of the hash of an inner node.
+	nodeHashPrefix = 0x01
+)
+
+var (
+	ErrInvalidAuditPath = errors.New("invalid audit path")
+)
+
+/*
+
+   Inclusion merkle tree:
+   ======================
+   The inclusion merkle proof of a milestone is the root of the merkle tree (RFC 6962) over the IDs
+   of the messages with a transaction that was included in the ledger by the milestone,
+   in the order they were applied by the white-flag confirmation.
+
+   EmptyRoot = BLAKE2b-256()
+   LeafHash  = BLAKE2b-256(0x00 + MessageID)
+   NodeHash  = BLAKE2b-256(0x01 + LeftHash + RightHash)
+
+   A list with more than one element is split at the largest power of two smaller than its length.
+   An audit path contains the hashes of the siblings from the leaf to the root.
+
+*/
+
+func hashLeaf(messageID iotago.MessageID) [blake2b.Size256]byte {
+	return blake2b.Sum256(append([]byte{leafHashPrefix}, messageID[:]...))
+}
+
+func hashNode(left [blake2b.Size256]byte, right [blake2b.Size256]byte) [blake2b.Size256]byte {
+	data := make([]byte, 0, 1+2*blake2b.Size256)
+	data = append(data, nodeHashPrefix)
+	data = append(data, left[:]...)
+	data = append(data, right[:]...)
+
+	return blake2b.Sum256(data)
+}
+
+// largestPowerOfTwo returns the largest power of two smaller than n (n must be at least 2).
+func largestPowerOfTwo(n int) int {
+	return 1 << (bits.Len(uint(n-1)) - 1)
+}
+
+// MerkleRoot computes the root of the merkle tree over the given message IDs.
+func MerkleRoot(messageIDs []iotago.MessageID) [blake2b.Size256]byte {
+	switch len(messageIDs) {
+	case 0:
+		return blake2b.Sum256(nil)
+	case 1:
+		return hashLeaf(messageIDs[0])
+	default:
+		k := largestPowerOfTwo(len(messageIDs))
+
+		return hashNode(MerkleRoot(messageIDs[:k]), MerkleRoot(messageIDs[k:]))
+	}
+}
+
+// AuditPath computes the audit path of the message ID at the given index in the merkle tree over the given message IDs.
+func AuditPath(messageIDs []iotago.MessageID, index int) ([][blake2b.Size256]byte, error) {
+	if index < 0 || index >= len(messageIDs) {
+		return nil, ErrInvalidAuditPath
+	}
+
+	if len(messageIDs) == 1 {
+		return [][blake2b.Size256]byte{}, nil
+	}
+
+	k := largestPowerOfTwo(len(messageIDs))
+	if index < k {
+		path, err := AuditPath(messageIDs[:k], index)
+		if err != nil {
+			return nil, err
+		}
+
+		return append(path, MerkleRoot(messageIDs[k:])), nil
+	}
+
+	path, err := AuditPath(messageIDs[k:], index-k)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(path, MerkleRoot(messageIDs[:k])), nil
+}
+
+// MerkleRootFromAuditPath computes the root of a merkle tree with leafCount leaves
+// from the message ID at the given index and its audit path.
+func MerkleRootFromAuditPath(messageID iotago.MessageID, index uint32, leafCount uint32, path [][blake2b.Size256]byte) ([blake2b.Size256]byte, error) {
+	if index >= leafCount {
+		return [blake2b.Size256]byte{}, ErrInvalidAuditPath
+	}
+
+	// the algorithm of RFC 9162, section 2.1.3.2
+	fn := index
+	sn := leafCount - 1
+	root := hashLeaf(messageID)
+
+	for _, sibling := range path {
+		if sn == 0 {
+			return [blake2b.Size256]byte{}, ErrInvalidAuditPath
+		}
+
+		if fn&1 == 1 || fn == sn {
+			root = hashNode(sibling, root)
+			for fn&1 == 0 && fn != 0 {
+				fn >>= 1
+				sn >>= 1
+			}
+		} else {
+			root = hashNode(root, sibling)
+		}
+
+		fn >>= 1
+		sn >>= 1
+	}
+
+	if sn != 0 {
+		return [blake2b.Size256]byte{}, ErrInvalidAuditPath
+	}
+
+	return root, nil
+}
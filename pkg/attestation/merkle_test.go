@@ -0,0 +1,169 @@
+package attestation
+
+import (
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+func testMessageIDs(count int) []iotago.MessageID {
+	messageIDs := make([]iotago.MessageID, count)
+	for i := range messageIDs {
+		messageIDs[i] = blake2b.Sum256([]byte{byte(i)})
+	}
+
+	return messageIDs
+}
+
+func mustMessageIDFromHex(t *testing.T, messageIDHex string) iotago.MessageID {
+	t.Helper()
+
+	messageIDBytes, err := hex.DecodeString(messageIDHex)
+	require.NoError(t, err)
+	require.Len(t, messageIDBytes, iotago.MessageIDLength)
+
+	var messageID iotago.MessageID
+	copy(messageID[:], messageIDBytes)
+
+	return messageID
+}
+
+// TestMerkleRootGoldenVector checks the root against the inclusion merkle proof test vector of the Chrysalis white-flag confirmation
+// (https://github.com/Wollac/iota-crypto-demo/tree/master/examples/merkle).
+func TestMerkleRootGoldenVector(t *testing.T) {
+	messageIDs := []iotago.MessageID{
+		mustMessageIDFromHex(t, "52fdfc072182654f163f5f0f9a621d729566c74d10037c4d7bbb0407d1e2c649"),
+		mustMessageIDFromHex(t, "81855ad8681d0d86d1e91e00167939cb6694d2c422acd208a0072939487f6999"),
+		mustMessageIDFromHex(t, "eb9d18a44784045d87f3c67cf22746e995af5a25367951baa2ff6cd471c483f1"),
+		mustMessageIDFromHex(t, "5fb90badb37c5821b6d95526a41a9504680b4e7c8b763a1b1d49d4955c848621"),
+		mustMessageIDFromHex(t, "6325253fec738dd7a9e28bf921119c160f0702448615bbda08313f6a8eb668d2"),
+		mustMessageIDFromHex(t, "0bf5059875921e668a5bdf2c7fc4844592d2572bcd0668d2d6c52f5054e2d083"),
+		mustMessageIDFromHex(t, "6bf84c7174cb7476364cc3dbd968b0f7172ed85794bb358b0c3b525da1786f9f"),
+	}
+	expectedRoot := "bf67ce7ba23e8c0951b5abaec4f5524360d2c26d971ff226d3359fa70cdb0beb"
+
+	root := MerkleRoot(messageIDs)
+	require.Equal(t, expectedRoot, hex.EncodeToString(root[:]))
+
+	for i, messageID := range messageIDs {
+		path, err := AuditPath(messageIDs, i)
+		require.NoError(t, err)
+
+		rootFromPath, err := MerkleRootFromAuditPath(messageID, uint32(i), uint32(len(messageIDs)), path)
+		require.NoError(t, err)
+		require.Equal(t, expectedRoot, hex.EncodeToString(rootFromPath[:]))
+	}
+}
+
+func TestMerkleRootEmpty(t *testing.T) {
+	require.Equal(t, blake2b.Sum256(nil), MerkleRoot(nil))
+
+	_, err := AuditPath(nil, 0)
+	require.ErrorIs(t, err, ErrInvalidAuditPath)
+
+	_, err = MerkleRootFromAuditPath(iotago.MessageID{}, 0, 0, nil)
+	require.ErrorIs(t, err, ErrInvalidAuditPath)
+}
+
+// auditPathDirections returns for every level of a tree with leafCount leaves, from the root to the leaf,
+// whether the leaf at the given index is in the left subtree.
+func auditPathDirections(index int, leafCount int) []bool {
+	directions := make([]bool, 0)
+	for leafCount > 1 {
+		k := largestPowerOfTwo(leafCount)
+		if index < k {
+			leafCount = k
+			directions = append(directions, true)
+		} else {
+			index -= k
+			leafCount -= k
+			directions = append(directions, false)
+		}
+	}
+
+	return directions
+}
+
+// requireInvalidRoot checks that the root computed from the audit path is either rejected or doesn't match the expected root.
+func requireInvalidRoot(t *testing.T, expectedRoot [blake2b.Size256]byte, messageID iotago.MessageID, index uint32, leafCount uint32, path [][blake2b.Size256]byte) {
+	t.Helper()
+
+	root, err := MerkleRootFromAuditPath(messageID, index, leafCount, path)
+	if err != nil {
+		require.ErrorIs(t, err, ErrInvalidAuditPath)
+
+		return
+	}
+	require.NotEqual(t, expectedRoot, root)
+}
+
+func TestAuditPath(t *testing.T) {
+	for leafCount := 1; leafCount <= 17; leafCount++ {
+		messageIDs := testMessageIDs(leafCount)
+		expectedRoot := MerkleRoot(messageIDs)
+
+		for index := 0; index < leafCount; index++ {
+			t.Run(fmt.Sprintf("%d/%d", index, leafCount), func(t *testing.T) {
+				path, err := AuditPath(messageIDs, index)
+				require.NoError(t, err)
+				require.Len(t, path, len(auditPathDirections(index, leafCount)))
+
+				root, err := MerkleRootFromAuditPath(messageIDs[index], uint32(index), uint32(leafCount), path)
+				require.NoError(t, err)
+				require.Equal(t, expectedRoot, root)
+
+				// a tampered sibling changes the root
+				for i := range path {
+					tampered := make([][blake2b.Size256]byte, len(path))
+					copy(tampered, path)
+					tampered[i][0] ^= 0xff
+					requireInvalidRoot(t, expectedRoot, messageIDs[index], uint32(index), uint32(leafCount), tampered)
+				}
+
+				// a truncated or extended path is rejected or changes the root
+				if len(path) > 0 {
+					requireInvalidRoot(t, expectedRoot, messageIDs[index], uint32(index), uint32(leafCount), path[:len(path)-1])
+				}
+				requireInvalidRoot(t, expectedRoot, messageIDs[index], uint32(index), uint32(leafCount), append(path, expectedRoot))
+
+				// the path only proves the message at its index
+				requireInvalidRoot(t, expectedRoot, blake2b.Sum256([]byte("foreign")), uint32(index), uint32(leafCount), path)
+				for otherIndex := 0; otherIndex < leafCount; otherIndex++ {
+					if otherIndex == index {
+						continue
+					}
+					requireInvalidRoot(t, expectedRoot, messageIDs[index], uint32(otherIndex), uint32(leafCount), path)
+					requireInvalidRoot(t, expectedRoot, messageIDs[otherIndex], uint32(otherIndex), uint32(leafCount), path)
+				}
+
+				// a wrong leaf count is rejected or changes the root, unless the leaf is on the same side of every node
+				// from the leaf to the root, in which case the siblings are hashed in the same order and the message is still proven
+				for _, wrongLeafCount := range []int{leafCount - 1, leafCount + 1, 2 * leafCount} {
+					if index < wrongLeafCount && slices.Equal(auditPathDirections(index, leafCount), auditPathDirections(index, wrongLeafCount)) {
+						root, err := MerkleRootFromAuditPath(messageIDs[index], uint32(index), uint32(wrongLeafCount), path)
+						require.NoError(t, err)
+						require.Equal(t, expectedRoot, root)
+
+						continue
+					}
+					requireInvalidRoot(t, expectedRoot, messageIDs[index], uint32(index), uint32(wrongLeafCount), path)
+				}
+			})
+		}
+
+		_, err := AuditPath(messageIDs, -1)
+		require.ErrorIs(t, err, ErrInvalidAuditPath)
+
+		_, err = AuditPath(messageIDs, leafCount)
+		require.ErrorIs(t, err, ErrInvalidAuditPath)
+
+		_, err = MerkleRootFromAuditPath(messageIDs[0], uint32(leafCount), uint32(leafCount), nil)
+		require.ErrorIs(t, err, ErrInvalidAuditPath)
+	}
+}
@@ -0,0 +1,431 @@
+package attestation
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/iotaledger/hive.go/serializer"
+	"github.com/iotaledger/inx-api-core-v1/pkg/milestone"
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+const (
+	// VerificationCheckBundle checks that the bundle has a supported version and network ID.
+	VerificationCheckBundle = "bundle"
+	// VerificationCheckMilestone checks that a milestone message is well-formed and signed by the applicable coordinator keys.
+	VerificationCheckMilestone = "milestone"
+	// VerificationCheckMessage checks that a message is well-formed and matches its message ID.
+	VerificationCheckMessage = "message"
+	// VerificationCheckOutput checks that an output was created by its message with the stated address, type and amount.
+	VerificationCheckOutput = "output"
+	// VerificationCheckInclusion checks that the message that created an output was included by the stated milestone.
+	VerificationCheckInclusion = "inclusion"
+	// VerificationCheckBalance checks that the balance of an address is the sum of its outputs.
+	VerificationCheckBalance = "balance"
+	// VerificationCheckUnproven reports outputs without an inclusion proof.
+	VerificationCheckUnproven = "unproven"
+	// VerificationCheckDuplicate checks that every address and every output is attested only once.
+	VerificationCheckDuplicate = "duplicate"
+)
+
+// PublicKeyRange is a public key of the coordinator and the range of milestones it is applicable for.
+type PublicKeyRange struct {
+	// The hex encoded ed25519 public key.
+	Key string `json:"key"`
+	// The first milestone index the key is applicable for.
+	StartIndex milestone.Index `json:"start"`
+	// The last milestone index the key is applicable for. The key has no end if it equals the start index.
+	EndIndex milestone.Index `json:"end"`
+}
+
+// CoordinatorKeys are the public keys of the coordinator, in the layout of the protocol parameters of the node.
+type CoordinatorKeys struct {
+	// The amount of public keys that must sign a milestone.
+	MilestonePublicKeyCount int `json:"milestonePublicKeyCount"`
+	// The public keys and the ranges of milestones they are applicable for.
+	PublicKeyRanges []*PublicKeyRange `json:"publicKeyRanges"`
+}
+
+// PublicKeysForMilestoneIndex returns the public keys that are applicable for the given milestone index.
+func (k *CoordinatorKeys) PublicKeysForMilestoneIndex(msIndex milestone.Index) (iotago.MilestonePublicKeySet, error) {
+	publicKeys := make(iotago.MilestonePublicKeySet)
+
+	for _, keyRange := range k.PublicKeyRanges {
+		if msIndex < keyRange.StartIndex || (msIndex > keyRange.EndIndex && keyRange.StartIndex != keyRange.EndIndex) {
+			continue
+		}
+
+		keyBytes, err := hex.DecodeString(keyRange.Key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key: %s, error: %w", keyRange.Key, err)
+		}
+
+		var publicKey iotago.MilestonePublicKey
+		if len(keyBytes) != len(publicKey) {
+			return nil, fmt.Errorf("invalid public key length: %s", keyRange.Key)
+		}
+		copy(publicKey[:], keyBytes)
+
+		publicKeys[publicKey] = struct{}{}
+	}
+
+	return publicKeys, nil
+}
+
+// VerificationIssue is a failed check of the verification.
+type VerificationIssue struct {
+	// The name of the failed check.
+	Check string `json:"check"`
+	// The hex encoded ID of the affected message, milestone message or output.
+	ID string `json:"id"`
+	// The description of the issue.
+	Message string `json:"message"`
+}
+
+// VerificationReport is the result of the verification of a Bundle.
+type VerificationReport struct {
+	// The ledger index stated by the bundle.
+	LedgerIndex milestone.Index `json:"ledgerIndex"`
+	// The amount of milestones with valid signatures.
+	VerifiedMilestoneCount int `json:"verifiedMilestoneCount"`
+	// The amount of attested addresses.
+	AddressCount int `json:"addressCount"`
+	// The amount of outputs in the bundle.
+	OutputCount int `json:"outputCount"`
+	// The amount of outputs whose creation was proven.
+	ProvenOutputCount int `json:"provenOutputCount"`
+	// The sum of the amounts of the outputs whose creation was proven.
+	ProvenAmount uint64 `json:"provenAmount"`
+	// The sum of the amounts of the outputs whose creation was not proven.
+	UnprovenAmount uint64 `json:"unprovenAmount"`
+	// The failed checks.
+	Issues []*VerificationIssue `json:"issues"`
+	// Whether the creation of all outputs was proven.
+	// It doesn't prove that the outputs were unspent at the ledger index, see UnspentVerified.
+	Valid bool `json:"valid"`
+	// Whether the outputs were verified to be unspent at the ledger index. This is always false,
+	// the ledger commitment of the bundle can't be checked offline and only states what the issuer claims.
+	UnspentVerified bool `json:"unspentVerified"`
+}
+
+// verifiedMilestone is a milestone with valid signatures.
+type verifiedMilestone struct {
+	messageID iotago.MessageID
+	milestone *iotago.Milestone
+}
+
+// messageFromHex deserializes a message and checks that it matches the message ID and the network ID.
+func messageFromHex(messageHex string, messageIDHex string, networkID uint64) (*iotago.Message, iotago.MessageID, error) {
+	data, err := hex.DecodeString(messageHex)
+	if err != nil {
+		return nil, iotago.MessageID{}, fmt.Errorf("invalid message encoding: %w", err)
+	}
+
+	msg := &iotago.Message{}
+	if _, err := msg.Deserialize(data, serializer.DeSeriModePerformValidation); err != nil {
+		return nil, iotago.MessageID{}, fmt.Errorf("invalid message: %w", err)
+	}
+
+	messageID := iotago.MessageID(blake2b.Sum256(data))
+	if hex.EncodeToString(messageID[:]) != messageIDHex {
+		return nil, iotago.MessageID{}, fmt.Errorf("message ID %s doesn't match the message", messageIDHex)
+	}
+
+	if msg.NetworkID != networkID {
+		return nil, iotago.MessageID{}, fmt.Errorf("network ID %d doesn't match the bundle", msg.NetworkID)
+	}
+
+	return msg, messageID, nil
+}
+
+// outputAddressTypeAmount returns the address, the type and the amount of a transaction output.
+func outputAddressTypeAmount(output serializer.Serializable) (iotago.Address, iotago.OutputType, uint64, error) {
+	switch o := output.(type) {
+	case *iotago.SigLockedSingleOutput:
+		address, ok := o.Address.(iotago.Address)
+		if !ok {
+			return nil, 0, 0, fmt.Errorf("unsupported address type: %T", o.Address)
+		}
+
+		return address, iotago.OutputSigLockedSingleOutput, o.Amount, nil
+	case *iotago.SigLockedDustAllowanceOutput:
+		address, ok := o.Address.(iotago.Address)
+		if !ok {
+			return nil, 0, 0, fmt.Errorf("unsupported address type: %T", o.Address)
+		}
+
+		return address, iotago.OutputSigLockedDustAllowanceOutput, o.Amount, nil
+	default:
+		return nil, 0, 0, fmt.Errorf("unsupported output type: %T", output)
+	}
+}
+
+// Verify checks the bundle against the public keys of the coordinator.
+// It proves that every output was created by a message that was confirmed by a milestone signed by the coordinator,
+// it doesn't prove that the outputs were still unspent at the ledger index.
+func Verify(bundle *Bundle, keys *CoordinatorKeys) *VerificationReport {
+	report := &VerificationReport{
+		LedgerIndex:  bundle.LedgerIndex,
+		AddressCount: len(bundle.Addresses),
+		Issues:       make([]*VerificationIssue, 0),
+	}
+
+	addIssue := func(check string, id string, format string, args ...interface{}) {
+		report.Issues = append(report.Issues, &VerificationIssue{
+			Check:   check,
+			ID:      id,
+			Message: fmt.Sprintf(format, args...),
+		})
+	}
+
+	if bundle.Version != BundleVersion {
+		addIssue(VerificationCheckBundle, "", "unsupported bundle version: %d", bundle.Version)
+
+		return report
+	}
+
+	networkID, err := strconv.ParseUint(bundle.NetworkID, 10, 64)
+	if err != nil {
+		addIssue(VerificationCheckBundle, "", "invalid network ID: %s", bundle.NetworkID)
+
+		return report
+	}
+
+	milestones := make(map[milestone.Index]*verifiedMilestone)
+	for _, bundleMilestone := range bundle.Milestones {
+		msg, messageID, err := messageFromHex(bundleMilestone.Message, bundleMilestone.MessageID, networkID)
+		if err != nil {
+			addIssue(VerificationCheckMilestone, bundleMilestone.MessageID, "%s", err)
+
+			continue
+		}
+
+		ms, ok := msg.Payload.(*iotago.Milestone)
+		if !ok {
+			addIssue(VerificationCheckMilestone, bundleMilestone.MessageID, "message contains no milestone payload")
+
+			continue
+		}
+
+		if milestone.Index(ms.Index) != bundleMilestone.MilestoneIndex {
+			addIssue(VerificationCheckMilestone, bundleMilestone.MessageID, "milestone index %d doesn't match %d", ms.Index, bundleMilestone.MilestoneIndex)
+
+			continue
+		}
+
+		publicKeys, err := keys.PublicKeysForMilestoneIndex(bundleMilestone.MilestoneIndex)
+		if err != nil {
+			addIssue(VerificationCheckMilestone, bundleMilestone.MessageID, "%s", err)
+
+			continue
+		}
+
+		if err := ms.VerifySignatures(keys.MilestonePublicKeyCount, publicKeys); err != nil {
+			addIssue(VerificationCheckMilestone, bundleMilestone.MessageID, "invalid milestone signatures: %s", err)
+
+			continue
+		}
+
+		milestones[bundleMilestone.MilestoneIndex] = &verifiedMilestone{
+			messageID: messageID,
+			milestone: ms,
+		}
+	}
+	report.VerifiedMilestoneCount = len(milestones)
+
+	messages := make(map[string]*iotago.Message)
+	referencedIndexes := make(map[string]milestone.Index)
+	for _, bundleMessage := range bundle.Messages {
+		msg, _, err := messageFromHex(bundleMessage.Message, bundleMessage.MessageID, networkID)
+		if err != nil {
+			addIssue(VerificationCheckMessage, bundleMessage.MessageID, "%s", err)
+
+			continue
+		}
+
+		messages[bundleMessage.MessageID] = msg
+		referencedIndexes[bundleMessage.MessageID] = bundleMessage.ReferencedByMilestoneIndex
+	}
+
+	// verifyOutput returns nil if the output was created by a message that was confirmed by a verified milestone.
+	verifyOutput := func(bundleAddress *BundleAddress, output *BundleOutput) (string, error) {
+		outputIDBytes, err := hex.DecodeString(output.OutputID)
+		if err != nil || len(outputIDBytes) != iotago.TransactionIDLength+serializer.UInt16ByteSize {
+			return VerificationCheckOutput, fmt.Errorf("invalid output ID")
+		}
+		outputIndex := int(binary.LittleEndian.Uint16(outputIDBytes[iotago.TransactionIDLength:]))
+
+		verified, exists := milestones[output.Inclusion.MilestoneIndex]
+		if !exists {
+			return VerificationCheckInclusion, fmt.Errorf("milestone %d was not verified", output.Inclusion.MilestoneIndex)
+		}
+
+		var address iotago.Address
+		var outputType iotago.OutputType
+		var amount uint64
+
+		if output.Migrated {
+			// migrated funds are created by the receipt of the milestone, the output ID is derived from the milestone ID
+			if output.MessageID != hex.EncodeToString(verified.messageID[:]) {
+				return VerificationCheckInclusion, fmt.Errorf("message %s is not the milestone message", output.MessageID)
+			}
+
+			milestoneID, err := verified.milestone.ID()
+			if err != nil {
+				return VerificationCheckOutput, err
+			}
+
+			if !bytes.Equal(outputIDBytes[:iotago.TransactionIDLength], milestoneID[:]) {
+				return VerificationCheckOutput, fmt.Errorf("output ID doesn't match the milestone ID")
+			}
+
+			receipt, ok := verified.milestone.Receipt.(*iotago.Receipt)
+			if !ok || outputIndex >= len(receipt.Funds) {
+				return VerificationCheckOutput, fmt.Errorf("milestone contains no migrated funds entry %d", outputIndex)
+			}
+
+			entry, ok := receipt.Funds[outputIndex].(*iotago.MigratedFundsEntry)
+			if !ok {
+				return VerificationCheckOutput, fmt.Errorf("funds entry %d is not a migrated funds entry", outputIndex)
+			}
+
+			if address, ok = entry.Address.(iotago.Address); !ok {
+				return VerificationCheckOutput, fmt.Errorf("unsupported address type: %T", entry.Address)
+			}
+			outputType = iotago.OutputSigLockedSingleOutput
+			amount = entry.Deposit
+		} else {
+			msg, exists := messages[output.MessageID]
+			if !exists {
+				return VerificationCheckMessage, fmt.Errorf("message %s is not part of the bundle", output.MessageID)
+			}
+
+			if referencedIndexes[output.MessageID] != output.Inclusion.MilestoneIndex {
+				return VerificationCheckInclusion, fmt.Errorf("message %s was referenced by milestone %d", output.MessageID, referencedIndexes[output.MessageID])
+			}
+
+			tx, ok := msg.Payload.(*iotago.Transaction)
+			if !ok {
+				return VerificationCheckOutput, fmt.Errorf("message %s contains no transaction", output.MessageID)
+			}
+
+			txID, err := tx.ID()
+			if err != nil {
+				return VerificationCheckOutput, err
+			}
+
+			if !bytes.Equal(outputIDBytes[:iotago.TransactionIDLength], txID[:]) {
+				return VerificationCheckOutput, fmt.Errorf("output ID doesn't match the transaction ID")
+			}
+
+			essence, ok := tx.Essence.(*iotago.TransactionEssence)
+			if !ok || outputIndex >= len(essence.Outputs) {
+				return VerificationCheckOutput, fmt.Errorf("transaction contains no output %d", outputIndex)
+			}
+
+			if address, outputType, amount, err = outputAddressTypeAmount(essence.Outputs[outputIndex]); err != nil {
+				return VerificationCheckOutput, err
+			}
+
+			messageIDBytes, err := hex.DecodeString(output.MessageID)
+			if err != nil {
+				return VerificationCheckMessage, err
+			}
+
+			auditPath := make([][blake2b.Size256]byte, len(output.Inclusion.AuditPath))
+			for i, hashHex := range output.Inclusion.AuditPath {
+				hash, err := hex.DecodeString(hashHex)
+				if err != nil || len(hash) != blake2b.Size256 {
+					return VerificationCheckInclusion, ErrInvalidAuditPath
+				}
+				copy(auditPath[i][:], hash)
+			}
+
+			var messageID iotago.MessageID
+			copy(messageID[:], messageIDBytes)
+
+			root, err := MerkleRootFromAuditPath(messageID, output.Inclusion.LeafIndex, output.Inclusion.LeafCount, auditPath)
+			if err != nil {
+				return VerificationCheckInclusion, err
+			}
+
+			if root != verified.milestone.InclusionMerkleProof {
+				return VerificationCheckInclusion, fmt.Errorf("audit path doesn't match the inclusion merkle proof of milestone %d", output.Inclusion.MilestoneIndex)
+			}
+		}
+
+		if address.Type() != bundleAddress.AddressType || address.String() != bundleAddress.Address {
+			return VerificationCheckOutput, fmt.Errorf("address %s doesn't match", address.String())
+		}
+
+		if outputType != output.OutputType || amount != output.Amount {
+			return VerificationCheckOutput, fmt.Errorf("output type %d and amount %d don't match", outputType, amount)
+		}
+
+		return "", nil
+	}
+
+	addresses := make(map[string]struct{})
+	outputIDs := make(map[string]struct{})
+	for _, bundleAddress := range bundle.Addresses {
+		addressKey := fmt.Sprintf("%d:%s", bundleAddress.AddressType, bundleAddress.Address)
+		if _, exists := addresses[addressKey]; exists {
+			// the outputs of a duplicated address would be counted twice
+			for _, output := range bundleAddress.Outputs {
+				report.OutputCount++
+				report.UnprovenAmount += output.Amount
+			}
+			addIssue(VerificationCheckDuplicate, bundleAddress.Address, "address is attested more than once")
+
+			continue
+		}
+		addresses[addressKey] = struct{}{}
+
+		var balance uint64
+		for _, output := range bundleAddress.Outputs {
+			report.OutputCount++
+			balance += output.Amount
+
+			// the hex decoding of the output ID is case-insensitive
+			outputIDKey := strings.ToLower(output.OutputID)
+			if _, exists := outputIDs[outputIDKey]; exists {
+				report.UnprovenAmount += output.Amount
+				addIssue(VerificationCheckDuplicate, output.OutputID, "output is attested more than once")
+
+				continue
+			}
+			outputIDs[outputIDKey] = struct{}{}
+
+			if output.Inclusion == nil {
+				report.UnprovenAmount += output.Amount
+				addIssue(VerificationCheckUnproven, output.OutputID, "output contains no inclusion proof")
+
+				continue
+			}
+
+			if check, err := verifyOutput(bundleAddress, output); err != nil {
+				report.UnprovenAmount += output.Amount
+				addIssue(check, output.OutputID, "%s", err)
+
+				continue
+			}
+
+			report.ProvenOutputCount++
+			report.ProvenAmount += output.Amount
+		}
+
+		if balance != bundleAddress.Balance {
+			addIssue(VerificationCheckBalance, bundleAddress.Address, "balance %d doesn't match the sum of the outputs %d", bundleAddress.Balance, balance)
+		}
+	}
+
+	report.Valid = len(report.Issues) == 0
+
+	return report
+}
@@ -0,0 +1,409 @@
+package attestation
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/iotaledger/hive.go/serializer"
+	"github.com/iotaledger/inx-api-core-v1/pkg/milestone"
+	iotago "github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/ed25519"
+)
+
+const (
+	testNetworkID      uint64          = 1454675179895816119
+	testMilestoneIndex milestone.Index = 5
+	testTransferAmount uint64          = 2_000_000
+	testMigratedAmount uint64          = 3_000_000
+)
+
+func testPrivateKey(seed byte) ed25519.PrivateKey {
+	seedHash := blake2b.Sum256([]byte{seed})
+
+	return ed25519.NewKeyFromSeed(seedHash[:])
+}
+
+func testEd25519Address(seed byte) *iotago.Ed25519Address {
+	address := iotago.AddressFromEd25519PubKey(testPrivateKey(seed).Public().(ed25519.PublicKey))
+
+	return &address
+}
+
+func serializeTestMessage(t *testing.T, parents iotago.MessageIDs, payload serializer.Serializable) ([]byte, iotago.MessageID) {
+	t.Helper()
+
+	msg := &iotago.Message{
+		NetworkID: testNetworkID,
+		Parents:   parents,
+		Payload:   payload,
+	}
+
+	data, err := msg.Serialize(serializer.DeSeriModePerformValidation)
+	require.NoError(t, err)
+
+	return data, blake2b.Sum256(data)
+}
+
+// testMilestoneMessage returns a milestone message signed by the given coordinator key
+// that includes the given messages and migrates funds to the given address.
+func testMilestoneMessage(t *testing.T, coordinatorKey ed25519.PrivateKey, includedMessageIDs []iotago.MessageID, migratedAddress *iotago.Ed25519Address) ([]byte, iotago.MessageID, *iotago.Milestone) {
+	t.Helper()
+
+	var publicKey iotago.MilestonePublicKey
+	copy(publicKey[:], coordinatorKey.Public().(ed25519.PublicKey))
+
+	ms, err := iotago.NewMilestone(uint32(testMilestoneIndex), 1000, iotago.MilestoneParentMessageIDs{includedMessageIDs[0]}, MerkleRoot(includedMessageIDs), []iotago.MilestonePublicKey{publicKey})
+	require.NoError(t, err)
+
+	ms.Receipt = &iotago.Receipt{
+		MigratedAt: 100,
+		Final:      false,
+		Funds: serializer.Serializables{
+			&iotago.MigratedFundsEntry{
+				TailTransactionHash: iotago.LegacyTailTransactionHash{1},
+				Address:             migratedAddress,
+				Deposit:             testMigratedAmount,
+			},
+		},
+		Transaction: &iotago.TreasuryTransaction{
+			Input:  &iotago.TreasuryInput{1},
+			Output: &iotago.TreasuryOutput{Amount: 1_000_000_000},
+		},
+	}
+
+	require.NoError(t, ms.Sign(iotago.InMemoryEd25519MilestoneSigner(iotago.MilestonePublicKeyMapping{publicKey: coordinatorKey})))
+
+	data, messageID := serializeTestMessage(t, iotago.MessageIDs{includedMessageIDs[0]}, ms)
+
+	return data, messageID, ms
+}
+
+// newTestBundle returns a bundle that attests an output of a transaction and a migrated output,
+// both confirmed by a milestone that is signed by the returned coordinator keys.
+func newTestBundle(t *testing.T) (*Bundle, *CoordinatorKeys) {
+	t.Helper()
+
+	coordinatorKey := testPrivateKey(0)
+	senderAddress := testEd25519Address(1)
+	receiverAddress := testEd25519Address(2)
+	migratedAddress := testEd25519Address(3)
+
+	tx, err := iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{
+			Address: senderAddress,
+			Input:   &iotago.UTXOInput{TransactionID: blake2b.Sum256([]byte("input"))},
+		}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: receiverAddress, Amount: testTransferAmount}).
+		Build(iotago.NewInMemoryAddressSigner(iotago.NewAddressKeysForEd25519Address(senderAddress, testPrivateKey(1))))
+	require.NoError(t, err)
+
+	txID, err := tx.ID()
+	require.NoError(t, err)
+
+	txMessage, txMessageID := serializeTestMessage(t, iotago.MessageIDs{blake2b.Sum256([]byte("parent"))}, tx)
+
+	// the transaction is the second of three included messages, so its audit path is not trivial
+	includedMessageIDs := []iotago.MessageID{blake2b.Sum256([]byte("first")), txMessageID, blake2b.Sum256([]byte("third"))}
+	msMessage, msMessageID, ms := testMilestoneMessage(t, coordinatorKey, includedMessageIDs, migratedAddress)
+
+	milestoneID, err := ms.ID()
+	require.NoError(t, err)
+
+	auditPath, err := AuditPath(includedMessageIDs, 1)
+	require.NoError(t, err)
+	auditPathHex := make([]string, len(auditPath))
+	for i, hash := range auditPath {
+		auditPathHex[i] = hex.EncodeToString(hash[:])
+	}
+
+	outputID := func(id []byte, index uint16) string {
+		outputIDBytes := make([]byte, iotago.TransactionIDLength+serializer.UInt16ByteSize)
+		copy(outputIDBytes, id)
+		binary.LittleEndian.PutUint16(outputIDBytes[iotago.TransactionIDLength:], index)
+
+		return hex.EncodeToString(outputIDBytes)
+	}
+
+	bundle := &Bundle{
+		Version:          BundleVersion,
+		NetworkID:        strconv.FormatUint(testNetworkID, 10),
+		LedgerIndex:      testMilestoneIndex,
+		LedgerCommitment: hex.EncodeToString(make([]byte, 32)),
+		Addresses: []*BundleAddress{
+			{
+				AddressType: iotago.AddressEd25519,
+				Address:     receiverAddress.String(),
+				Balance:     testTransferAmount,
+				Outputs: []*BundleOutput{
+					{
+						OutputID:   outputID(txID[:], 0),
+						OutputType: iotago.OutputSigLockedSingleOutput,
+						Amount:     testTransferAmount,
+						MessageID:  hex.EncodeToString(txMessageID[:]),
+						Inclusion: &InclusionProof{
+							MilestoneIndex: testMilestoneIndex,
+							LeafIndex:      1,
+							LeafCount:      uint32(len(includedMessageIDs)),
+							AuditPath:      auditPathHex,
+						},
+					},
+				},
+			},
+			{
+				AddressType: iotago.AddressEd25519,
+				Address:     migratedAddress.String(),
+				Balance:     testMigratedAmount,
+				Outputs: []*BundleOutput{
+					{
+						OutputID:   outputID(milestoneID[:], 0),
+						OutputType: iotago.OutputSigLockedSingleOutput,
+						Amount:     testMigratedAmount,
+						MessageID:  hex.EncodeToString(msMessageID[:]),
+						Migrated:   true,
+						Inclusion: &InclusionProof{
+							MilestoneIndex: testMilestoneIndex,
+							AuditPath:      []string{},
+						},
+					},
+				},
+			},
+		},
+		Messages: []*BundleMessage{
+			{
+				MessageID:                  hex.EncodeToString(txMessageID[:]),
+				Message:                    hex.EncodeToString(txMessage),
+				ReferencedByMilestoneIndex: testMilestoneIndex,
+				LedgerInclusionState:       "included",
+			},
+		},
+		Milestones: []*BundleMilestone{
+			{
+				MilestoneIndex: testMilestoneIndex,
+				MessageID:      hex.EncodeToString(msMessageID[:]),
+				Message:        hex.EncodeToString(msMessage),
+			},
+		},
+	}
+
+	keys := &CoordinatorKeys{
+		MilestonePublicKeyCount: 1,
+		PublicKeyRanges: []*PublicKeyRange{
+			{
+				Key:        hex.EncodeToString(coordinatorKey.Public().(ed25519.PublicKey)),
+				StartIndex: 1,
+				EndIndex:   1,
+			},
+		},
+	}
+
+	return bundle, keys
+}
+
+// issueChecks returns the names of the failed checks of the report.
+func issueChecks(report *VerificationReport) []string {
+	checks := make([]string, len(report.Issues))
+	for i, issue := range report.Issues {
+		checks[i] = issue.Check
+	}
+
+	return checks
+}
+
+func TestVerify(t *testing.T) {
+	bundle, keys := newTestBundle(t)
+
+	report := Verify(bundle, keys)
+	require.Empty(t, report.Issues)
+	require.True(t, report.Valid)
+	require.False(t, report.UnspentVerified)
+	require.Equal(t, testMilestoneIndex, report.LedgerIndex)
+	require.Equal(t, 1, report.VerifiedMilestoneCount)
+	require.Equal(t, 2, report.AddressCount)
+	require.Equal(t, 2, report.OutputCount)
+	require.Equal(t, 2, report.ProvenOutputCount)
+	require.Equal(t, testTransferAmount+testMigratedAmount, report.ProvenAmount)
+	require.Zero(t, report.UnprovenAmount)
+}
+
+func TestVerifyForgedBundles(t *testing.T) {
+	tests := []struct {
+		name string
+		// forge modifies the valid bundle or the coordinator keys
+		forge func(bundle *Bundle, keys *CoordinatorKeys)
+		// the expected failed checks
+		checks []string
+	}{
+		{
+			name: "unsupported version",
+			forge: func(bundle *Bundle, _ *CoordinatorKeys) {
+				bundle.Version = BundleVersion + 1
+			},
+			checks: []string{VerificationCheckBundle},
+		},
+		{
+			name: "milestone signed by another coordinator",
+			forge: func(_ *Bundle, keys *CoordinatorKeys) {
+				keys.PublicKeyRanges[0].Key = hex.EncodeToString(testPrivateKey(9).Public().(ed25519.PublicKey))
+			},
+			checks: []string{VerificationCheckMilestone, VerificationCheckInclusion, VerificationCheckInclusion},
+		},
+		{
+			name: "milestone outside of the range of the coordinator key",
+			forge: func(_ *Bundle, keys *CoordinatorKeys) {
+				keys.PublicKeyRanges[0].StartIndex = testMilestoneIndex + 1
+				keys.PublicKeyRanges[0].EndIndex = testMilestoneIndex + 1
+			},
+			checks: []string{VerificationCheckMilestone, VerificationCheckInclusion, VerificationCheckInclusion},
+		},
+		{
+			name: "milestone with a wrong index",
+			forge: func(bundle *Bundle, _ *CoordinatorKeys) {
+				bundle.Milestones[0].MilestoneIndex = testMilestoneIndex + 1
+			},
+			checks: []string{VerificationCheckMilestone, VerificationCheckInclusion, VerificationCheckInclusion},
+		},
+		{
+			name: "tampered message",
+			forge: func(bundle *Bundle, _ *CoordinatorKeys) {
+				message := []byte(bundle.Messages[0].Message)
+				message[len(message)-1] ^= 0x01
+				bundle.Messages[0].Message = string(message)
+			},
+			checks: []string{VerificationCheckMessage, VerificationCheckMessage},
+		},
+		{
+			name: "forged amount",
+			forge: func(bundle *Bundle, _ *CoordinatorKeys) {
+				bundle.Addresses[0].Outputs[0].Amount *= 2
+				bundle.Addresses[0].Balance *= 2
+			},
+			checks: []string{VerificationCheckOutput},
+		},
+		{
+			name: "forged migrated amount",
+			forge: func(bundle *Bundle, _ *CoordinatorKeys) {
+				bundle.Addresses[1].Outputs[0].Amount++
+				bundle.Addresses[1].Balance++
+			},
+			checks: []string{VerificationCheckOutput},
+		},
+		{
+			name: "forged balance",
+			forge: func(bundle *Bundle, _ *CoordinatorKeys) {
+				bundle.Addresses[0].Balance++
+			},
+			checks: []string{VerificationCheckBalance},
+		},
+		{
+			name: "output attested for another address",
+			forge: func(bundle *Bundle, _ *CoordinatorKeys) {
+				bundle.Addresses[0].Address = testEd25519Address(9).String()
+			},
+			checks: []string{VerificationCheckOutput},
+		},
+		{
+			name: "tampered audit path",
+			forge: func(bundle *Bundle, _ *CoordinatorKeys) {
+				bundle.Addresses[0].Outputs[0].Inclusion.AuditPath[0] = hex.EncodeToString(make([]byte, 32))
+			},
+			checks: []string{VerificationCheckInclusion},
+		},
+		{
+			name: "wrong leaf index",
+			forge: func(bundle *Bundle, _ *CoordinatorKeys) {
+				bundle.Addresses[0].Outputs[0].Inclusion.LeafIndex = 0
+			},
+			checks: []string{VerificationCheckInclusion},
+		},
+		{
+			name: "wrong referencing milestone",
+			forge: func(bundle *Bundle, _ *CoordinatorKeys) {
+				bundle.Messages[0].ReferencedByMilestoneIndex = testMilestoneIndex - 1
+			},
+			checks: []string{VerificationCheckInclusion},
+		},
+		{
+			name: "missing message",
+			forge: func(bundle *Bundle, _ *CoordinatorKeys) {
+				bundle.Messages = []*BundleMessage{}
+			},
+			checks: []string{VerificationCheckMessage},
+		},
+		{
+			name: "migrated output of another output index",
+			forge: func(bundle *Bundle, _ *CoordinatorKeys) {
+				output := bundle.Addresses[1].Outputs[0]
+				output.OutputID = output.OutputID[:len(output.OutputID)-4] + "0100"
+			},
+			checks: []string{VerificationCheckOutput},
+		},
+		{
+			name: "output without an inclusion proof",
+			forge: func(bundle *Bundle, _ *CoordinatorKeys) {
+				bundle.Addresses[0].Outputs[0].Inclusion = nil
+			},
+			checks: []string{VerificationCheckUnproven},
+		},
+		{
+			name: "duplicated output",
+			forge: func(bundle *Bundle, _ *CoordinatorKeys) {
+				output := *bundle.Addresses[0].Outputs[0]
+				bundle.Addresses[0].Outputs = append(bundle.Addresses[0].Outputs, &output)
+				bundle.Addresses[0].Balance *= 2
+			},
+			checks: []string{VerificationCheckDuplicate},
+		},
+		{
+			name: "duplicated output with a differently cased output ID",
+			forge: func(bundle *Bundle, _ *CoordinatorKeys) {
+				output := *bundle.Addresses[0].Outputs[0]
+				output.OutputID = strings.ToUpper(output.OutputID)
+				bundle.Addresses[0].Outputs = append(bundle.Addresses[0].Outputs, &output)
+				bundle.Addresses[0].Balance *= 2
+			},
+			checks: []string{VerificationCheckDuplicate},
+		},
+		{
+			name: "duplicated address",
+			forge: func(bundle *Bundle, _ *CoordinatorKeys) {
+				address := *bundle.Addresses[0]
+				bundle.Addresses = append(bundle.Addresses, &address)
+			},
+			checks: []string{VerificationCheckDuplicate},
+		},
+		{
+			name: "foreign network",
+			forge: func(bundle *Bundle, _ *CoordinatorKeys) {
+				bundle.NetworkID = strconv.FormatUint(testNetworkID+1, 10)
+			},
+			checks: []string{VerificationCheckMilestone, VerificationCheckMessage, VerificationCheckInclusion, VerificationCheckInclusion},
+		},
+		{
+			name: "invalid network ID",
+			forge: func(bundle *Bundle, _ *CoordinatorKeys) {
+				bundle.NetworkID = "mainnet"
+			},
+			checks: []string{VerificationCheckBundle},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bundle, keys := newTestBundle(t)
+			tt.forge(bundle, keys)
+
+			report := Verify(bundle, keys)
+			require.False(t, report.Valid)
+			require.False(t, report.UnspentVerified)
+			require.Equal(t, tt.checks, issueChecks(report))
+			require.LessOrEqual(t, report.ProvenOutputCount, 2)
+			require.LessOrEqual(t, report.ProvenAmount, testTransferAmount+testMigratedAmount)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+)
+
+// WeightFunc returns the weight of a value in the cache.
+type WeightFunc[V any] func(value V) int
+
+type weightedLRUEntry[K comparable, V any] struct {
+	key    K
+	value  V
+	weight int
+}
+
+// WeightedLRU is a thread-safe LRU cache that limits the sum of the weights of its entries
+// instead of the amount of entries. The least recently used entries are evicted until the
+// new entry fits into the cache.
+type WeightedLRU[K comparable, V any] struct {
+	mutex sync.Mutex
+
+	maxWeight  int
+	weight     int
+	weightFunc WeightFunc[V]
+
+	evictList *list.List
+	items     map[K]*list.Element
+}
+
+// NewWeightedLRU creates a new WeightedLRU with the given maximum weight.
+// The weight of an entry is at least 1, so entries with a zero weight still count.
+func NewWeightedLRU[K comparable, V any](maxWeight int, weightFunc WeightFunc[V]) *WeightedLRU[K, V] {
+	return &WeightedLRU[K, V]{
+		maxWeight:  maxWeight,
+		weight:     0,
+		weightFunc: weightFunc,
+		evictList:  list.New(),
+		items:      make(map[K]*list.Element),
+	}
+}
+
+// Get returns the value for the given key and marks it as recently used.
+func (c *WeightedLRU[K, V]) Get(key K) (V, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, exists := c.items[key]
+	if !exists {
+		var empty V
+
+		return empty, false
+	}
+
+	c.evictList.MoveToFront(element)
+
+	//nolint:forcetypeassert // we only add weightedLRUEntry to the list
+	return element.Value.(*weightedLRUEntry[K, V]).value, true
+}
+
+// Add adds the value for the given key to the cache and evicts the least recently used entries if needed.
+// Values that are heavier than the maximum weight of the cache are not added.
+// Returns true if the value was added.
+func (c *WeightedLRU[K, V]) Add(key K, value V) bool {
+	weight := c.weightFunc(value)
+	if weight < 1 {
+		weight = 1
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, exists := c.items[key]; exists {
+		c.removeElement(element)
+	}
+
+	if weight > c.maxWeight {
+		return false
+	}
+
+	for c.weight+weight > c.maxWeight {
+		c.removeElement(c.evictList.Back())
+	}
+
+	c.items[key] = c.evictList.PushFront(&weightedLRUEntry[K, V]{
+		key:    key,
+		value:  value,
+		weight: weight,
+	})
+	c.weight += weight
+
+	return true
+}
+
+// Len returns the amount of entries in the cache.
+func (c *WeightedLRU[K, V]) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.evictList.Len()
+}
+
+// MaxWeight returns the maximum sum of the weights of the entries in the cache.
+func (c *WeightedLRU[K, V]) MaxWeight() int {
+	return c.maxWeight
+}
+
+// Weight returns the sum of the weights of all entries in the cache.
+func (c *WeightedLRU[K, V]) Weight() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.weight
+}
+
+func (c *WeightedLRU[K, V]) removeElement(element *list.Element) {
+	//nolint:forcetypeassert // we only add weightedLRUEntry to the list
+	entry := c.evictList.Remove(element).(*weightedLRUEntry[K, V])
+	delete(c.items, entry.key)
+	c.weight -= entry.weight
+}
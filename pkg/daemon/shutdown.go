@@ -3,6 +3,8 @@ package daemon
 const (
 	PriorityDisconnectINX = iota // no dependencies
 	PriorityStopDatabase
+	PriorityStopDatabaseIndexes
+	PriorityStopLabels
 	PriorityStopDatabaseAPI
 	PriorityStopDatabaseAPIINX
 	PriorityStopPrometheus
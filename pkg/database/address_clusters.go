@@ -0,0 +1,514 @@
+package database
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/serializer"
+	"github.com/iotaledger/hive.go/serializer/v2/byteutils"
+	"github.com/iotaledger/hive.go/serializer/v2/marshalutil"
+	"github.com/iotaledger/inx-api-core-v1/pkg/utxo"
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+const (
+	// AddressClustersIndexVersion is the version of the layout of the address clusters index.
+	AddressClustersIndexVersion = 3
+
+	addressClustersKeyPrefixAddress byte = 0
+	addressClustersKeyPrefixMember  byte = 1
+	addressClustersKeyPrefixCluster byte = 2
+	addressClustersKeyPrefixOptions byte = 3
+)
+
+var (
+	ErrAddressClusterNotFound = errors.New("address cluster not found")
+)
+
+/*
+
+   Address clusters:
+   =================
+   Key:
+       addressClustersKeyPrefixAddress + iotago.Ed25519Address.Serialized()
+                    1 byte             +       1 byte type + 32 bytes
+
+   Value:
+       ClusterID
+        4 bytes (big endian)
+
+   Key:
+       addressClustersKeyPrefixMember + ClusterID + iotago.Ed25519Address.Serialized()
+                    1 byte            +  4 bytes  +       1 byte type + 32 bytes
+
+   Value:
+       Empty
+
+   Key:
+       addressClustersKeyPrefixCluster + ClusterID
+                    1 byte             +  4 bytes
+
+   Value:
+       MemberCount + Balance
+         8 bytes   + 8 bytes
+
+   Key:
+       addressClustersKeyPrefixOptions
+                    1 byte
+
+   Value:
+       ChangeHeuristic
+           1 byte
+
+   Addresses that were spent together as inputs of a transaction belong to the same cluster (common-input-ownership heuristic).
+   If the change address heuristic is enabled, the only output address of a transaction that never received funds before
+   is added to the cluster of the inputs, if the transaction has other output addresses that already received funds.
+   Addresses that never spent funds are only part of a cluster if they were identified as change address.
+   The addresses of outputs that were created at or before the pruning index already received funds.
+   The cluster IDs start at 1 and are assigned in the order the clusters were first seen in the ledger.
+   The options entry stores whether the change address heuristic was used, so the index is rebuilt if the option changes.
+
+*/
+
+// AddressCluster is a group of addresses that are likely owned by the same entity.
+type AddressCluster struct {
+	// The ID of the cluster.
+	ClusterID uint32
+	// The amount of addresses in the cluster.
+	MemberCount int64
+	// The sum of the balances of all addresses in the cluster.
+	Balance uint64
+}
+
+func addressClustersAddressKey(addrBytes []byte) []byte {
+	return byteutils.ConcatBytes([]byte{addressClustersKeyPrefixAddress}, addrBytes)
+}
+
+func addressClustersClusterIDBytes(clusterID uint32) []byte {
+	clusterIDBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(clusterIDBytes, clusterID)
+
+	return clusterIDBytes
+}
+
+func addressClustersMemberKeyPrefix(clusterID uint32) []byte {
+	return byteutils.ConcatBytes([]byte{addressClustersKeyPrefixMember}, addressClustersClusterIDBytes(clusterID))
+}
+
+func addressClustersClusterKey(clusterID uint32) []byte {
+	return byteutils.ConcatBytes([]byte{addressClustersKeyPrefixCluster}, addressClustersClusterIDBytes(clusterID))
+}
+
+// addressClustersOptionsMatch checks whether the address clusters index was built with the configured change address heuristic.
+func (db *Database) addressClustersOptionsMatch() (bool, error) {
+	value, err := db.addressClustersStore.Get([]byte{addressClustersKeyPrefixOptions})
+	if err != nil {
+		if errors.Is(err, kvstore.ErrKeyNotFound) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("reading address clusters options failed: %w", err)
+	}
+
+	changeHeuristic, err := marshalutil.New(value).ReadBool()
+	if err != nil {
+		return false, fmt.Errorf("reading address clusters options failed: %w", err)
+	}
+
+	return changeHeuristic == db.addressClustersChangeHeuristic, nil
+}
+
+// addressUnionFind is a disjoint-set forest over serialized addresses.
+type addressUnionFind struct {
+	parents map[string]string
+	// the addresses in the order they were added
+	addresses []string
+}
+
+func newAddressUnionFind() *addressUnionFind {
+	return &addressUnionFind{
+		parents:   make(map[string]string),
+		addresses: make([]string, 0),
+	}
+}
+
+// find returns the root of the set of the address and adds the address if it is unknown.
+func (u *addressUnionFind) find(address string) string {
+	parent, exists := u.parents[address]
+	if !exists {
+		u.parents[address] = address
+		u.addresses = append(u.addresses, address)
+
+		return address
+	}
+
+	root := parent
+	for u.parents[root] != root {
+		root = u.parents[root]
+	}
+
+	// path compression
+	for address != root {
+		next := u.parents[address]
+		u.parents[address] = root
+		address = next
+	}
+
+	return root
+}
+
+// union merges the sets of both addresses. The root of the set of the first address is kept.
+func (u *addressUnionFind) union(address1 string, address2 string) {
+	root1 := u.find(address1)
+	root2 := u.find(address2)
+
+	if root1 != root2 {
+		u.parents[root2] = root1
+	}
+}
+
+// addressClusterer applies the clustering heuristics to the transfers of the ledger in the order they were confirmed.
+// The addresses are serialized.
+type addressClusterer struct {
+	clusters *addressUnionFind
+	// the addresses that already received funds
+	seenAddresses map[string]struct{}
+	// whether the change address heuristic is used
+	changeHeuristic bool
+}
+
+func newAddressClusterer(changeHeuristic bool) *addressClusterer {
+	return &addressClusterer{
+		clusters:        newAddressUnionFind(),
+		seenAddresses:   make(map[string]struct{}),
+		changeHeuristic: changeHeuristic,
+	}
+}
+
+// markSeen marks the address as having received funds.
+func (c *addressClusterer) markSeen(address string) {
+	c.seenAddresses[address] = struct{}{}
+}
+
+// addTransfer clusters the input addresses of a transfer and, if enabled, its change address.
+// The output addresses of all transfers of a milestone need to be marked as seen after the transfers were added.
+func (c *addressClusterer) addTransfer(inputAddresses []string, outputAddresses []string) {
+	if len(inputAddresses) == 0 {
+		return
+	}
+
+	firstInput := inputAddresses[0]
+	c.clusters.find(firstInput)
+
+	inputs := make(map[string]struct{}, len(inputAddresses))
+	for _, address := range inputAddresses {
+		inputs[address] = struct{}{}
+		// the funds were received before, maybe before the pruning index
+		c.markSeen(address)
+
+		// common-input-ownership heuristic
+		c.clusters.union(firstInput, address)
+	}
+
+	if !c.changeHeuristic {
+		return
+	}
+
+	var changeAddress string
+	var freshCount, paymentCount int
+	for _, address := range outputAddresses {
+		if _, isInput := inputs[address]; isInput {
+			continue
+		}
+		paymentCount++
+
+		if _, seen := c.seenAddresses[address]; !seen {
+			freshCount++
+			changeAddress = address
+		}
+	}
+
+	// change address heuristic
+	if freshCount == 1 && paymentCount > 1 {
+		c.clusters.union(firstInput, changeAddress)
+	}
+}
+
+// createAddressClustersIndex clusters the addresses by common-input ownership and optionally by change addresses.
+func (db *Database) createAddressClustersIndex(ctx context.Context) error {
+	// first we need to delete the old index before we rebuild it
+	if err := db.resetIndex(IndexStorePrefixAddressClusters, db.addressClustersStore); err != nil {
+		return fmt.Errorf("deleting address clusters index failed: %w", err)
+	}
+
+	progress := db.newProgressLogger(ctx, "address clusters")
+
+	serializeAddress := func(address iotago.Address) (string, error) {
+		addrBytes, err := address.Serialize(serializer.DeSeriModeNoValidation)
+		if err != nil {
+			return "", fmt.Errorf("failed to serialize address: %s, error: %w", address, err)
+		}
+
+		return string(addrBytes), nil
+	}
+
+	serializeAddresses := func(amounts []*utxo.TransferAmount) ([]string, error) {
+		addresses := make([]string, len(amounts))
+		for i, amount := range amounts {
+			address, err := serializeAddress(amount.Address)
+			if err != nil {
+				return nil, err
+			}
+			addresses[i] = address
+		}
+
+		return addresses, nil
+	}
+
+	clusterer := newAddressClusterer(db.addressClustersChangeHeuristic)
+
+	// the addresses of outputs created at or before the pruning index already received funds,
+	// the milestone diffs of these outputs are not available.
+	var innerErr error
+	var outputCounter int64
+	seedOutput := func(output *utxo.Output) bool {
+		outputCounter++
+		if err := progress.Log("analyzed %d outputs", outputCounter); err != nil {
+			innerErr = err
+
+			return false
+		}
+
+		if !db.outputCreatedAtOrBeforePruningIndex(output) {
+			return true
+		}
+
+		address, err := serializeAddress(output.Address())
+		if err != nil {
+			innerErr = err
+
+			return false
+		}
+		clusterer.markSeen(address)
+
+		return true
+	}
+
+	if err := db.utxoManager.ForEachUnspentOutput(seedOutput); err != nil {
+		return fmt.Errorf("iterating over unspent outputs failed: %w", err)
+	}
+	if innerErr != nil {
+		return innerErr
+	}
+
+	if err := db.utxoManager.ForEachSpentOutput(func(spent *utxo.Spent) bool {
+		return seedOutput(spent.Output())
+	}); err != nil {
+		return fmt.Errorf("iterating over spent outputs failed: %w", err)
+	}
+	if innerErr != nil {
+		return innerErr
+	}
+
+	ledgerIndex := db.utxoManager.ReadLedgerIndex()
+	for msIndex := db.snapshot.PruningIndex + 1; msIndex <= ledgerIndex; msIndex++ {
+		if err := progress.Log("analyzed %d/%d milestones", msIndex, ledgerIndex); err != nil {
+			return err
+		}
+
+		diff, err := db.utxoManager.MilestoneDiff(msIndex)
+		if err != nil {
+			if errors.Is(err, kvstore.ErrKeyNotFound) {
+				// the milestone diff is not available
+				continue
+			}
+
+			return fmt.Errorf("loading milestone diff failed, msIndex: %d, error: %w", msIndex, err)
+		}
+
+		for _, transfer := range utxo.TransfersFromMilestoneDiff(diff) {
+			inputAddresses, err := serializeAddresses(transfer.Inputs)
+			if err != nil {
+				return err
+			}
+
+			outputAddresses, err := serializeAddresses(transfer.Outputs)
+			if err != nil {
+				return err
+			}
+
+			clusterer.addTransfer(inputAddresses, outputAddresses)
+		}
+
+		// all outputs of the milestone, including migrated funds, received funds
+		for _, output := range diff.Outputs {
+			address, err := serializeAddress(output.Address())
+			if err != nil {
+				return err
+			}
+			clusterer.markSeen(address)
+		}
+	}
+
+	clusters := clusterer.clusters
+
+	// assign the cluster IDs in the order the clusters were first seen
+	clusterIDs := make(map[string]uint32)
+	addressClusterIDs := make(map[string]uint32, len(clusters.addresses))
+	clusterInfos := make([]*AddressCluster, 0)
+
+	for _, addrKey := range clusters.addresses {
+		root := clusters.find(addrKey)
+
+		clusterID, exists := clusterIDs[root]
+		if !exists {
+			clusterID = uint32(len(clusterInfos) + 1)
+			clusterIDs[root] = clusterID
+			clusterInfos = append(clusterInfos, &AddressCluster{ClusterID: clusterID})
+		}
+		addressClusterIDs[addrKey] = clusterID
+		clusterInfos[clusterID-1].MemberCount++
+
+		if err := db.addressClustersStore.Set(addressClustersAddressKey([]byte(addrKey)), addressClustersClusterIDBytes(clusterID)); err != nil {
+			return fmt.Errorf("setting entry in address clusters index failed: %w", err)
+		}
+
+		if err := db.addressClustersStore.Set(byteutils.ConcatBytes(addressClustersMemberKeyPrefix(clusterID), []byte(addrKey)), []byte{}); err != nil {
+			return fmt.Errorf("setting entry in address clusters index failed: %w", err)
+		}
+	}
+
+	if err := db.utxoManager.ForEachBalance(func(address iotago.Address, balance uint64, _ uint64, _ int64) bool {
+		addrKey, err := serializeAddress(address)
+		if err != nil {
+			innerErr = err
+
+			return false
+		}
+
+		if clusterID, exists := addressClusterIDs[addrKey]; exists {
+			clusterInfos[clusterID-1].Balance += balance
+		}
+
+		return true
+	}); err != nil {
+		return fmt.Errorf("iterating over all balances failed: %w", err)
+	}
+	if innerErr != nil {
+		return innerErr
+	}
+
+	for _, cluster := range clusterInfos {
+		value := marshalutil.New(16)
+		value.WriteInt64(cluster.MemberCount)
+		value.WriteUint64(cluster.Balance)
+
+		if err := db.addressClustersStore.Set(addressClustersClusterKey(cluster.ClusterID), value.Bytes()); err != nil {
+			return fmt.Errorf("setting entry in address clusters index failed: %w", err)
+		}
+	}
+
+	options := marshalutil.New(1)
+	options.WriteBool(db.addressClustersChangeHeuristic)
+
+	if err := db.addressClustersStore.Set([]byte{addressClustersKeyPrefixOptions}, options.Bytes()); err != nil {
+		return fmt.Errorf("setting entry in address clusters index failed: %w", err)
+	}
+
+	return nil
+}
+
+// AddressClusterByID returns the address cluster with the given ID.
+func (db *Database) AddressClusterByID(clusterID uint32) (*AddressCluster, error) {
+	value, err := db.addressClustersStore.Get(addressClustersClusterKey(clusterID))
+	if err != nil {
+		if errors.Is(err, kvstore.ErrKeyNotFound) {
+			return nil, ErrAddressClusterNotFound
+		}
+
+		return nil, err
+	}
+
+	marshalUtil := marshalutil.New(value)
+
+	memberCount, err := marshalUtil.ReadInt64()
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := marshalUtil.ReadUint64()
+	if err != nil {
+		return nil, err
+	}
+
+	return &AddressCluster{
+		ClusterID:   clusterID,
+		MemberCount: memberCount,
+		Balance:     balance,
+	}, nil
+}
+
+// AddressClusterByAddress returns the address cluster of the given address.
+func (db *Database) AddressClusterByAddress(address iotago.Address) (*AddressCluster, error) {
+	addrBytes, err := address.Serialize(serializer.DeSeriModeNoValidation)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := db.addressClustersStore.Get(addressClustersAddressKey(addrBytes))
+	if err != nil {
+		if errors.Is(err, kvstore.ErrKeyNotFound) {
+			return nil, ErrAddressClusterNotFound
+		}
+
+		return nil, err
+	}
+
+	if len(value) != 4 {
+		return nil, fmt.Errorf("invalid address clusters value length: %d", len(value))
+	}
+
+	return db.AddressClusterByID(binary.BigEndian.Uint32(value))
+}
+
+// AddressClusterMembers returns the addresses of the cluster sorted by address, starting at the given offset.
+func (db *Database) AddressClusterMembers(clusterID uint32, offset int, maxResults int) ([]iotago.Address, error) {
+	var innerErr error
+	var i int
+
+	prefix := addressClustersMemberKeyPrefix(clusterID)
+
+	members := make([]iotago.Address, 0)
+	if err := db.addressClustersStore.IterateKeys(prefix, func(key kvstore.Key) bool {
+		i++
+		if i <= offset {
+			return true
+		}
+
+		if len(members) >= maxResults {
+			return false
+		}
+
+		address, err := addressFromBytes(key[len(prefix):])
+		if err != nil {
+			innerErr = err
+
+			return false
+		}
+
+		members = append(members, address)
+
+		return true
+	}); err != nil {
+		return nil, err
+	}
+
+	if innerErr != nil {
+		return nil, innerErr
+	}
+
+	return members, nil
+}
@@ -0,0 +1,141 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddressUnionFind(t *testing.T) {
+	u := newAddressUnionFind()
+
+	// unknown addresses are added as their own set
+	require.Equal(t, "a", u.find("a"))
+	require.Equal(t, "b", u.find("b"))
+	require.Equal(t, "a", u.find("a"))
+	require.Equal(t, []string{"a", "b"}, u.addresses)
+
+	// the root of the set of the first address is kept
+	u.union("a", "b")
+	require.Equal(t, "a", u.find("b"))
+
+	// a chain of unions ends up in a single set
+	u.union("c", "d")
+	u.union("d", "e")
+	require.Equal(t, "c", u.find("e"))
+	u.union("b", "e")
+	for _, address := range []string{"a", "b", "c", "d", "e"} {
+		require.Equal(t, "a", u.find(address))
+	}
+
+	// path compression points all members directly to the root
+	for _, address := range []string{"a", "b", "c", "d", "e"} {
+		require.Equal(t, "a", u.parents[address])
+	}
+
+	// merging the same set again is a no-op
+	u.union("e", "a")
+	require.Equal(t, "a", u.find("e"))
+
+	// unrelated sets are not merged
+	require.Equal(t, "f", u.find("f"))
+	require.Equal(t, []string{"a", "b", "c", "d", "e", "f"}, u.addresses)
+}
+
+func TestAddressClustererCommonInputOwnership(t *testing.T) {
+	c := newAddressClusterer(false)
+
+	c.addTransfer([]string{"a", "b"}, []string{"x", "y"})
+	c.addTransfer([]string{"c"}, []string{"x"})
+	c.addTransfer([]string{"b", "d"}, []string{"z"})
+
+	require.Equal(t, c.clusters.find("a"), c.clusters.find("b"))
+	require.Equal(t, c.clusters.find("a"), c.clusters.find("d"))
+	require.NotEqual(t, c.clusters.find("a"), c.clusters.find("c"))
+
+	// the inputs received funds, the outputs are only marked as seen by the caller
+	for _, address := range []string{"a", "b", "c", "d"} {
+		require.Contains(t, c.seenAddresses, address)
+	}
+	require.NotContains(t, c.seenAddresses, "x")
+
+	// outputs are never clustered without the change address heuristic
+	c.addTransfer([]string{"e"}, []string{"f", "g"})
+	require.NotContains(t, c.clusters.parents, "f")
+	require.NotContains(t, c.clusters.parents, "g")
+
+	// transfers without inputs are ignored
+	c.addTransfer(nil, []string{"h"})
+	require.NotContains(t, c.clusters.parents, "h")
+}
+
+func TestAddressClustererChangeHeuristic(t *testing.T) {
+	tests := []struct {
+		name    string
+		seen    []string
+		inputs  []string
+		outputs []string
+		// the output address that is expected to be added to the cluster of the inputs, empty for none
+		change string
+	}{
+		{
+			name:    "single fresh output among payments",
+			seen:    []string{"paid"},
+			inputs:  []string{"in"},
+			outputs: []string{"paid", "fresh"},
+			change:  "fresh",
+		},
+		{
+			name:    "fresh output seeded from the pruned ledger",
+			seen:    []string{"paid", "pruned"},
+			inputs:  []string{"in"},
+			outputs: []string{"paid", "pruned"},
+		},
+		{
+			name:    "two fresh outputs are ambiguous",
+			seen:    []string{"paid"},
+			inputs:  []string{"in"},
+			outputs: []string{"paid", "fresh1", "fresh2"},
+		},
+		{
+			name:    "single payment output",
+			inputs:  []string{"in"},
+			outputs: []string{"fresh"},
+		},
+		{
+			name:    "outputs back to an input are not payments",
+			inputs:  []string{"in"},
+			outputs: []string{"in", "fresh"},
+		},
+		{
+			name:    "no fresh outputs",
+			seen:    []string{"paid1", "paid2"},
+			inputs:  []string{"in"},
+			outputs: []string{"paid1", "paid2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newAddressClusterer(true)
+			for _, address := range tt.seen {
+				c.markSeen(address)
+			}
+
+			c.addTransfer(tt.inputs, tt.outputs)
+
+			for _, output := range tt.outputs {
+				if output == tt.change {
+					require.Equal(t, c.clusters.find(tt.inputs[0]), c.clusters.find(output))
+
+					continue
+				}
+
+				if output == tt.inputs[0] {
+					continue
+				}
+				require.NotContains(t, c.clusters.parents, output)
+			}
+		})
+	}
+}
@@ -0,0 +1,579 @@
+package database
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/serializer"
+	"github.com/iotaledger/hive.go/serializer/v2/byteutils"
+	"github.com/iotaledger/hive.go/serializer/v2/marshalutil"
+	"github.com/iotaledger/inx-api-core-v1/pkg/hornet"
+	"github.com/iotaledger/inx-api-core-v1/pkg/milestone"
+	"github.com/iotaledger/inx-api-core-v1/pkg/utxo"
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+const (
+	// AddressHistoryIndexVersion is the version of the layout of the address history index.
+	AddressHistoryIndexVersion = 2
+)
+
+/*
+
+   Address history:
+   ================
+   Key:
+       iotago.Ed25519Address.Serialized() + (math.MaxUint32 - ReferencedByMilestoneIndex) + MessageID
+              1 byte type + 32 bytes      +                 4 bytes (big endian)          + 32 bytes
+
+   Value:
+       TransactionID + MilestoneTimestampReferenced + LedgerInclusionState + Conflict + InputsCount + OutputsCount + AddressBalanceChange + CounterpartiesCount + Counterparties
+         32 bytes    +            8 bytes           +        1 byte        +  1 byte  +   2 bytes   +   2 bytes    +       8 bytes        +       2 bytes       + n * 33 bytes
+
+   The inverted milestone index in the key sorts the entries of an address by highest milestone index and lowest messageID.
+   The counterparties are the serialized addresses of all other inputs and outputs of the transaction.
+
+*/
+
+// LedgerInclusionState defines the inclusion state of a message in the ledger.
+type LedgerInclusionState byte
+
+const (
+	// LedgerInclusionStateNoTransaction the message does not contain a transaction.
+	LedgerInclusionStateNoTransaction LedgerInclusionState = iota
+	// LedgerInclusionStateIncluded the transaction of the message was included in the ledger.
+	LedgerInclusionStateIncluded
+	// LedgerInclusionStateConflicting the transaction of the message is conflicting.
+	LedgerInclusionStateConflicting
+	// LedgerInclusionStateMigrated the message contains a receipt with funds migrated from the legacy network.
+	LedgerInclusionStateMigrated
+)
+
+// String returns the name of the ledger inclusion state as used in the API.
+func (s LedgerInclusionState) String() string {
+	switch s {
+	case LedgerInclusionStateIncluded:
+		return "included"
+	case LedgerInclusionStateConflicting:
+		return "conflicting"
+	case LedgerInclusionStateMigrated:
+		return "migrated"
+	default:
+		return "noTransaction"
+	}
+}
+
+// TransactionHistoryItem is an entry in the transaction history of an address.
+type TransactionHistoryItem struct {
+	// The message in which the transaction payload was included.
+	MessageID hornet.MessageID
+	// The ID of the transaction. For migrations this is the milestone ID of the legacy network.
+	TransactionID iotago.TransactionID
+	// The milestone index that references the message.
+	ReferencedByMilestoneIndex milestone.Index
+	// The milestone timestamp that references the message.
+	MilestoneTimestampReferenced int64
+	// The ledger inclusion state of the transaction payload.
+	LedgerInclusionState LedgerInclusionState
+	// The reason why the message is marked as conflicting.
+	Conflict Conflict
+	// The amount of inputs in the transaction payload.
+	InputsCount int
+	// The amount of outputs in the transaction payload.
+	OutputsCount int
+	// The balance change of the address the history belongs to.
+	AddressBalanceChange int64
+	// The other addresses that are part of the inputs and outputs of the transaction.
+	Counterparties []iotago.Address
+}
+
+func addressHistoryKey(addrBytes []byte, item *TransactionHistoryItem) []byte {
+	invertedMilestoneIndex := make([]byte, 4)
+	binary.BigEndian.PutUint32(invertedMilestoneIndex, math.MaxUint32-uint32(item.ReferencedByMilestoneIndex))
+
+	return byteutils.ConcatBytes(addrBytes, invertedMilestoneIndex, item.MessageID)
+}
+
+func (item *TransactionHistoryItem) bytes() ([]byte, error) {
+	m := marshalutil.New(56 + len(item.Counterparties)*iotago.Ed25519AddressSerializedBytesSize)
+	m.WriteBytes(item.TransactionID[:])
+	m.WriteInt64(item.MilestoneTimestampReferenced)
+	m.WriteByte(byte(item.LedgerInclusionState))
+	m.WriteByte(byte(item.Conflict))
+	m.WriteUint16(uint16(item.InputsCount))
+	m.WriteUint16(uint16(item.OutputsCount))
+	m.WriteInt64(item.AddressBalanceChange)
+	m.WriteUint16(uint16(len(item.Counterparties)))
+	for _, counterparty := range item.Counterparties {
+		addrBytes, err := counterparty.Serialize(serializer.DeSeriModeNoValidation)
+		if err != nil {
+			return nil, err
+		}
+		m.WriteBytes(addrBytes)
+	}
+
+	return m.Bytes(), nil
+}
+
+func transactionHistoryItemFromKeyAndValue(addrLength int, key []byte, value []byte) (*TransactionHistoryItem, error) {
+	if len(key) != addrLength+4+iotago.MessageIDLength {
+		return nil, fmt.Errorf("invalid address history key length: %d", len(key))
+	}
+
+	item := &TransactionHistoryItem{
+		MessageID:                  hornet.MessageIDFromSlice(key[addrLength+4:]),
+		ReferencedByMilestoneIndex: milestone.Index(math.MaxUint32 - binary.BigEndian.Uint32(key[addrLength:addrLength+4])),
+	}
+
+	marshalUtil := marshalutil.New(value)
+
+	transactionID, err := marshalUtil.ReadBytes(iotago.TransactionIDLength)
+	if err != nil {
+		return nil, err
+	}
+	copy(item.TransactionID[:], transactionID)
+
+	if item.MilestoneTimestampReferenced, err = marshalUtil.ReadInt64(); err != nil {
+		return nil, err
+	}
+
+	ledgerInclusionState, err := marshalUtil.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	item.LedgerInclusionState = LedgerInclusionState(ledgerInclusionState)
+
+	conflict, err := marshalUtil.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	item.Conflict = Conflict(conflict)
+
+	inputsCount, err := marshalUtil.ReadUint16()
+	if err != nil {
+		return nil, err
+	}
+	item.InputsCount = int(inputsCount)
+
+	outputsCount, err := marshalUtil.ReadUint16()
+	if err != nil {
+		return nil, err
+	}
+	item.OutputsCount = int(outputsCount)
+
+	if item.AddressBalanceChange, err = marshalUtil.ReadInt64(); err != nil {
+		return nil, err
+	}
+
+	counterpartiesCount, err := marshalUtil.ReadUint16()
+	if err != nil {
+		return nil, err
+	}
+
+	item.Counterparties = make([]iotago.Address, counterpartiesCount)
+	for i := range item.Counterparties {
+		addrBytes, err := marshalUtil.ReadBytes(iotago.Ed25519AddressSerializedBytesSize)
+		if err != nil {
+			return nil, err
+		}
+
+		if item.Counterparties[i], err = addressFromBytes(addrBytes); err != nil {
+			return nil, err
+		}
+	}
+
+	return item, nil
+}
+
+// spendingMessageID returns the message ID of the transaction that spent an output.
+// It returns nil if the transaction is unknown.
+func (db *Database) spendingMessageID(transactionID *iotago.TransactionID) (hornet.MessageID, error) {
+	// get the first output of that transaction (using index 0)
+	outputID := &iotago.UTXOInputID{}
+	copy(outputID[:], transactionID[:])
+
+	output, err := db.utxoManager.ReadOutputByOutputID(outputID)
+	if err != nil {
+		if errors.Is(err, kvstore.ErrKeyNotFound) {
+			// if we don't have the output, we don't have the history, which is fine.
+			//nolint:nilnil
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to load output for transaction: %s, error: %w", hex.EncodeToString(transactionID[:]), err)
+	}
+
+	return output.MessageID(), nil
+}
+
+// transactionHistoryItem computes the effect of the given message on the given address.
+// It returns nil if the history can't be computed because the message or its inputs are unknown.
+//
+//nolint:nilnil
+func (db *Database) transactionHistoryItem(address iotago.Address, messageID hornet.MessageID) (*TransactionHistoryItem, error) {
+	msg := db.MessageOrNil(messageID)
+	if msg == nil {
+		// if we don't have the message, we don't have the history, which is fine.
+		return nil, nil
+	}
+
+	msgMeta := db.MessageMetadataOrNil(messageID)
+	if msgMeta == nil {
+		return nil, fmt.Errorf("message not found: %s", messageID.ToHex())
+	}
+
+	var referencedByMilestoneIndex milestone.Index
+	if referenced, referencedIndex := msgMeta.ReferencedWithIndex(); referenced {
+		referencedByMilestoneIndex = referencedIndex
+	}
+
+	milestoneTimestampReferenced, err := db.MilestoneTimestampUnixByIndex(referencedByMilestoneIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	ledgerInclusionState := LedgerInclusionStateNoTransaction
+	conflict := msgMeta.Conflict()
+
+	if conflict != ConflictNone {
+		ledgerInclusionState = LedgerInclusionStateConflicting
+	} else if msgMeta.IsIncludedTxInLedger() {
+		ledgerInclusionState = LedgerInclusionStateIncluded
+	}
+
+	txPayload := msg.Transaction()
+	if txPayload == nil {
+		// not a transaction payload. check if it is a milestone payload
+		msPayload := msg.Milestone()
+		//nolint:forcetypeassert
+		if msPayload == nil || msPayload.Receipt == nil || msPayload.Receipt.(*iotago.Receipt).Transaction == nil {
+			return nil, fmt.Errorf("message does not contain a transaction or milestone payload: %s", messageID.ToHex())
+		}
+
+		//nolint:forcetypeassert
+		receipt := msPayload.Receipt.(*iotago.Receipt)
+		//nolint:forcetypeassert
+		treasuryInput := receipt.Transaction.(*iotago.TreasuryTransaction).Input.(*iotago.TreasuryInput)
+
+		var addressBalanceOutputs int64
+		for _, input := range receipt.Funds {
+			//nolint:forcetypeassert
+			migratedFundEntry := input.(*iotago.MigratedFundsEntry)
+			//nolint:forcetypeassert
+			if migratedFundEntry.Address.(iotago.Address).String() != address.String() {
+				continue
+			}
+
+			addressBalanceOutputs += int64(migratedFundEntry.Deposit)
+		}
+
+		return &TransactionHistoryItem{
+			MessageID:                    messageID,
+			TransactionID:                iotago.TransactionID(*treasuryInput), // milestone ID of the legacy network
+			ReferencedByMilestoneIndex:   referencedByMilestoneIndex,
+			MilestoneTimestampReferenced: milestoneTimestampReferenced,
+			// we need to signal that this was a migration from the legacy network
+			LedgerInclusionState: LedgerInclusionStateMigrated,
+			Conflict:             conflict,
+			InputsCount:          1,
+			OutputsCount:         len(receipt.Funds),
+			AddressBalanceChange: addressBalanceOutputs,
+		}, nil
+	}
+
+	transactionID, err := txPayload.ID()
+	if err != nil {
+		return nil, fmt.Errorf("can't compute the transaction ID, msgID: %s, error: %w", messageID.ToHex(), err)
+	}
+
+	txEssence := msg.TransactionEssence()
+	if txEssence == nil {
+		return nil, fmt.Errorf("transaction does not contain a valid transactionEssence: msgID: %s", messageID.ToHex())
+	}
+
+	// collect all other addresses of the transaction in the order they appear in the inputs and outputs
+	counterparties := make([]iotago.Address, 0)
+	seenCounterparties := make(map[string]struct{})
+	addCounterparty := func(counterparty iotago.Address) {
+		key := counterparty.String()
+		if key == address.String() {
+			return
+		}
+
+		if _, seen := seenCounterparties[key]; seen {
+			return
+		}
+		seenCounterparties[key] = struct{}{}
+		counterparties = append(counterparties, counterparty)
+	}
+
+	var addressBalanceInputs int64
+	for _, input := range txEssence.Inputs {
+		utxoInput, ok := input.(*iotago.UTXOInput)
+		if !ok {
+			return nil, fmt.Errorf("transaction contains an unsupported input type: msgID: %s", messageID.ToHex())
+		}
+
+		utxoInputID := utxoInput.ID()
+		output, err := db.utxoManager.ReadOutputByOutputID(&utxoInputID)
+		if err != nil {
+			// if we don't have the input, we don't have the history, which is fine.
+			//nolint:nilerr
+			return nil, nil
+		}
+
+		addCounterparty(output.Address())
+
+		if output.Address().String() != address.String() {
+			continue
+		}
+
+		addressBalanceInputs += int64(output.Amount())
+	}
+
+	var addressBalanceOutputs int64
+	for _, txOutput := range txEssence.Outputs {
+		switch output := txOutput.(type) {
+		case *iotago.SigLockedSingleOutput:
+			//nolint:forcetypeassert
+			addCounterparty(output.Address.(iotago.Address))
+			//nolint:forcetypeassert
+			if output.Address.(iotago.Address).String() != address.String() {
+				continue
+			}
+			addressBalanceOutputs += int64(output.Amount)
+		case *iotago.SigLockedDustAllowanceOutput:
+			//nolint:forcetypeassert
+			addCounterparty(output.Address.(iotago.Address))
+			//nolint:forcetypeassert
+			if output.Address.(iotago.Address).String() != address.String() {
+				continue
+			}
+			addressBalanceOutputs += int64(output.Amount)
+		default:
+			return nil, fmt.Errorf("transaction contains an unsupported output type: msgID: %s", messageID.ToHex())
+		}
+	}
+
+	return &TransactionHistoryItem{
+		MessageID:                    messageID,
+		TransactionID:                *transactionID,
+		ReferencedByMilestoneIndex:   referencedByMilestoneIndex,
+		MilestoneTimestampReferenced: milestoneTimestampReferenced,
+		LedgerInclusionState:         ledgerInclusionState,
+		Conflict:                     conflict,
+		InputsCount:                  len(txEssence.Inputs),
+		OutputsCount:                 len(txEssence.Outputs),
+		AddressBalanceChange:         addressBalanceOutputs - addressBalanceInputs,
+		Counterparties:               counterparties,
+	}, nil
+}
+
+// createAddressHistoryIndex creates the transaction history of all addresses.
+// In a first pass, all messages that affected an address are collected in the temporary store,
+// which also removes duplicates. In a second pass, the history items are computed per address.
+func (db *Database) createAddressHistoryIndex(ctx context.Context) error {
+	// first we need to delete the old index before we rebuild it
+	if err := db.resetIndex(IndexStorePrefixAddressHistory, db.addressHistoryStore); err != nil {
+		return fmt.Errorf("deleting address history index failed: %w", err)
+	}
+
+	addAddressMessage := func(address iotago.Address, messageID hornet.MessageID) error {
+		addrBytes, err := address.Serialize(serializer.DeSeriModeNoValidation)
+		if err != nil {
+			return fmt.Errorf("failed to serialize address, msgID: %s, address: %s, error: %w", messageID.ToHex(), address.String(), err)
+		}
+
+		if err := db.temporaryStore.Set(byteutils.ConcatBytes(addrBytes, messageID), []byte{}); err != nil {
+			return fmt.Errorf("setting entry in temporary store failed, msgID: %s, error: %w", messageID.ToHex(), err)
+		}
+
+		return nil
+	}
+
+	var innerErr error
+	progress := db.newProgressLogger(ctx, "address history")
+	var outputsCounter int64
+
+	if err := db.utxoManager.ForEachUnspentOutput(func(output *utxo.Output) bool {
+		outputsCounter++
+		if err := progress.Log("collected %d outputs", outputsCounter); err != nil {
+			innerErr = err
+
+			return false
+		}
+
+		// add the message that contains the transaction which created this output
+		if err := addAddressMessage(output.Address(), output.MessageID()); err != nil {
+			innerErr = err
+
+			return false
+		}
+
+		return true
+	}); err != nil {
+		return fmt.Errorf("iterating over unspent outputs failed: %w", err)
+	}
+	if innerErr != nil {
+		return innerErr
+	}
+
+	if err := db.utxoManager.ForEachSpentOutput(func(spent *utxo.Spent) bool {
+		outputsCounter++
+		if err := progress.Log("collected %d outputs", outputsCounter); err != nil {
+			innerErr = err
+
+			return false
+		}
+
+		// add the message that contains the transaction which created this output
+		if err := addAddressMessage(spent.Address(), spent.MessageID()); err != nil {
+			innerErr = err
+
+			return false
+		}
+
+		// also add the message that contains the transaction that spent this output
+		spendingMessageID, err := db.spendingMessageID(spent.TargetTransactionID())
+		if err != nil {
+			innerErr = err
+
+			return false
+		}
+
+		if spendingMessageID == nil {
+			return true
+		}
+
+		if err := addAddressMessage(spent.Address(), spendingMessageID); err != nil {
+			innerErr = err
+
+			return false
+		}
+
+		return true
+	}); err != nil {
+		return fmt.Errorf("iterating over spent outputs failed: %w", err)
+	}
+	if innerErr != nil {
+		return innerErr
+	}
+
+	// add the messages that contain conflicting transactions, the lookup table uses the same key layout
+	if err := db.conflictingTransactionsStore.IterateKeys(kvstore.EmptyPrefix, func(key []byte) bool {
+		if len(key) != iotago.Ed25519AddressSerializedBytesSize+iotago.MessageIDLength {
+			// skip the status entry
+			return true
+		}
+
+		if err := db.temporaryStore.Set(key, []byte{}); err != nil {
+			innerErr = fmt.Errorf("setting entry in temporary store failed: %w", err)
+
+			return false
+		}
+
+		return true
+	}); err != nil {
+		return fmt.Errorf("iterating over conflicting transactions failed: %w", err)
+	}
+	if innerErr != nil {
+		return innerErr
+	}
+
+	var entriesCounter int64
+	if err := db.temporaryStore.IterateKeys(kvstore.EmptyPrefix, func(key []byte) bool {
+		entriesCounter++
+		if err := progress.Log("analyzed %d entries", entriesCounter); err != nil {
+			innerErr = err
+
+			return false
+		}
+
+		addrBytes := key[:len(key)-iotago.MessageIDLength]
+		messageID := hornet.MessageIDFromSlice(key[len(key)-iotago.MessageIDLength:])
+
+		address, err := addressFromBytes(addrBytes)
+		if err != nil {
+			innerErr = fmt.Errorf("failed to deserialize address, msgID: %s, error: %w", messageID.ToHex(), err)
+
+			return false
+		}
+
+		item, err := db.transactionHistoryItem(address, messageID)
+		if err != nil {
+			innerErr = fmt.Errorf("computing transaction history failed: %s, error: %w", address, err)
+
+			return false
+		}
+
+		if item == nil {
+			// skip if we don't have the history
+			return true
+		}
+
+		value, err := item.bytes()
+		if err != nil {
+			innerErr = fmt.Errorf("serializing address history entry failed, msgID: %s, error: %w", messageID.ToHex(), err)
+
+			return false
+		}
+
+		if err := db.addressHistoryStore.Set(addressHistoryKey(addrBytes, item), value); err != nil {
+			innerErr = fmt.Errorf("setting entry in address history index failed, msgID: %s, error: %w", messageID.ToHex(), err)
+
+			return false
+		}
+
+		return true
+	}); err != nil {
+		return fmt.Errorf("iterating over temporary store failed: %w", err)
+	}
+	if innerErr != nil {
+		return innerErr
+	}
+
+	if err := db.temporaryStore.Clear(); err != nil {
+		return fmt.Errorf("clearing temporary store failed: %w", err)
+	}
+
+	db.LogInfof("address history: indexed %d entries", entriesCounter)
+
+	return nil
+}
+
+// AddressTransactionHistory returns the transaction history of the given address,
+// sorted by highest milestone index and lowest messageID.
+func (db *Database) AddressTransactionHistory(address iotago.Address) ([]*TransactionHistoryItem, error) {
+	addrBytes, err := address.Serialize(serializer.DeSeriModeNoValidation)
+	if err != nil {
+		return nil, err
+	}
+
+	var innerErr error
+	items := make([]*TransactionHistoryItem, 0)
+	if err := db.addressHistoryStore.Iterate(addrBytes, func(key kvstore.Key, value kvstore.Value) bool {
+		item, err := transactionHistoryItemFromKeyAndValue(len(addrBytes), key, value)
+		if err != nil {
+			innerErr = err
+
+			return false
+		}
+
+		items = append(items, item)
+
+		return true
+	}); err != nil {
+		return nil, err
+	}
+
+	if innerErr != nil {
+		return nil, innerErr
+	}
+
+	return items, nil
+}
@@ -0,0 +1,292 @@
+package database
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/iotaledger/inx-api-core-v1/pkg/attestation"
+	"github.com/iotaledger/inx-api-core-v1/pkg/hornet"
+	"github.com/iotaledger/inx-api-core-v1/pkg/milestone"
+	"github.com/iotaledger/inx-api-core-v1/pkg/utxo"
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+var (
+	ErrAttestationTooManyOutputs = errors.New("too many unspent outputs for an attestation bundle")
+)
+
+// whiteFlagIncludedMessageIDs returns the IDs of the messages with a transaction that were included in the ledger
+// by the milestone, in the order of the white-flag confirmation.
+// The past cone of the milestone message is walked depth-first, parents in their order before the message itself,
+// and stops at messages that were referenced by older milestones or that are not available.
+func (db *Database) whiteFlagIncludedMessageIDs(msIndex milestone.Index) ([]iotago.MessageID, error) {
+	ms := db.MilestoneOrNil(msIndex)
+	if ms == nil {
+		return nil, fmt.Errorf("%w: %d", ErrMilestoneNotFound, msIndex)
+	}
+
+	msMsg := db.MessageOrNil(ms.MessageID)
+	if msMsg == nil {
+		return nil, fmt.Errorf("milestone message not found: %s", ms.MessageID.ToHex())
+	}
+
+	msPayload := msMsg.Milestone()
+	if msPayload == nil {
+		return nil, fmt.Errorf("message contains no milestone payload: %s", ms.MessageID.ToHex())
+	}
+
+	included := make([]iotago.MessageID, 0)
+	processed := make(map[string]struct{})
+
+	for _, parent := range msMsg.Message().Parents {
+		stack := hornet.MessageIDs{hornet.MessageIDFromSlice(parent[:])}
+
+		for len(stack) > 0 {
+			messageID := stack[len(stack)-1]
+			if _, wasProcessed := processed[messageID.ToMapKey()]; wasProcessed {
+				stack = stack[:len(stack)-1]
+
+				continue
+			}
+
+			msgMeta := db.MessageMetadataOrNil(messageID)
+			if msgMeta == nil {
+				// the message is a solid entry point or was pruned
+				processed[messageID.ToMapKey()] = struct{}{}
+				stack = stack[:len(stack)-1]
+
+				continue
+			}
+
+			if referenced, referencedIndex := msgMeta.ReferencedWithIndex(); !referenced || referencedIndex != msIndex {
+				processed[messageID.ToMapKey()] = struct{}{}
+				stack = stack[:len(stack)-1]
+
+				continue
+			}
+
+			parentPending := false
+			for _, parentMessageID := range msgMeta.Parents() {
+				if _, parentProcessed := processed[parentMessageID.ToMapKey()]; !parentProcessed {
+					// the parents are applied before the message
+					stack = append(stack, parentMessageID)
+					parentPending = true
+
+					break
+				}
+			}
+			if parentPending {
+				continue
+			}
+
+			processed[messageID.ToMapKey()] = struct{}{}
+			stack = stack[:len(stack)-1]
+
+			if msgMeta.IsIncludedTxInLedger() {
+				var includedMessageID iotago.MessageID
+				copy(includedMessageID[:], messageID)
+				included = append(included, includedMessageID)
+			}
+		}
+	}
+
+	if attestation.MerkleRoot(included) != msPayload.InclusionMerkleProof {
+		return nil, fmt.Errorf("included messages don't match the inclusion merkle proof of milestone %d", msIndex)
+	}
+
+	return included, nil
+}
+
+// attestationBundleBuilder collects the messages and milestones of an attestation bundle.
+type attestationBundleBuilder struct {
+	db     *Database
+	bundle *attestation.Bundle
+	// the included message IDs of the milestones in white-flag order
+	includedMessageIDs map[milestone.Index][]iotago.MessageID
+	messages           map[string]struct{}
+	milestones         map[milestone.Index]struct{}
+}
+
+// addMilestone adds the milestone message to the bundle. It returns false if the milestone is not available.
+func (b *attestationBundleBuilder) addMilestone(msIndex milestone.Index) bool {
+	if _, exists := b.milestones[msIndex]; exists {
+		return true
+	}
+
+	ms := b.db.MilestoneOrNil(msIndex)
+	if ms == nil {
+		return false
+	}
+
+	msMsg := b.db.MessageOrNil(ms.MessageID)
+	if msMsg == nil {
+		return false
+	}
+
+	b.milestones[msIndex] = struct{}{}
+	b.bundle.Milestones = append(b.bundle.Milestones, &attestation.BundleMilestone{
+		MilestoneIndex: msIndex,
+		MessageID:      ms.MessageID.ToHex(),
+		Message:        hex.EncodeToString(msMsg.Data()),
+	})
+
+	return true
+}
+
+// inclusionProof returns the proof that the message that created the output was included by its milestone
+// and whether the output was created by the migration. The messages of the proof are added to the bundle.
+// The proof is nil if the messages are not available.
+func (b *attestationBundleBuilder) inclusionProof(output *utxo.Output) (*attestation.InclusionProof, bool, error) {
+	msgMeta := b.db.MessageMetadataOrNil(output.MessageID())
+	if msgMeta == nil {
+		// the metadata of messages at or before the pruning index is not available
+		return nil, false, nil
+	}
+
+	referenced, msIndex := msgMeta.ReferencedWithIndex()
+	if !referenced {
+		return nil, false, nil
+	}
+
+	// migrated funds are created by the receipt in the milestone message
+	if msgMeta.IsMilestone() {
+		if !b.addMilestone(msIndex) {
+			return nil, true, nil
+		}
+
+		return &attestation.InclusionProof{
+			MilestoneIndex: msIndex,
+			AuditPath:      []string{},
+		}, true, nil
+	}
+
+	includedMessageIDs, exists := b.includedMessageIDs[msIndex]
+	if !exists {
+		var err error
+		if includedMessageIDs, err = b.db.whiteFlagIncludedMessageIDs(msIndex); err != nil {
+			return nil, false, err
+		}
+		b.includedMessageIDs[msIndex] = includedMessageIDs
+	}
+
+	leafIndex := -1
+	for i, messageID := range includedMessageIDs {
+		if bytes.Equal(messageID[:], output.MessageID()) {
+			leafIndex = i
+
+			break
+		}
+	}
+	if leafIndex == -1 {
+		return nil, false, fmt.Errorf("message %s not found in the included messages of milestone %d", output.MessageID().ToHex(), msIndex)
+	}
+
+	auditPath, err := attestation.AuditPath(includedMessageIDs, leafIndex)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if _, exists := b.messages[output.MessageID().ToMapKey()]; !exists {
+		msg := b.db.MessageOrNil(output.MessageID())
+		if msg == nil {
+			return nil, false, nil
+		}
+
+		b.messages[output.MessageID().ToMapKey()] = struct{}{}
+		b.bundle.Messages = append(b.bundle.Messages, &attestation.BundleMessage{
+			MessageID:                  output.MessageID().ToHex(),
+			Message:                    hex.EncodeToString(msg.Data()),
+			ReferencedByMilestoneIndex: msIndex,
+			LedgerInclusionState:       LedgerInclusionStateIncluded.String(),
+		})
+	}
+
+	if !b.addMilestone(msIndex) {
+		return nil, false, nil
+	}
+
+	auditPathHex := make([]string, len(auditPath))
+	for i, hash := range auditPath {
+		auditPathHex[i] = hex.EncodeToString(hash[:])
+	}
+
+	return &attestation.InclusionProof{
+		MilestoneIndex: msIndex,
+		LeafIndex:      uint32(leafIndex),
+		LeafCount:      uint32(len(includedMessageIDs)),
+		AuditPath:      auditPathHex,
+	}, false, nil
+}
+
+// AttestationBundle builds a self-contained proof bundle for the unspent outputs of the given addresses.
+// Outputs whose creating message is not available anymore are part of the bundle without an inclusion proof.
+func (db *Database) AttestationBundle(addresses []iotago.Address, maxOutputs int) (*attestation.Bundle, error) {
+	ledgerCommitment, err := db.LedgerCommitment()
+	if err != nil {
+		return nil, fmt.Errorf("reading ledger commitment failed: %w", err)
+	}
+
+	builder := &attestationBundleBuilder{
+		db: db,
+		bundle: &attestation.Bundle{
+			Version:          attestation.BundleVersion,
+			NetworkID:        strconv.FormatUint(db.snapshot.NetworkID, 10),
+			LedgerIndex:      ledgerCommitment.LedgerIndex,
+			LedgerCommitment: hex.EncodeToString(ledgerCommitment.Commitment[:]),
+			Addresses:        make([]*attestation.BundleAddress, 0, len(addresses)),
+			Messages:         make([]*attestation.BundleMessage, 0),
+			Milestones:       make([]*attestation.BundleMilestone, 0),
+		},
+		includedMessageIDs: make(map[milestone.Index][]iotago.MessageID),
+		messages:           make(map[string]struct{}),
+		milestones:         make(map[milestone.Index]struct{}),
+	}
+
+	var outputCount int
+	for _, address := range addresses {
+		outputs, err := db.utxoManager.UnspentOutputs(utxo.FilterAddress(address))
+		if err != nil {
+			return nil, fmt.Errorf("reading unspent outputs failed, address: %s, error: %w", address, err)
+		}
+
+		outputCount += len(outputs)
+		if maxOutputs > 0 && outputCount > maxOutputs {
+			return nil, fmt.Errorf("%w: more than %d", ErrAttestationTooManyOutputs, maxOutputs)
+		}
+
+		sort.Slice(outputs, func(i, j int) bool {
+			return bytes.Compare(outputs[i].OutputID()[:], outputs[j].OutputID()[:]) < 0
+		})
+
+		bundleAddress := &attestation.BundleAddress{
+			AddressType: address.Type(),
+			Address:     address.String(),
+			Outputs:     make([]*attestation.BundleOutput, len(outputs)),
+		}
+
+		for i, output := range outputs {
+			inclusion, migrated, err := builder.inclusionProof(output)
+			if err != nil {
+				return nil, fmt.Errorf("building inclusion proof failed, output: %s, error: %w", output.OutputID().ToHex(), err)
+			}
+
+			bundleAddress.Balance += output.Amount()
+			bundleAddress.Outputs[i] = &attestation.BundleOutput{
+				OutputID:   output.OutputID().ToHex(),
+				OutputType: output.OutputType(),
+				Amount:     output.Amount(),
+				MessageID:  output.MessageID().ToHex(),
+				Migrated:   migrated,
+				Inclusion:  inclusion,
+			}
+		}
+
+		builder.bundle.Addresses = append(builder.bundle.Addresses, bundleAddress)
+	}
+
+	return builder.bundle, nil
+}
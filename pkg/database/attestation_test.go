@@ -0,0 +1,106 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/serializer"
+	"github.com/iotaledger/inx-api-core-v1/pkg/attestation"
+	"github.com/iotaledger/inx-api-core-v1/pkg/hornet"
+	"github.com/iotaledger/inx-api-core-v1/pkg/milestone"
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+// toMessageIDArrays converts the message IDs to the iota.go message IDs.
+func toMessageIDArrays(messageIDs hornet.MessageIDs) []iotago.MessageID {
+	arrays := make([]iotago.MessageID, len(messageIDs))
+	for i, messageID := range messageIDs {
+		copy(arrays[i][:], messageID)
+	}
+
+	return arrays
+}
+
+// storeTestMilestoneMessage stores the raw milestone message with the given parents and inclusion merkle proof.
+func storeTestMilestoneMessage(t *testing.T, db *Database, messageID hornet.MessageID, msIndex milestone.Index, parents hornet.MessageIDs, inclusionMerkleProof iotago.MilestoneInclusionMerkleProof) {
+	t.Helper()
+
+	msg := &iotago.Message{
+		Parents: toMessageIDArrays(parents),
+		Payload: &iotago.Milestone{
+			Index:                uint32(msIndex),
+			Timestamp:            1010,
+			Parents:              toMessageIDArrays(parents),
+			InclusionMerkleProof: inclusionMerkleProof,
+			PublicKeys:           []iotago.MilestonePublicKey{{1}},
+			Signatures:           []iotago.MilestoneSignature{{1}},
+		},
+	}
+
+	data, err := msg.Serialize(serializer.DeSeriModeNoValidation)
+	require.NoError(t, err)
+
+	require.NoError(t, db.messagesStore.Set(messageID, data))
+}
+
+func TestWhiteFlagIncludedMessageIDs(t *testing.T) {
+	db := newTestDatabase(t)
+	messageIDs := storeTestMilestoneCone(t, db)
+
+	toArrays := func(names ...string) []iotago.MessageID {
+		ids := make(hornet.MessageIDs, len(names))
+		for i, name := range names {
+			ids[i] = messageIDs[name]
+		}
+
+		return toMessageIDArrays(ids)
+	}
+
+	tests := []struct {
+		name string
+		// the parents of the milestone message in their order
+		parents []string
+		// the included messages in white-flag order
+		included []string
+	}{
+		{
+			// A is applied after its parents C and X, D after its parent Z (missing) before the conflicting B
+			name:     "parents in DAG order",
+			parents:  []string{"A", "B"},
+			included: []string{"A", "D"},
+		},
+		{
+			// the order of the parents of the milestone determines the order of the included messages
+			name:     "parents in reverse order",
+			parents:  []string{"B", "A"},
+			included: []string{"D", "A"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parents := make(hornet.MessageIDs, len(tt.parents))
+			for i, name := range tt.parents {
+				parents[i] = messageIDs[name]
+			}
+			storeTestMilestoneMessage(t, db, messageIDs["M"], 5, parents, attestation.MerkleRoot(toArrays(tt.included...)))
+
+			included, err := db.whiteFlagIncludedMessageIDs(5)
+			require.NoError(t, err)
+			require.Equal(t, toArrays(tt.included...), included)
+		})
+	}
+
+	// the included messages must match the inclusion merkle proof of the milestone
+	storeTestMilestoneMessage(t, db, messageIDs["M"], 5, hornet.MessageIDs{messageIDs["A"], messageIDs["B"]}, attestation.MerkleRoot(toArrays("D", "A")))
+	_, err := db.whiteFlagIncludedMessageIDs(5)
+	require.Error(t, err)
+
+	_, err = db.whiteFlagIncludedMessageIDs(6)
+	require.ErrorIs(t, err, ErrMilestoneNotFound)
+
+	// milestone 4 is stored without its milestone message
+	_, err = db.whiteFlagIncludedMessageIDs(4)
+	require.Error(t, err)
+}
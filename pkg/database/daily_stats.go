@@ -0,0 +1,472 @@
+package database
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/serializer"
+	"github.com/iotaledger/hive.go/serializer/v2/marshalutil"
+	"github.com/iotaledger/inx-api-core-v1/pkg/hornet"
+	"github.com/iotaledger/inx-api-core-v1/pkg/milestone"
+	"github.com/iotaledger/inx-api-core-v1/pkg/utxo"
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+const (
+	// DailyStatsIndexVersion is the version of the layout of the daily stats index.
+	DailyStatsIndexVersion = 3
+
+	secondsPerDay = 24 * 60 * 60
+)
+
+/*
+
+   Daily stats:
+   ============
+   Key:
+       Day (days since unix epoch, UTC)
+           4 bytes (big endian)
+
+   Value:
+       MilestoneCount + TransactionCount + ConflictingTransactionCount + IndexationMessageCount + ValueMoved + ActiveAddressCount + NewAddressCount
+           8 bytes    +      8 bytes     +           8 bytes           +         8 bytes        +  8 bytes   +       8 bytes      +     8 bytes
+
+   The messages are assigned to the day of the milestone that referenced them.
+   While the index is built, the temporary store contains the serialized addresses that were already seen.
+
+*/
+
+// DailyStats contains the aggregated network statistics of a day (UTC).
+type DailyStats struct {
+	// The start of the day (UTC).
+	Date time.Time
+	// The amount of milestones issued on this day.
+	MilestoneCount int64
+	// The amount of referenced transactions that were included in the ledger.
+	TransactionCount int64
+	// The amount of referenced transactions that were conflicting.
+	ConflictingTransactionCount int64
+	// The amount of referenced messages with an indexation payload.
+	IndexationMessageCount int64
+	// The sum of the amounts of all outputs consumed by the milestones of this day.
+	ValueMoved uint64
+	// The amount of distinct addresses of all outputs created or consumed by the milestones of this day.
+	ActiveAddressCount int64
+	// The amount of addresses that received their first output on this day.
+	NewAddressCount int64
+}
+
+func dailyStatsDay(timestamp int64) uint32 {
+	return uint32(timestamp / secondsPerDay)
+}
+
+func dailyStatsKey(day uint32) []byte {
+	key := make([]byte, 4)
+	binary.BigEndian.PutUint32(key, day)
+
+	return key
+}
+
+func (s *DailyStats) bytes() []byte {
+	m := marshalutil.New(56)
+	m.WriteInt64(s.MilestoneCount)
+	m.WriteInt64(s.TransactionCount)
+	m.WriteInt64(s.ConflictingTransactionCount)
+	m.WriteInt64(s.IndexationMessageCount)
+	m.WriteUint64(s.ValueMoved)
+	m.WriteInt64(s.ActiveAddressCount)
+	m.WriteInt64(s.NewAddressCount)
+
+	return m.Bytes()
+}
+
+func dailyStatsFromKeyAndValue(key []byte, value []byte) (*DailyStats, error) {
+	if len(key) != 4 {
+		return nil, fmt.Errorf("invalid daily stats key length: %d", len(key))
+	}
+
+	s := &DailyStats{
+		Date: time.Unix(int64(binary.BigEndian.Uint32(key))*secondsPerDay, 0).UTC(),
+	}
+
+	marshalUtil := marshalutil.New(value)
+
+	var err error
+	if s.MilestoneCount, err = marshalUtil.ReadInt64(); err != nil {
+		return nil, err
+	}
+	if s.TransactionCount, err = marshalUtil.ReadInt64(); err != nil {
+		return nil, err
+	}
+	if s.ConflictingTransactionCount, err = marshalUtil.ReadInt64(); err != nil {
+		return nil, err
+	}
+	if s.IndexationMessageCount, err = marshalUtil.ReadInt64(); err != nil {
+		return nil, err
+	}
+	if s.ValueMoved, err = marshalUtil.ReadUint64(); err != nil {
+		return nil, err
+	}
+	if s.ActiveAddressCount, err = marshalUtil.ReadInt64(); err != nil {
+		return nil, err
+	}
+	if s.NewAddressCount, err = marshalUtil.ReadInt64(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// milestoneDays maps the milestone indexes to the days of their timestamps.
+type milestoneDays struct {
+	first milestone.Index
+	days  []uint32
+}
+
+// day returns the day of the milestone and false if the milestone is unknown.
+func (m *milestoneDays) day(msIndex milestone.Index) (uint32, bool) {
+	if msIndex < m.first || int(msIndex-m.first) >= len(m.days) {
+		return 0, false
+	}
+
+	day := m.days[msIndex-m.first]
+
+	return day, day != 0
+}
+
+// loadMilestoneDays reads the days of the timestamps of all milestones.
+func (db *Database) loadMilestoneDays() (*milestoneDays, error) {
+	var first, last milestone.Index
+	var found bool
+	if err := db.milestonesStore.IterateKeys(kvstore.EmptyPrefix, func(key kvstore.Key) bool {
+		msIndex := milestoneIndexFromDatabaseKey(key)
+		if !found || msIndex < first {
+			first = msIndex
+		}
+		if !found || msIndex > last {
+			last = msIndex
+		}
+		found = true
+
+		return true
+	}); err != nil {
+		return nil, fmt.Errorf("iterating over all milestones failed: %w", err)
+	}
+
+	if !found {
+		return &milestoneDays{}, nil
+	}
+
+	msDays := &milestoneDays{
+		first: first,
+		days:  make([]uint32, last-first+1),
+	}
+
+	if err := db.milestonesStore.Iterate(kvstore.EmptyPrefix, func(key kvstore.Key, value kvstore.Value) bool {
+		ms := milestoneFactory(key, value)
+		msDays.days[ms.Index-first] = dailyStatsDay(ms.Timestamp.Unix())
+
+		return true
+	}); err != nil {
+		return nil, fmt.Errorf("iterating over all milestones failed: %w", err)
+	}
+
+	return msDays, nil
+}
+
+// outputCreatedAtOrBeforePruningIndex returns whether the output was created at or before the pruning index.
+// The metadata of messages at or before the pruning index is not available.
+func (db *Database) outputCreatedAtOrBeforePruningIndex(output *utxo.Output) bool {
+	metadata := db.MessageMetadataOrNil(output.MessageID())
+	if metadata == nil {
+		return true
+	}
+
+	referenced, msIndex := metadata.ReferencedWithIndex()
+
+	return !referenced || msIndex <= db.snapshot.PruningIndex
+}
+
+// createDailyStatsIndex aggregates the network statistics per day.
+func (db *Database) createDailyStatsIndex(ctx context.Context) error {
+	// first we need to delete the old index before we rebuild it
+	if err := db.resetIndex(IndexStorePrefixDailyStats, db.dailyStatsStore); err != nil {
+		return fmt.Errorf("deleting daily stats index failed: %w", err)
+	}
+
+	msDays, err := db.loadMilestoneDays()
+	if err != nil {
+		return err
+	}
+
+	stats := make(map[uint32]*DailyStats)
+	statsForDay := func(day uint32) *DailyStats {
+		s, exists := stats[day]
+		if !exists {
+			s = &DailyStats{}
+			stats[day] = s
+		}
+
+		return s
+	}
+
+	for _, day := range msDays.days {
+		if day != 0 {
+			statsForDay(day).MilestoneCount++
+		}
+	}
+
+	var innerErr error
+	progress := db.newProgressLogger(ctx, "daily stats")
+
+	// count the transactions by the day of the milestone that referenced them
+	var metadataCounter int64
+	if err := db.metadataStore.Iterate(kvstore.EmptyPrefix, func(key []byte, data []byte) bool {
+		metadataCounter++
+		if err := progress.Log("analyzed %d messages", metadataCounter); err != nil {
+			innerErr = err
+
+			return false
+		}
+
+		messageID := hornet.MessageIDFromSlice(key[:iotago.MessageIDLength])
+
+		msgMeta, err := metadataFactory(messageID, data)
+		if err != nil {
+			innerErr = fmt.Errorf("failed to deserialize message metadata: %s, error: %w", messageID.ToHex(), err)
+
+			return false
+		}
+
+		referenced, referencedIndex := msgMeta.ReferencedWithIndex()
+		if !referenced || msgMeta.IsNoTransaction() {
+			return true
+		}
+
+		day, known := msDays.day(referencedIndex)
+		if !known {
+			return true
+		}
+
+		if msgMeta.IsConflictingTx() {
+			statsForDay(day).ConflictingTransactionCount++
+		} else {
+			statsForDay(day).TransactionCount++
+		}
+
+		return true
+	}, kvstore.IterDirectionForward); err != nil {
+		return fmt.Errorf("iterating over all existing messages failed: %w", err)
+	}
+	if innerErr != nil {
+		return innerErr
+	}
+
+	// count the indexation messages by the day of the milestone that referenced them
+	var indexationCounter int64
+	if err := db.indexationStore.IterateKeys(kvstore.EmptyPrefix, func(key []byte) bool {
+		indexationCounter++
+		if err := progress.Log("analyzed %d indexation messages", indexationCounter); err != nil {
+			innerErr = err
+
+			return false
+		}
+
+		msgMeta := db.MessageMetadataOrNil(hornet.MessageIDFromSlice(key[IndexationIndexLength : IndexationIndexLength+iotago.MessageIDLength]))
+		if msgMeta == nil {
+			return true
+		}
+
+		referenced, referencedIndex := msgMeta.ReferencedWithIndex()
+		if !referenced {
+			return true
+		}
+
+		if day, known := msDays.day(referencedIndex); known {
+			statsForDay(day).IndexationMessageCount++
+		}
+
+		return true
+	}); err != nil {
+		return fmt.Errorf("iterating over all indexation keys failed: %w", err)
+	}
+	if innerErr != nil {
+		return innerErr
+	}
+
+	// the addresses of outputs created at or before the pruning index already received funds,
+	// the milestone diffs of these outputs are not available.
+	var outputCounter int64
+	seedOutput := func(output *utxo.Output) bool {
+		outputCounter++
+		if err := progress.Log("analyzed %d outputs", outputCounter); err != nil {
+			innerErr = err
+
+			return false
+		}
+
+		if !db.outputCreatedAtOrBeforePruningIndex(output) {
+			return true
+		}
+
+		addrBytes, err := output.Address().Serialize(serializer.DeSeriModeNoValidation)
+		if err != nil {
+			innerErr = fmt.Errorf("failed to serialize address: %s, error: %w", output.Address(), err)
+
+			return false
+		}
+
+		if err := db.temporaryStore.Set(addrBytes, []byte{}); err != nil {
+			innerErr = fmt.Errorf("storing seen address failed: %w", err)
+
+			return false
+		}
+
+		return true
+	}
+
+	if err := db.utxoManager.ForEachUnspentOutput(seedOutput); err != nil {
+		return fmt.Errorf("iterating over unspent outputs failed: %w", err)
+	}
+	if innerErr != nil {
+		return innerErr
+	}
+
+	if err := db.utxoManager.ForEachSpentOutput(func(spent *utxo.Spent) bool {
+		return seedOutput(spent.Output())
+	}); err != nil {
+		return fmt.Errorf("iterating over spent outputs failed: %w", err)
+	}
+	if innerErr != nil {
+		return innerErr
+	}
+
+	// walk the milestone diffs in order to find the active and the new addresses of every day
+	var currentDay uint32
+	activeAddresses := make(map[string]struct{})
+
+	// the addresses of consumed outputs are marked as seen without being counted as new,
+	// they received the output before the milestone diffs that are available.
+	addAddress := func(day uint32, address iotago.Address, created bool) error {
+		addrBytes, err := address.Serialize(serializer.DeSeriModeNoValidation)
+		if err != nil {
+			return fmt.Errorf("failed to serialize address: %s, error: %w", address, err)
+		}
+
+		if _, exists := activeAddresses[string(addrBytes)]; !exists {
+			activeAddresses[string(addrBytes)] = struct{}{}
+			statsForDay(day).ActiveAddressCount++
+		}
+
+		seen, err := db.temporaryStore.Has(addrBytes)
+		if err != nil {
+			return fmt.Errorf("reading seen address failed: %w", err)
+		}
+
+		if seen {
+			return nil
+		}
+
+		if created {
+			statsForDay(day).NewAddressCount++
+		}
+
+		if err := db.temporaryStore.Set(addrBytes, []byte{}); err != nil {
+			return fmt.Errorf("storing seen address failed: %w", err)
+		}
+
+		return nil
+	}
+
+	for i, day := range msDays.days {
+		msIndex := msDays.first + milestone.Index(i)
+
+		if err := progress.Log("analyzed %d milestone diffs", i); err != nil {
+			return err
+		}
+
+		if day == 0 {
+			continue
+		}
+
+		diff, err := db.utxoManager.MilestoneDiff(msIndex)
+		if err != nil {
+			if errors.Is(err, kvstore.ErrKeyNotFound) {
+				// the milestone diff is not available
+				continue
+			}
+
+			return fmt.Errorf("reading milestone diff %d failed: %w", msIndex, err)
+		}
+
+		if day != currentDay {
+			currentDay = day
+			activeAddresses = make(map[string]struct{})
+		}
+
+		for _, spent := range diff.Spents {
+			statsForDay(day).ValueMoved += spent.Amount()
+
+			if err := addAddress(day, spent.Address(), false); err != nil {
+				return err
+			}
+		}
+
+		for _, output := range diff.Outputs {
+			if err := addAddress(day, output.Address(), true); err != nil {
+				return err
+			}
+		}
+	}
+
+	for day, s := range stats {
+		if err := db.dailyStatsStore.Set(dailyStatsKey(day), s.bytes()); err != nil {
+			return fmt.Errorf("setting entry in daily stats index failed: %w", err)
+		}
+	}
+
+	// the seen addresses are not needed anymore
+	return db.temporaryStore.Clear()
+}
+
+// DailyStats returns the aggregated network statistics of all days between from and to (inclusive, UTC).
+func (db *Database) DailyStats(from time.Time, to time.Time) ([]*DailyStats, error) {
+	fromDay := dailyStatsDay(from.Unix())
+	toDay := dailyStatsDay(to.Unix())
+
+	var innerErr error
+	stats := make([]*DailyStats, 0)
+	if err := db.dailyStatsStore.Iterate(kvstore.EmptyPrefix, func(key kvstore.Key, value kvstore.Value) bool {
+		day := binary.BigEndian.Uint32(key)
+		if day < fromDay {
+			return true
+		}
+
+		if day > toDay {
+			return false
+		}
+
+		s, err := dailyStatsFromKeyAndValue(key, value)
+		if err != nil {
+			innerErr = err
+
+			return false
+		}
+
+		stats = append(stats, s)
+
+		return true
+	}); err != nil {
+		return nil, err
+	}
+
+	if innerErr != nil {
+		return nil, innerErr
+	}
+
+	return stats, nil
+}
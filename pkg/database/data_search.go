@@ -0,0 +1,218 @@
+package database
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/serializer/v2/byteutils"
+	"github.com/iotaledger/inx-api-core-v1/pkg/hornet"
+	"github.com/iotaledger/inx-api-core-v1/pkg/milestone"
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+const (
+	// DataSearchIndexVersion is the version of the layout of the data search index.
+	DataSearchIndexVersion = 1
+
+	// dataSearchMinTokenLength is the minimum amount of characters of an indexed token.
+	dataSearchMinTokenLength = 2
+	// dataSearchMaxTokenLength is the maximum amount of bytes of an indexed token. Longer tokens are not indexed.
+	dataSearchMaxTokenLength = 64
+)
+
+/*
+
+   Data search:
+   ============
+   Key:
+       TokenLength + Token + MilestoneIndex + MessageID
+          1 byte   + ≤64 bytes + 4 bytes (big endian) + 32 bytes
+
+   Value:
+       Empty
+
+   The tokens are the lower case words of the UTF-8 text in the data of referenced indexation payloads.
+   The length prefix separates tokens that are prefixes of other tokens,
+   the big endian milestone index sorts the messages of a token by the milestone that referenced them.
+
+*/
+
+// DataSearchResult is a message whose indexation payload data matched a search query.
+type DataSearchResult struct {
+	// The ID of the message.
+	MessageID hornet.MessageID
+	// The milestone index that references the message.
+	MilestoneIndex milestone.Index
+}
+
+// dataSearchTokens splits the text into distinct lower case words.
+// Words shorter than dataSearchMinTokenLength characters or longer than dataSearchMaxTokenLength bytes are ignored.
+func dataSearchTokens(text string) []string {
+	seen := make(map[string]struct{})
+	tokens := make([]string, 0)
+
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if utf8.RuneCountInString(word) < dataSearchMinTokenLength || len(word) > dataSearchMaxTokenLength {
+			continue
+		}
+
+		if _, exists := seen[word]; exists {
+			continue
+		}
+		seen[word] = struct{}{}
+		tokens = append(tokens, word)
+	}
+
+	return tokens
+}
+
+func dataSearchTokenPrefix(token string) []byte {
+	return byteutils.ConcatBytes([]byte{byte(len(token))}, []byte(token))
+}
+
+func dataSearchKey(token string, msIndex milestone.Index, messageID hornet.MessageID) []byte {
+	msIndexBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(msIndexBytes, uint32(msIndex))
+
+	return byteutils.ConcatBytes(dataSearchTokenPrefix(token), msIndexBytes, messageID)
+}
+
+// DataSearchEnabled returns true if the data search index is built.
+func (db *Database) DataSearchEnabled() bool {
+	return db.dataSearchEnabled
+}
+
+// createDataSearchIndex creates the inverted index over the UTF-8 text in the data of the indexation payloads.
+func (db *Database) createDataSearchIndex(ctx context.Context) error {
+	// first we need to delete the old index before we rebuild it
+	if err := db.resetIndex(IndexStorePrefixDataSearch, db.dataSearchStore); err != nil {
+		return fmt.Errorf("deleting data search index failed: %w", err)
+	}
+
+	var innerErr error
+	progress := db.newProgressLogger(ctx, "data search")
+
+	var messageCounter int64
+	if err := db.indexationStore.IterateKeys(kvstore.EmptyPrefix, func(key []byte) bool {
+		messageCounter++
+		if err := progress.Log("analyzed %d messages", messageCounter); err != nil {
+			innerErr = err
+
+			return false
+		}
+
+		messageID := hornet.MessageIDFromSlice(key[IndexationIndexLength : IndexationIndexLength+iotago.MessageIDLength])
+
+		// only messages that were referenced by a milestone are part of the history
+		msgMeta := db.MessageMetadataOrNil(messageID)
+		if msgMeta == nil {
+			return true
+		}
+
+		referenced, referencedIndex := msgMeta.ReferencedWithIndex()
+		if !referenced {
+			return true
+		}
+
+		msg := db.MessageOrNil(messageID)
+		if msg == nil {
+			innerErr = fmt.Errorf("message not found: %s", messageID.ToHex())
+
+			return false
+		}
+
+		indexation := msg.Indexation()
+		if indexation == nil || !utf8.Valid(indexation.Data) {
+			return true
+		}
+
+		for _, token := range dataSearchTokens(string(indexation.Data)) {
+			if err := db.dataSearchStore.Set(dataSearchKey(token, referencedIndex, messageID), []byte{}); err != nil {
+				innerErr = fmt.Errorf("setting entry in data search index failed, msgID: %s, error: %w", messageID.ToHex(), err)
+
+				return false
+			}
+		}
+
+		return true
+	}); err != nil {
+		return fmt.Errorf("iterating over all indexation keys failed: %w", err)
+	}
+
+	return innerErr
+}
+
+// SearchData returns the messages whose indexation payload data contains all words of the query,
+// sorted by the milestone that referenced them, starting at the given offset.
+func (db *Database) SearchData(query string, offset int, maxResults int) ([]*DataSearchResult, error) {
+	tokens := dataSearchTokens(query)
+	if len(tokens) == 0 || maxResults <= 0 {
+		return []*DataSearchResult{}, nil
+	}
+
+	// the longest token is likely the most selective one
+	first := 0
+	for i, token := range tokens {
+		if len(token) > len(tokens[first]) {
+			first = i
+		}
+	}
+	others := append(append([]string{}, tokens[:first]...), tokens[first+1:]...)
+
+	firstPrefix := dataSearchTokenPrefix(tokens[first])
+
+	var innerErr error
+	var i int
+	results := make([]*DataSearchResult, 0)
+	if err := db.dataSearchStore.IterateKeys(firstPrefix, func(key kvstore.Key) bool {
+		if len(key) != len(firstPrefix)+4+iotago.MessageIDLength {
+			innerErr = fmt.Errorf("invalid data search key length: %d", len(key))
+
+			return false
+		}
+
+		msIndex := milestone.Index(binary.BigEndian.Uint32(key[len(firstPrefix) : len(firstPrefix)+4]))
+		messageID := hornet.MessageIDFromSlice(key[len(firstPrefix)+4:])
+
+		for _, token := range others {
+			contains, err := db.dataSearchStore.Has(dataSearchKey(token, msIndex, messageID))
+			if err != nil {
+				innerErr = err
+
+				return false
+			}
+
+			if !contains {
+				return true
+			}
+		}
+
+		i++
+		if i <= offset {
+			return true
+		}
+
+		results = append(results, &DataSearchResult{
+			MessageID:      messageID,
+			MilestoneIndex: msIndex,
+		})
+
+		// stop as soon as the page is full, the rest of the posting list is not needed
+		return len(results) < maxResults
+	}); err != nil {
+		return nil, err
+	}
+
+	if innerErr != nil {
+		return nil, innerErr
+	}
+
+	return results, nil
+}
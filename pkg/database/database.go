@@ -41,6 +41,7 @@ type Database struct {
 	// databases
 	tangleDatabase kvstore.KVStore
 	utxoDatabase   kvstore.KVStore
+	indexDatabase  kvstore.KVStore
 
 	// kv stores
 	messagesStore                kvstore.KVStore
@@ -51,9 +52,42 @@ type Database struct {
 	indexationStore              kvstore.KVStore
 	conflictingTransactionsStore kvstore.KVStore
 
+	// index stores
+	indexStatusStore       kvstore.KVStore
+	addressHistoryStore    kvstore.KVStore
+	publicKeysStore        kvstore.KVStore
+	richListStore          kvstore.KVStore
+	migrationsStore        kvstore.KVStore
+	indexationCatalogStore kvstore.KVStore
+	dataSearchStore        kvstore.KVStore
+	dailyStatsStore        kvstore.KVStore
+	dormancyStore          kvstore.KVStore
+	addressClustersStore   kvstore.KVStore
+	ledgerCommitmentsStore kvstore.KVStore
+	temporaryStore         kvstore.KVStore
+
 	// snapshot info
 	snapshot *SnapshotInfo
 
+	// whether the optional data search index is built
+	dataSearchEnabled bool
+
+	// whether the change address heuristic is used to build the address clusters index
+	addressClustersChangeHeuristic bool
+
+	// the milestone at which the milestone commitment chain of the ledger commitments index is anchored
+	commitmentAnchorIndex milestone.Index
+
+	// the totals of the rich list, computed after the index was built
+	richListSummary *RichListSummary
+
+	// the unspent supply bucketed by age, computed after the index was built
+	dormancySummary *DormancySummary
+
+	// the store prefixes of the indexes that were built and can be queried
+	readyIndexes     map[byte]struct{}
+	readyIndexesLock sync.RWMutex
+
 	// utxo
 	utxoManager *utxo.Manager
 
@@ -62,7 +96,17 @@ type Database struct {
 	syncStateOnce sync.Once
 }
 
-func New(ctx context.Context, log *logger.Logger, tangleDatabasePath string, utxoDatabasePath string, networkID uint64, skipHealthCheck bool) (*Database, error) {
+// IndexOptions defines the optional indexes and how the indexes are built.
+type IndexOptions struct {
+	// whether to build the full-text search index over the UTF-8 text in indexation payloads
+	DataSearch bool
+	// whether to add change addresses to the address clusters in addition to the common-input-ownership heuristic
+	ClusterChangeHeuristic bool
+	// the well-known milestone at which the milestone commitment chain is anchored
+	CommitmentAnchorIndex milestone.Index
+}
+
+func New(ctx context.Context, log *logger.Logger, tangleDatabasePath string, utxoDatabasePath string, indexDatabasePath string, networkID uint64, indexOptions *IndexOptions, skipHealthCheck bool) (*Database, error) {
 
 	checkDatabaseHealth := func(store kvstore.KVStore) error {
 		healthTracker, err := kvstore.NewStoreHealthTracker(store, kvstore.KeyPrefix{StorePrefixHealth}, DBVersion, nil)
@@ -102,20 +146,40 @@ func New(ctx context.Context, log *logger.Logger, tangleDatabasePath string, utx
 		}
 
 		db := &Database{
-			WrappedLogger:                logger.NewWrappedLogger(log),
-			tangleDatabase:               tangleDatabase,
-			utxoDatabase:                 utxoDatabase,
-			messagesStore:                lo.PanicOnErr(tangleDatabase.WithRealm([]byte{StorePrefixMessages})),
-			metadataStore:                lo.PanicOnErr(tangleDatabase.WithRealm([]byte{StorePrefixMessageMetadata})),
-			milestonesStore:              lo.PanicOnErr(tangleDatabase.WithRealm([]byte{StorePrefixMilestones})),
-			snapshotStore:                lo.PanicOnErr(tangleDatabase.WithRealm([]byte{StorePrefixSnapshot})),
-			childrenStore:                lo.PanicOnErr(tangleDatabase.WithRealm([]byte{StorePrefixChildren})),
-			indexationStore:              lo.PanicOnErr(tangleDatabase.WithRealm([]byte{StorePrefixIndexation})),
-			conflictingTransactionsStore: lo.PanicOnErr(tangleDatabase.WithRealm([]byte{StorePrefixConflictingTransactions})),
-			snapshot:                     nil,
-			utxoManager:                  utxo.New(utxoDatabase),
-			syncState:                    nil,
-			syncStateOnce:                sync.Once{},
+			WrappedLogger:                  logger.NewWrappedLogger(log),
+			tangleDatabase:                 tangleDatabase,
+			utxoDatabase:                   utxoDatabase,
+			indexDatabase:                  nil,
+			messagesStore:                  lo.PanicOnErr(tangleDatabase.WithRealm([]byte{StorePrefixMessages})),
+			metadataStore:                  lo.PanicOnErr(tangleDatabase.WithRealm([]byte{StorePrefixMessageMetadata})),
+			milestonesStore:                lo.PanicOnErr(tangleDatabase.WithRealm([]byte{StorePrefixMilestones})),
+			snapshotStore:                  lo.PanicOnErr(tangleDatabase.WithRealm([]byte{StorePrefixSnapshot})),
+			childrenStore:                  lo.PanicOnErr(tangleDatabase.WithRealm([]byte{StorePrefixChildren})),
+			indexationStore:                lo.PanicOnErr(tangleDatabase.WithRealm([]byte{StorePrefixIndexation})),
+			conflictingTransactionsStore:   lo.PanicOnErr(tangleDatabase.WithRealm([]byte{StorePrefixConflictingTransactions})),
+			indexStatusStore:               nil,
+			addressHistoryStore:            nil,
+			publicKeysStore:                nil,
+			richListStore:                  nil,
+			migrationsStore:                nil,
+			indexationCatalogStore:         nil,
+			dataSearchStore:                nil,
+			dailyStatsStore:                nil,
+			dormancyStore:                  nil,
+			addressClustersStore:           nil,
+			ledgerCommitmentsStore:         nil,
+			dataSearchEnabled:              indexOptions.DataSearch,
+			addressClustersChangeHeuristic: indexOptions.ClusterChangeHeuristic,
+			commitmentAnchorIndex:          indexOptions.CommitmentAnchorIndex,
+			temporaryStore:                 nil,
+			snapshot:                       nil,
+			richListSummary:                nil,
+			dormancySummary:                nil,
+			readyIndexes:                   make(map[byte]struct{}),
+			readyIndexesLock:               sync.RWMutex{},
+			utxoManager:                    utxo.New(utxoDatabase),
+			syncState:                      nil,
+			syncStateOnce:                  sync.Once{},
 		}
 
 		if err := db.loadSnapshotInfo(); err != nil {
@@ -179,6 +243,13 @@ func New(ctx context.Context, log *logger.Logger, tangleDatabasePath string, utx
 		}
 	}
 
+	// the indexes are stored in a separate database, which is always opened in write mode
+	if err := db.openIndexDatabase(indexDatabasePath); err != nil {
+		_ = db.CloseDatabases()
+		return nil, err
+	}
+
+	// the indexes are built in the background by BuildIndexes
 	return db, nil
 }
 
@@ -194,6 +265,11 @@ func (db *Database) CloseDatabases() error {
 	if err := db.utxoDatabase.Close(); err != nil {
 		closeError = err
 	}
+	if db.indexDatabase != nil {
+		if err := db.indexDatabase.Close(); err != nil {
+			closeError = err
+		}
+	}
 
 	return closeError
 }
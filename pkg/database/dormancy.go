@@ -0,0 +1,371 @@
+package database
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/runtime/contextutils"
+	"github.com/iotaledger/hive.go/serializer/v2/byteutils"
+	"github.com/iotaledger/hive.go/serializer/v2/marshalutil"
+	"github.com/iotaledger/inx-api-core-v1/pkg/milestone"
+	"github.com/iotaledger/inx-api-core-v1/pkg/utxo"
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+const (
+	// DormancyIndexVersion is the version of the layout of the dormancy index.
+	DormancyIndexVersion = 2
+
+	// DormancyMaxAgeDays is the maximum age in days that dormant outputs can be queried for.
+	DormancyMaxAgeDays = 100 * 365
+
+	// dormancyAbortCheckInterval is the amount of scanned outputs after which a query checks whether it was aborted.
+	dormancyAbortCheckInterval = 10000
+
+	dormancyFlagMigrated byte = 1 << 0
+)
+
+// DormancyBucketUpperBoundsDays are the exclusive upper bounds of the age buckets of the dormancy summary in days.
+// A last bucket without upper bound is always added.
+var DormancyBucketUpperBoundsDays = []int{30, 182, 365, 730}
+
+/*
+
+   Dormancy:
+   =========
+   Key:
+       CreatedAtMilestoneIndex + OutputID
+               4 bytes         + 34 bytes
+             (big endian)
+
+   Value:
+       Amount  + Flags
+       8 bytes + 1 byte
+
+   The index contains all unspent outputs sorted by the milestone that created them (oldest first).
+   Outputs created at or before the pruning index are stored with the pruning index as the creation milestone.
+   Flags: bit 0 is set if the output was created by a receipt of the migration from the legacy network.
+   The migrated outputs are taken from the migrations index, which is built before the dormancy index.
+
+*/
+
+// DormantOutput is an unspent output in the dormancy index.
+type DormantOutput struct {
+	// The ID of the output.
+	OutputID *iotago.UTXOInputID
+	// The index of the milestone that created the output.
+	// Outputs created at or before the pruning index are assigned to the pruning index.
+	CreatedAtMilestoneIndex milestone.Index
+	// The amount of the output.
+	Amount uint64
+	// Whether the output was created by a receipt of the migration from the legacy network.
+	Migrated bool
+}
+
+// DormancyBucket contains the unspent outputs with an age in the range of the bucket.
+type DormancyBucket struct {
+	// The inclusive lower bound of the age of the outputs in this bucket in days.
+	MinAgeDays int
+	// The exclusive upper bound of the age of the outputs in this bucket in days. Zero for the last bucket without upper bound.
+	MaxAgeDays int
+	// The amount of unspent outputs in this bucket.
+	OutputCount int64
+	// The sum of the amounts of the unspent outputs in this bucket.
+	Amount uint64
+	// The amount of unspent outputs in this bucket that were created by the migration.
+	MigratedOutputCount int64
+	// The sum of the amounts of the unspent outputs in this bucket that were created by the migration.
+	MigratedAmount uint64
+}
+
+// DormancySummary contains the unspent supply bucketed by the age of the outputs.
+type DormancySummary struct {
+	// The ledger index at which the summary was computed.
+	LedgerIndex milestone.Index
+	// The timestamp of the ledger milestone, the ages of the outputs are relative to this timestamp.
+	ReferenceTimestamp time.Time
+	// The pruning index of the database. The age of outputs created at or before the pruning index is
+	// relative to the snapshot timestamp and therefore a lower bound.
+	PruningIndex milestone.Index
+	// The amount of unspent outputs.
+	OutputCount int64
+	// The sum of the amounts of all unspent outputs.
+	Amount uint64
+	// The sum of the amounts of all unspent outputs that were created by the migration and never moved.
+	MigratedAmount uint64
+	// The unspent outputs bucketed by age.
+	Buckets []*DormancyBucket
+}
+
+func dormancyKey(createdAt milestone.Index, outputID *iotago.UTXOInputID) []byte {
+	msIndexBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(msIndexBytes, uint32(createdAt))
+
+	return byteutils.ConcatBytes(msIndexBytes, outputID[:])
+}
+
+func dormantOutputFromKeyAndValue(key []byte, value []byte) (*DormantOutput, error) {
+	if len(key) != 4+utxo.OutputIDLength {
+		return nil, fmt.Errorf("invalid dormancy key length: %d", len(key))
+	}
+
+	outputID := &iotago.UTXOInputID{}
+	copy(outputID[:], key[4:])
+
+	marshalUtil := marshalutil.New(value)
+
+	amount, err := marshalUtil.ReadUint64()
+	if err != nil {
+		return nil, err
+	}
+
+	flags, err := marshalUtil.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+
+	return &DormantOutput{
+		OutputID:                outputID,
+		CreatedAtMilestoneIndex: milestone.Index(binary.BigEndian.Uint32(key[:4])),
+		Amount:                  amount,
+		Migrated:                flags&dormancyFlagMigrated != 0,
+	}, nil
+}
+
+// createDormancyIndex creates the list of all unspent outputs sorted by the milestone that created them.
+func (db *Database) createDormancyIndex(ctx context.Context) error {
+	// first we need to delete the old index before we rebuild it
+	if err := db.resetIndex(IndexStorePrefixDormancy, db.dormancyStore); err != nil {
+		return fmt.Errorf("deleting dormancy index failed: %w", err)
+	}
+
+	// the migrated outputs are known by the migrations index even if the receipts are pruned
+	if err := db.CheckIndexReady(IndexStorePrefixMigrations); err != nil {
+		return fmt.Errorf("dormancy index depends on the migrations index: %w", err)
+	}
+
+	migratedOutputIDs, err := db.migratedOutputIDs()
+	if err != nil {
+		return err
+	}
+
+	var innerErr error
+	progress := db.newProgressLogger(ctx, "dormancy")
+
+	var outputCounter int64
+	if err := db.utxoManager.ForEachUnspentOutput(func(output *utxo.Output) bool {
+		outputCounter++
+		if err := progress.Log("analyzed %d outputs", outputCounter); err != nil {
+			innerErr = err
+
+			return false
+		}
+
+		// the metadata of messages at or before the pruning index is not available
+		createdAt := db.snapshot.PruningIndex
+		var flags byte
+
+		if metadata := db.MessageMetadataOrNil(output.MessageID()); metadata != nil {
+			if referenced, msIndex := metadata.ReferencedWithIndex(); referenced {
+				createdAt = msIndex
+			}
+		}
+
+		if _, migrated := migratedOutputIDs[*output.OutputID()]; migrated {
+			flags |= dormancyFlagMigrated
+		}
+
+		value := marshalutil.New(9)
+		value.WriteUint64(output.Amount())
+		value.WriteByte(flags)
+
+		if err := db.dormancyStore.Set(dormancyKey(createdAt, output.OutputID()), value.Bytes()); err != nil {
+			innerErr = fmt.Errorf("setting entry in dormancy index failed, output: %s, error: %w", output.OutputID().ToHex(), err)
+
+			return false
+		}
+
+		return true
+	}); err != nil {
+		return fmt.Errorf("iterating over unspent outputs failed: %w", err)
+	}
+
+	return innerErr
+}
+
+// dormancyCutoffIndex returns the first milestone index that is younger than the given age.
+// All outputs created before the returned index are at least as old as the given age.
+func (db *Database) dormancyCutoffIndex(referenceTimestamp time.Time, ageDays int) (time.Time, milestone.Index, error) {
+	// the days are subtracted in UTC, so that the cutoff is not shifted by daylight saving time
+	cutoffTimestamp := referenceTimestamp.UTC().AddDate(0, 0, -ageDays)
+
+	cutoffIndex, err := db.MilestoneIndexByTimestamp(cutoffTimestamp)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+
+	return cutoffTimestamp, cutoffIndex, nil
+}
+
+// isDormant returns whether the output created at the given milestone index is older than the cutoff.
+func (db *Database) isDormant(createdAt milestone.Index, cutoffTimestamp time.Time, cutoffIndex milestone.Index) bool {
+	if createdAt <= db.snapshot.PruningIndex {
+		// the exact creation time is unknown, so the snapshot timestamp is used
+		return db.snapshot.Timestamp.Before(cutoffTimestamp)
+	}
+
+	return createdAt < cutoffIndex
+}
+
+// loadDormancySummary buckets the unspent supply by the age of the outputs.
+func (db *Database) loadDormancySummary() error {
+	ledgerIndex := db.utxoManager.ReadLedgerIndex()
+
+	referenceTimestampUnix, err := db.MilestoneTimestampUnixByIndex(ledgerIndex)
+	if err != nil {
+		return fmt.Errorf("loading dormancy summary failed: %w", err)
+	}
+	referenceTimestamp := time.Unix(referenceTimestampUnix, 0)
+
+	summary := &DormancySummary{
+		LedgerIndex:        ledgerIndex,
+		ReferenceTimestamp: referenceTimestamp,
+		PruningIndex:       db.snapshot.PruningIndex,
+		Buckets:            make([]*DormancyBucket, 0, len(DormancyBucketUpperBoundsDays)+1),
+	}
+
+	type cutoff struct {
+		timestamp time.Time
+		index     milestone.Index
+	}
+
+	// the cutoffs of the upper bounds, the oldest bucket has no cutoff
+	cutoffs := make([]*cutoff, 0, len(DormancyBucketUpperBoundsDays))
+
+	minAgeDays := 0
+	for _, maxAgeDays := range DormancyBucketUpperBoundsDays {
+		cutoffTimestamp, cutoffIndex, err := db.dormancyCutoffIndex(referenceTimestamp, maxAgeDays)
+		if err != nil {
+			return fmt.Errorf("loading dormancy summary failed: %w", err)
+		}
+
+		summary.Buckets = append(summary.Buckets, &DormancyBucket{MinAgeDays: minAgeDays, MaxAgeDays: maxAgeDays})
+		cutoffs = append(cutoffs, &cutoff{timestamp: cutoffTimestamp, index: cutoffIndex})
+		minAgeDays = maxAgeDays
+	}
+	summary.Buckets = append(summary.Buckets, &DormancyBucket{MinAgeDays: minAgeDays})
+
+	var innerErr error
+	if err := db.dormancyStore.Iterate(kvstore.EmptyPrefix, func(key kvstore.Key, value kvstore.Value) bool {
+		output, err := dormantOutputFromKeyAndValue(key, value)
+		if err != nil {
+			innerErr = err
+
+			return false
+		}
+
+		// the output belongs to the youngest bucket it is not older than
+		bucket := summary.Buckets[len(summary.Buckets)-1]
+		for i, cutoff := range cutoffs {
+			if !db.isDormant(output.CreatedAtMilestoneIndex, cutoff.timestamp, cutoff.index) {
+				bucket = summary.Buckets[i]
+
+				break
+			}
+		}
+
+		bucket.OutputCount++
+		bucket.Amount += output.Amount
+		summary.OutputCount++
+		summary.Amount += output.Amount
+
+		if output.Migrated {
+			bucket.MigratedOutputCount++
+			bucket.MigratedAmount += output.Amount
+			summary.MigratedAmount += output.Amount
+		}
+
+		return true
+	}); err != nil {
+		return fmt.Errorf("loading dormancy summary failed: %w", err)
+	}
+	if innerErr != nil {
+		return fmt.Errorf("loading dormancy summary failed: %w", innerErr)
+	}
+
+	db.dormancySummary = summary
+
+	return nil
+}
+
+// DormancySummary returns the unspent supply bucketed by the age of the outputs.
+func (db *Database) DormancySummary() *DormancySummary {
+	return db.dormancySummary
+}
+
+// DormantOutputs returns the unspent outputs that are older than the given age in days
+// and have at least the given amount, sorted by age (oldest first), starting at the given offset.
+// It returns ErrOperationAborted if the context is done before the scan finished.
+func (db *Database) DormantOutputs(ctx context.Context, minAgeDays int, minAmount uint64, offset int, maxResults int) ([]*DormantOutput, error) {
+	cutoffTimestamp, cutoffIndex, err := db.dormancyCutoffIndex(db.dormancySummary.ReferenceTimestamp, minAgeDays)
+	if err != nil {
+		return nil, err
+	}
+
+	var innerErr error
+	var i int
+	var scanned int
+
+	outputs := make([]*DormantOutput, 0)
+	if err := db.dormancyStore.Iterate(kvstore.EmptyPrefix, func(key kvstore.Key, value kvstore.Value) bool {
+		// a high minimum amount may scan the whole index, so the scan stops if the request was aborted
+		scanned++
+		if scanned%dormancyAbortCheckInterval == 0 {
+			if err := contextutils.ReturnErrIfCtxDone(ctx, ErrOperationAborted); err != nil {
+				innerErr = err
+
+				return false
+			}
+		}
+
+		output, err := dormantOutputFromKeyAndValue(key, value)
+		if err != nil {
+			innerErr = err
+
+			return false
+		}
+
+		if !db.isDormant(output.CreatedAtMilestoneIndex, cutoffTimestamp, cutoffIndex) {
+			// the outputs are sorted by age, all following outputs are younger
+			return false
+		}
+
+		if output.Amount < minAmount {
+			return true
+		}
+
+		i++
+		if i <= offset {
+			return true
+		}
+
+		if len(outputs) >= maxResults {
+			return false
+		}
+
+		outputs = append(outputs, output)
+
+		return true
+	}); err != nil {
+		return nil, err
+	}
+
+	if innerErr != nil {
+		return nil, innerErr
+	}
+
+	return outputs, nil
+}
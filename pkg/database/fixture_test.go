@@ -0,0 +1,110 @@
+package database
+
+import (
+	"encoding/binary"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/ds/bitmask"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/iotaledger/hive.go/lo"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/serializer/v2/marshalutil"
+	"github.com/iotaledger/inx-api-core-v1/pkg/hornet"
+	"github.com/iotaledger/inx-api-core-v1/pkg/milestone"
+	"github.com/iotaledger/inx-api-core-v1/pkg/utxo"
+)
+
+// newTestDatabase returns a database with empty in-memory tangle and utxo stores.
+func newTestDatabase(t *testing.T) *Database {
+	t.Helper()
+
+	tangleDatabase := mapdb.NewMapDB()
+	utxoDatabase := mapdb.NewMapDB()
+
+	return &Database{
+		WrappedLogger:                logger.NewWrappedLogger(logger.NewNopLogger()),
+		tangleDatabase:               tangleDatabase,
+		utxoDatabase:                 utxoDatabase,
+		messagesStore:                lo.PanicOnErr(tangleDatabase.WithRealm([]byte{StorePrefixMessages})),
+		metadataStore:                lo.PanicOnErr(tangleDatabase.WithRealm([]byte{StorePrefixMessageMetadata})),
+		milestonesStore:              lo.PanicOnErr(tangleDatabase.WithRealm([]byte{StorePrefixMilestones})),
+		snapshotStore:                lo.PanicOnErr(tangleDatabase.WithRealm([]byte{StorePrefixSnapshot})),
+		childrenStore:                lo.PanicOnErr(tangleDatabase.WithRealm([]byte{StorePrefixChildren})),
+		indexationStore:              lo.PanicOnErr(tangleDatabase.WithRealm([]byte{StorePrefixIndexation})),
+		conflictingTransactionsStore: lo.PanicOnErr(tangleDatabase.WithRealm([]byte{StorePrefixConflictingTransactions})),
+		snapshot:                     &SnapshotInfo{},
+		readyIndexes:                 make(map[byte]struct{}),
+		readyIndexesLock:             sync.RWMutex{},
+		utxoManager:                  utxo.New(utxoDatabase),
+	}
+}
+
+// testMessageID returns a message ID whose bytes are all set to b.
+func testMessageID(b byte) hornet.MessageID {
+	messageID := make(hornet.MessageID, 32)
+	for i := range messageID {
+		messageID[i] = b
+	}
+
+	return messageID
+}
+
+// storeTestMilestone stores the milestone with the given index, message ID and timestamp.
+func storeTestMilestone(t *testing.T, db *Database, msIndex milestone.Index, messageID hornet.MessageID, timestamp time.Time) {
+	t.Helper()
+
+	value := make([]byte, 40)
+	copy(value[:32], messageID)
+	binary.LittleEndian.PutUint64(value[32:], uint64(timestamp.Unix()))
+
+	require.NoError(t, db.milestonesStore.Set(databaseKeyForMilestoneIndex(msIndex), value))
+}
+
+// storeTestMessageMetadata stores the metadata of a message that was referenced by the given milestone.
+// A referencedIndex of zero stores a message that was not referenced.
+func storeTestMessageMetadata(t *testing.T, db *Database, messageID hornet.MessageID, referencedIndex milestone.Index, bits []uint, parents ...hornet.MessageID) {
+	t.Helper()
+
+	metadata := bitmask.BitMask(0).SetBit(MessageMetadataSolid)
+	if referencedIndex != 0 {
+		metadata = metadata.SetBit(MessageMetadataReferenced)
+	}
+	for _, bit := range bits {
+		metadata = metadata.SetBit(bit)
+	}
+
+	marshalUtil := marshalutil.New()
+	marshalUtil.WriteByte(byte(metadata))
+	marshalUtil.WriteUint32(0)
+	marshalUtil.WriteUint32(uint32(referencedIndex))
+	marshalUtil.WriteByte(byte(ConflictNone))
+	marshalUtil.WriteUint32(0)
+	marshalUtil.WriteUint32(0)
+	marshalUtil.WriteUint32(0)
+	marshalUtil.WriteByte(byte(len(parents)))
+	for _, parent := range parents {
+		marshalUtil.WriteBytes(parent)
+	}
+
+	require.NoError(t, db.metadataStore.Set(messageID, marshalUtil.Bytes()))
+}
+
+// storeTestEmptyMilestoneDiff stores a milestone diff without created and consumed outputs.
+func storeTestEmptyMilestoneDiff(t *testing.T, db *Database, msIndex milestone.Index) {
+	t.Helper()
+
+	key := marshalutil.New(5)
+	key.WriteByte(utxo.UTXOStoreKeyPrefixMilestoneDiffs)
+	key.WriteUint32(uint32(msIndex))
+
+	value := marshalutil.New(9)
+	value.WriteUint32(0)
+	value.WriteUint32(0)
+	value.WriteBool(false)
+
+	require.NoError(t, db.utxoDatabase.Set(key.Bytes(), value.Bytes()))
+}
@@ -1,6 +1,8 @@
 package database
 
 import (
+	"bytes"
+
 	"github.com/iotaledger/inx-api-core-v1/pkg/hornet"
 	iotago "github.com/iotaledger/iota.go/v2"
 )
@@ -33,3 +35,52 @@ func (db *Database) IndexMessageIDs(index []byte, maxResults int) (hornet.Messag
 
 	return messageIDs, nil
 }
+
+// IndexMessageIDs contains the message IDs of a full index that matched a prefix search.
+type IndexMessageIDs struct {
+	// The full index without the padding.
+	Index []byte
+	// The IDs of the messages with this index.
+	MessageIDs hornet.MessageIDs
+}
+
+// IndexPrefixMessageIDs returns the message IDs of all indexes that start with the given prefix, grouped by the full index.
+// The message IDs are paginated in the order of the indexes, starting at the given offset.
+// The indexes are stored padded with zeros, so trailing zeros of an index can't be distinguished from the padding and are removed.
+func (db *Database) IndexPrefixMessageIDs(prefix []byte, offset int, maxResults int) ([]*IndexMessageIDs, error) {
+	var i int
+	var count int
+	groups := make([]*IndexMessageIDs, 0)
+	if err := db.indexationStore.IterateKeys(prefix, func(key []byte) bool {
+		i++
+		if i <= offset {
+			return true
+		}
+
+		if count >= maxResults {
+			return false
+		}
+		count++
+
+		index := bytes.TrimRight(key[:IndexationIndexLength], "\x00")
+		messageID := hornet.MessageIDFromSlice(key[IndexationIndexLength : IndexationIndexLength+iotago.MessageIDLength])
+
+		// the keys are sorted by index, so all message IDs of an index are adjacent
+		if len(groups) > 0 && bytes.Equal(groups[len(groups)-1].Index, index) {
+			groups[len(groups)-1].MessageIDs = append(groups[len(groups)-1].MessageIDs, messageID)
+
+			return true
+		}
+
+		groups = append(groups, &IndexMessageIDs{
+			Index:      index,
+			MessageIDs: hornet.MessageIDs{messageID},
+		})
+
+		return true
+	}); err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
@@ -0,0 +1,212 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/serializer/v2/marshalutil"
+	"github.com/iotaledger/inx-api-core-v1/pkg/hornet"
+	"github.com/iotaledger/inx-api-core-v1/pkg/milestone"
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+const (
+	// IndexationCatalogIndexVersion is the version of the layout of the indexation catalog index.
+	IndexationCatalogIndexVersion = 1
+)
+
+/*
+
+   Indexation catalog:
+   ===================
+   Key:
+       Index (padded with zeros)
+             64 bytes
+
+   Value:
+       MessageCount + ReferencedMessageCount + FirstReferencedMilestoneIndex + LastReferencedMilestoneIndex
+         8 bytes    +        8 bytes         +            4 bytes            +           4 bytes
+
+   The milestone indexes are zero if no message with this index was referenced.
+
+*/
+
+// IndexationCatalogEntry contains the statistics of a distinct indexation index.
+type IndexationCatalogEntry struct {
+	// The index without the padding.
+	Index []byte
+	// The amount of messages with this index.
+	MessageCount int64
+	// The amount of messages with this index that were referenced by a milestone.
+	ReferencedMessageCount int64
+	// The index of the first milestone that referenced a message with this index.
+	FirstReferencedMilestoneIndex milestone.Index
+	// The index of the last milestone that referenced a message with this index.
+	LastReferencedMilestoneIndex milestone.Index
+}
+
+func (e *IndexationCatalogEntry) bytes() []byte {
+	m := marshalutil.New(24)
+	m.WriteInt64(e.MessageCount)
+	m.WriteInt64(e.ReferencedMessageCount)
+	m.WriteUint32(uint32(e.FirstReferencedMilestoneIndex))
+	m.WriteUint32(uint32(e.LastReferencedMilestoneIndex))
+
+	return m.Bytes()
+}
+
+func indexationCatalogEntryFromKeyAndValue(key []byte, value []byte) (*IndexationCatalogEntry, error) {
+	if len(key) != IndexationIndexLength {
+		return nil, fmt.Errorf("invalid indexation catalog key length: %d", len(key))
+	}
+
+	e := &IndexationCatalogEntry{
+		Index: bytes.TrimRight(key, "\x00"),
+	}
+
+	marshalUtil := marshalutil.New(value)
+
+	var err error
+	if e.MessageCount, err = marshalUtil.ReadInt64(); err != nil {
+		return nil, err
+	}
+
+	if e.ReferencedMessageCount, err = marshalUtil.ReadInt64(); err != nil {
+		return nil, err
+	}
+
+	firstIndex, err := marshalUtil.ReadUint32()
+	if err != nil {
+		return nil, err
+	}
+	e.FirstReferencedMilestoneIndex = milestone.Index(firstIndex)
+
+	lastIndex, err := marshalUtil.ReadUint32()
+	if err != nil {
+		return nil, err
+	}
+	e.LastReferencedMilestoneIndex = milestone.Index(lastIndex)
+
+	return e, nil
+}
+
+// createIndexationCatalogIndex creates the list of all distinct indexation indexes
+// with their message counts and the range of milestones that referenced them.
+func (db *Database) createIndexationCatalogIndex(ctx context.Context) error {
+	// first we need to delete the old index before we rebuild it
+	if err := db.resetIndex(IndexStorePrefixIndexationCatalog, db.indexationCatalogStore); err != nil {
+		return fmt.Errorf("deleting indexation catalog index failed: %w", err)
+	}
+
+	var innerErr error
+	progress := db.newProgressLogger(ctx, "indexation catalog")
+
+	var currentKey []byte
+	var current *IndexationCatalogEntry
+
+	storeCurrent := func() error {
+		if current == nil {
+			return nil
+		}
+
+		if err := db.indexationCatalogStore.Set(currentKey, current.bytes()); err != nil {
+			return fmt.Errorf("setting entry in indexation catalog index failed: %w", err)
+		}
+
+		return nil
+	}
+
+	var messageCounter int64
+	if err := db.indexationStore.IterateKeys(kvstore.EmptyPrefix, func(key []byte) bool {
+		messageCounter++
+		if err := progress.Log("analyzed %d messages", messageCounter); err != nil {
+			innerErr = err
+
+			return false
+		}
+
+		// the keys are sorted by index, so all messages of an index are adjacent
+		indexKey := key[:IndexationIndexLength]
+		if current == nil || !bytes.Equal(currentKey, indexKey) {
+			if err := storeCurrent(); err != nil {
+				innerErr = err
+
+				return false
+			}
+
+			currentKey = append([]byte{}, indexKey...)
+			current = &IndexationCatalogEntry{}
+		}
+		current.MessageCount++
+
+		messageID := hornet.MessageIDFromSlice(key[IndexationIndexLength : IndexationIndexLength+iotago.MessageIDLength])
+
+		msgMeta := db.MessageMetadataOrNil(messageID)
+		if msgMeta == nil {
+			return true
+		}
+
+		referenced, referencedIndex := msgMeta.ReferencedWithIndex()
+		if !referenced {
+			return true
+		}
+		current.ReferencedMessageCount++
+
+		if current.FirstReferencedMilestoneIndex == 0 || referencedIndex < current.FirstReferencedMilestoneIndex {
+			current.FirstReferencedMilestoneIndex = referencedIndex
+		}
+
+		if referencedIndex > current.LastReferencedMilestoneIndex {
+			current.LastReferencedMilestoneIndex = referencedIndex
+		}
+
+		return true
+	}); err != nil {
+		return fmt.Errorf("iterating over all indexation keys failed: %w", err)
+	}
+
+	if innerErr != nil {
+		return innerErr
+	}
+
+	return storeCurrent()
+}
+
+// IndexationCatalog returns the distinct indexation indexes sorted by index, starting at the given offset.
+func (db *Database) IndexationCatalog(offset int, maxResults int) ([]*IndexationCatalogEntry, error) {
+	var innerErr error
+	var i int
+
+	entries := make([]*IndexationCatalogEntry, 0)
+	if err := db.indexationCatalogStore.Iterate(kvstore.EmptyPrefix, func(key kvstore.Key, value kvstore.Value) bool {
+		i++
+		if i <= offset {
+			return true
+		}
+
+		if len(entries) >= maxResults {
+			return false
+		}
+
+		entry, err := indexationCatalogEntryFromKeyAndValue(key, value)
+		if err != nil {
+			innerErr = err
+
+			return false
+		}
+
+		entries = append(entries, entry)
+
+		return true
+	}); err != nil {
+		return nil, err
+	}
+
+	if innerErr != nil {
+		return nil, innerErr
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,409 @@
+package database
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	hivedb "github.com/iotaledger/hive.go/kvstore/database"
+	"github.com/iotaledger/hive.go/lo"
+	"github.com/iotaledger/hive.go/runtime/contextutils"
+	"github.com/iotaledger/hive.go/serializer"
+	"github.com/iotaledger/inx-api-core-v1/pkg/database/engine"
+	"github.com/iotaledger/inx-api-core-v1/pkg/milestone"
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+const (
+	// IndexDBVersion is the version of the layout of the indexes database.
+	// If the version of an existing database doesn't match, all indexes are rebuilt.
+	IndexDBVersion = 1
+)
+
+var (
+	// ErrIndexNotReady is returned if an index is queried that is still being built.
+	ErrIndexNotReady = errors.New("index is not ready yet")
+	// ErrIndexBuildFailed is returned if some of the indexes could not be built.
+	ErrIndexBuildFailed = errors.New("building indexes failed")
+)
+
+const (
+	IndexStorePrefixStatus            byte = 0
+	IndexStorePrefixAddressHistory    byte = 1
+	IndexStorePrefixPublicKeys        byte = 2
+	IndexStorePrefixRichList          byte = 3
+	IndexStorePrefixMigrations        byte = 4
+	IndexStorePrefixIndexationCatalog byte = 5
+	IndexStorePrefixDataSearch        byte = 6
+	IndexStorePrefixDailyStats        byte = 7
+	IndexStorePrefixDormancy          byte = 8
+	IndexStorePrefixAddressClusters   byte = 9
+	IndexStorePrefixLedgerCommitments byte = 10
+	// IndexStorePrefixTemporary is used to store intermediate results while building an index.
+	IndexStorePrefixTemporary byte = 254
+	IndexStorePrefixHealth    byte = 255
+)
+
+// openIndexDatabase opens the sidecar database that contains the indexes derived from the tangle and utxo databases.
+// The tangle and utxo databases are frozen, so the indexes only need to be built once.
+func (db *Database) openIndexDatabase(indexDatabasePath string) error {
+	indexDatabase, err := engine.StoreWithDefaultSettings(indexDatabasePath, true, hivedb.EngineRocksDB, false, engine.AllowedEnginesStorage...)
+	if err != nil {
+		return fmt.Errorf("opening indexes database failed: %w", err)
+	}
+
+	healthTracker, err := kvstore.NewStoreHealthTracker(indexDatabase, kvstore.KeyPrefix{IndexStorePrefixHealth}, IndexDBVersion, func(oldVersion byte, newVersion byte) error {
+		db.LogInfof("Indexes database version changed (%d => %d), all indexes will be rebuilt", oldVersion, newVersion)
+
+		return indexDatabase.Clear()
+	})
+	if err != nil {
+		_ = indexDatabase.Close()
+
+		return fmt.Errorf("opening indexes database failed: %w", err)
+	}
+
+	if _, err := healthTracker.UpdateStoreVersion(); err != nil {
+		_ = indexDatabase.Close()
+
+		return fmt.Errorf("updating indexes database version failed: %w", err)
+	}
+
+	db.indexDatabase = indexDatabase
+	db.indexStatusStore = lo.PanicOnErr(indexDatabase.WithRealm([]byte{IndexStorePrefixStatus}))
+	db.addressHistoryStore = lo.PanicOnErr(indexDatabase.WithRealm([]byte{IndexStorePrefixAddressHistory}))
+	db.publicKeysStore = lo.PanicOnErr(indexDatabase.WithRealm([]byte{IndexStorePrefixPublicKeys}))
+	db.richListStore = lo.PanicOnErr(indexDatabase.WithRealm([]byte{IndexStorePrefixRichList}))
+	db.migrationsStore = lo.PanicOnErr(indexDatabase.WithRealm([]byte{IndexStorePrefixMigrations}))
+	db.indexationCatalogStore = lo.PanicOnErr(indexDatabase.WithRealm([]byte{IndexStorePrefixIndexationCatalog}))
+	db.dataSearchStore = lo.PanicOnErr(indexDatabase.WithRealm([]byte{IndexStorePrefixDataSearch}))
+	db.dailyStatsStore = lo.PanicOnErr(indexDatabase.WithRealm([]byte{IndexStorePrefixDailyStats}))
+	db.dormancyStore = lo.PanicOnErr(indexDatabase.WithRealm([]byte{IndexStorePrefixDormancy}))
+	db.addressClustersStore = lo.PanicOnErr(indexDatabase.WithRealm([]byte{IndexStorePrefixAddressClusters}))
+	db.ledgerCommitmentsStore = lo.PanicOnErr(indexDatabase.WithRealm([]byte{IndexStorePrefixLedgerCommitments}))
+	db.temporaryStore = lo.PanicOnErr(indexDatabase.WithRealm([]byte{IndexStorePrefixTemporary}))
+
+	return nil
+}
+
+// index is an index in the indexes database that is derived from the tangle and utxo databases.
+type index struct {
+	// The name of the index used in log messages.
+	name string
+	// The prefix of the realm of the index.
+	storePrefix byte
+	// The version of the layout of the index.
+	version byte
+	// The function that builds the index.
+	create func(ctx context.Context) error
+	// The function that loads the in-memory state of the index after it was built (optional).
+	load func() error
+	// The function that checks whether a built index matches the current options (optional).
+	matchesOptions func() (bool, error)
+}
+
+// indexes returns all indexes that are built in the background after startup.
+func (db *Database) indexes() []*index {
+	indexes := []*index{
+		{
+			name:        "address history",
+			storePrefix: IndexStorePrefixAddressHistory,
+			version:     AddressHistoryIndexVersion,
+			create:      db.createAddressHistoryIndex,
+		},
+		{
+			name:        "public keys",
+			storePrefix: IndexStorePrefixPublicKeys,
+			version:     PublicKeysIndexVersion,
+			create:      db.createPublicKeysIndex,
+		},
+		{
+			name:        "rich list",
+			storePrefix: IndexStorePrefixRichList,
+			version:     RichListIndexVersion,
+			create:      db.createRichListIndex,
+			load:        db.loadRichListSummary,
+		},
+		{
+			name:        "migrations",
+			storePrefix: IndexStorePrefixMigrations,
+			version:     MigrationsIndexVersion,
+			create:      db.createMigrationsIndex,
+		},
+		{
+			name:        "indexation catalog",
+			storePrefix: IndexStorePrefixIndexationCatalog,
+			version:     IndexationCatalogIndexVersion,
+			create:      db.createIndexationCatalogIndex,
+		},
+		{
+			name:        "daily stats",
+			storePrefix: IndexStorePrefixDailyStats,
+			version:     DailyStatsIndexVersion,
+			create:      db.createDailyStatsIndex,
+		},
+		{
+			name:        "dormancy",
+			storePrefix: IndexStorePrefixDormancy,
+			version:     DormancyIndexVersion,
+			create:      db.createDormancyIndex,
+			load:        db.loadDormancySummary,
+		},
+		{
+			name:           "address clusters",
+			storePrefix:    IndexStorePrefixAddressClusters,
+			version:        AddressClustersIndexVersion,
+			create:         db.createAddressClustersIndex,
+			matchesOptions: db.addressClustersOptionsMatch,
+		},
+		{
+			name:           "ledger commitments",
+			storePrefix:    IndexStorePrefixLedgerCommitments,
+			version:        LedgerCommitmentsIndexVersion,
+			create:         db.createLedgerCommitmentsIndex,
+			matchesOptions: db.ledgerCommitmentsAnchorMatches,
+		},
+	}
+
+	if db.dataSearchEnabled {
+		indexes = append(indexes, &index{
+			name:        "data search",
+			storePrefix: IndexStorePrefixDataSearch,
+			version:     DataSearchIndexVersion,
+			create:      db.createDataSearchIndex,
+		})
+	}
+
+	return indexes
+}
+
+// BuildIndexes builds all indexes that are not up to date.
+// It is meant to be run in a background worker, every index can be queried as soon as it is ready.
+// Indexes that are already up to date are made ready first, so they don't wait for the others to be built.
+// An index that fails to build stays not ready and doesn't stop the other indexes,
+// ErrIndexBuildFailed is returned with the names of the failed indexes afterwards.
+func (db *Database) BuildIndexes(ctx context.Context) error {
+	failedIndexes := make([]string, 0)
+	indexFailed := func(index *index, err error) {
+		db.LogErrorf("building %s index failed, its endpoints stay unavailable, error: %s", index.name, err)
+		failedIndexes = append(failedIndexes, index.name)
+	}
+
+	pendingIndexes := make([]*index, 0)
+	for _, index := range db.indexes() {
+		upToDate, err := db.checkIndexStatus(index.storePrefix, index.version)
+		if err != nil {
+			indexFailed(index, err)
+
+			continue
+		}
+
+		if upToDate && index.matchesOptions != nil {
+			if upToDate, err = index.matchesOptions(); err != nil {
+				indexFailed(index, err)
+
+				continue
+			}
+		}
+
+		if !upToDate {
+			pendingIndexes = append(pendingIndexes, index)
+
+			continue
+		}
+
+		if err := db.setIndexReady(index); err != nil {
+			indexFailed(index, err)
+		}
+	}
+
+	for _, index := range pendingIndexes {
+		if err := db.buildIndex(ctx, index); err != nil {
+			if errors.Is(err, ErrOperationAborted) || ctx.Err() != nil {
+				return ErrOperationAborted
+			}
+			indexFailed(index, err)
+		}
+	}
+
+	if len(failedIndexes) > 0 {
+		return fmt.Errorf("%w: %s", ErrIndexBuildFailed, strings.Join(failedIndexes, ", "))
+	}
+
+	return nil
+}
+
+// buildIndex creates the given index, stores its status and marks it as ready to be queried.
+func (db *Database) buildIndex(ctx context.Context, index *index) error {
+	db.LogInfof("%s index not up to date. Updating now... (this may take some time!)", index.name)
+
+	ts := time.Now()
+	if err := index.create(ctx); err != nil {
+		return fmt.Errorf("failed to create %s index: error: %w", index.name, err)
+	}
+
+	if err := db.setIndexStatus(index.storePrefix, index.version); err != nil {
+		return err
+	}
+
+	if err := db.setIndexReady(index); err != nil {
+		return err
+	}
+
+	db.LogInfof("Updating %s index done! Took: %v", index.name, time.Since(ts).Truncate(time.Millisecond))
+
+	return nil
+}
+
+// setIndexReady loads the in-memory state of the index and marks it as ready to be queried.
+func (db *Database) setIndexReady(index *index) error {
+	if index.load != nil {
+		if err := index.load(); err != nil {
+			return fmt.Errorf("failed to load %s index: error: %w", index.name, err)
+		}
+	}
+
+	db.readyIndexesLock.Lock()
+	defer db.readyIndexesLock.Unlock()
+
+	db.readyIndexes[index.storePrefix] = struct{}{}
+
+	return nil
+}
+
+// CheckIndexReady returns an error wrapping ErrIndexNotReady if the index with the given prefix is still being built.
+func (db *Database) CheckIndexReady(indexStorePrefix byte) error {
+	db.readyIndexesLock.RLock()
+	defer db.readyIndexesLock.RUnlock()
+
+	if _, ready := db.readyIndexes[indexStorePrefix]; ready {
+		return nil
+	}
+
+	for _, index := range db.indexes() {
+		if index.storePrefix == indexStorePrefix {
+			return fmt.Errorf("%w: %s", ErrIndexNotReady, index.name)
+		}
+	}
+
+	return ErrIndexNotReady
+}
+
+// progressLogger periodically logs the progress while building an index.
+type progressLogger struct {
+	db             *Database
+	ctx            context.Context
+	name           string
+	lastStatusTime time.Time
+}
+
+func (db *Database) newProgressLogger(ctx context.Context, name string) *progressLogger {
+	return &progressLogger{
+		db:             db,
+		ctx:            ctx,
+		name:           name,
+		lastStatusTime: time.Now(),
+	}
+}
+
+// Log logs the progress if the status interval passed since the last message.
+// It returns ErrOperationAborted if the context is done.
+func (p *progressLogger) Log(format string, args ...interface{}) error {
+	if time.Since(p.lastStatusTime) < printStatusInterval {
+		return nil
+	}
+	p.lastStatusTime = time.Now()
+
+	if err := contextutils.ReturnErrIfCtxDone(p.ctx, ErrOperationAborted); err != nil {
+		return err
+	}
+
+	p.db.LogInfof("%s: %s", p.name, fmt.Sprintf(format, args...))
+
+	return nil
+}
+
+// addressFromBytes deserializes an address that is used as part of a key.
+func addressFromBytes(addrBytes []byte) (iotago.Address, error) {
+	if len(addrBytes) == 0 {
+		return nil, errors.New("empty address")
+	}
+
+	addr, err := iotago.AddressSelector(uint32(addrBytes[0]))
+	if err != nil {
+		return nil, err
+	}
+
+	//nolint:forcetypeassert
+	address := addr.(iotago.Address)
+	if _, err := address.Deserialize(addrBytes, serializer.DeSeriModeNoValidation); err != nil {
+		return nil, err
+	}
+
+	return address, nil
+}
+
+/*
+   Index status:
+   =============
+   Key:
+       IndexStorePrefix
+            1 byte
+
+   Value:
+       LedgerIndex (milestone.Index) + IndexVersion
+                4 bytes              +    1 byte
+*/
+
+// checkIndexStatus checks if the index with the given prefix was fully built for the current ledger index
+// and with the given version of its layout.
+func (db *Database) checkIndexStatus(indexStorePrefix byte, indexVersion byte) (bool, error) {
+	value, err := db.indexStatusStore.Get([]byte{indexStorePrefix})
+	if err != nil {
+		if errors.Is(err, kvstore.ErrKeyNotFound) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("reading index status failed: %w", err)
+	}
+
+	if len(value) != 5 {
+		return false, nil
+	}
+
+	return milestone.Index(binary.LittleEndian.Uint32(value[:4])) == db.utxoManager.ReadLedgerIndex() && value[4] == indexVersion, nil
+}
+
+// setIndexStatus marks the index with the given prefix as fully built and flushes the indexes database.
+func (db *Database) setIndexStatus(indexStorePrefix byte, indexVersion byte) error {
+	value := make([]byte, 5)
+	binary.LittleEndian.PutUint32(value[:4], uint32(db.utxoManager.ReadLedgerIndex()))
+	value[4] = indexVersion
+
+	if err := db.indexStatusStore.Set([]byte{indexStorePrefix}, value); err != nil {
+		return fmt.Errorf("setting index status failed: %w", err)
+	}
+
+	if err := db.indexDatabase.Flush(); err != nil {
+		return fmt.Errorf("flushing indexes database failed: %w", err)
+	}
+
+	return nil
+}
+
+// resetIndex deletes the status and all entries of the index with the given prefix.
+func (db *Database) resetIndex(indexStorePrefix byte, store kvstore.KVStore) error {
+	if err := db.indexStatusStore.Delete([]byte{indexStorePrefix}); err != nil {
+		return fmt.Errorf("deleting index status failed: %w", err)
+	}
+
+	if err := store.Clear(); err != nil {
+		return fmt.Errorf("clearing index failed: %w", err)
+	}
+
+	return db.temporaryStore.Clear()
+}
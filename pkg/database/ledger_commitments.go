@@ -0,0 +1,293 @@
+package database
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/serializer/v2/byteutils"
+	"github.com/iotaledger/hive.go/serializer/v2/marshalutil"
+	"github.com/iotaledger/inx-api-core-v1/pkg/milestone"
+	"github.com/iotaledger/inx-api-core-v1/pkg/utxo"
+)
+
+const (
+	// LedgerCommitmentsIndexVersion is the version of the layout of the ledger commitments index.
+	LedgerCommitmentsIndexVersion = 2
+
+	ledgerCommitmentsKeyPrefixMilestone byte = 0
+	ledgerCommitmentsKeyPrefixLedger    byte = 1
+	ledgerCommitmentsKeyPrefixAnchor    byte = 2
+)
+
+var (
+	ErrMilestoneCommitmentNotFound    = errors.New("milestone commitment not found")
+	ErrMilestoneCommitmentBelowAnchor = errors.New("milestone commitment below the anchor milestone")
+)
+
+/*
+
+   Ledger commitments:
+   ===================
+   Key:
+       ledgerCommitmentsKeyPrefixMilestone + MilestoneIndex
+                      1 byte               +    4 bytes (big endian)
+
+   Value:
+       MilestoneDiffHash + MilestoneCommitment
+            32 bytes     +      32 bytes
+
+   Key:
+       ledgerCommitmentsKeyPrefixLedger
+                   1 byte
+
+   Value:
+       LedgerIndex + UnspentOutputCount + LedgerCommitment
+         4 bytes   +      8 bytes       +     32 bytes
+
+   Key:
+       ledgerCommitmentsKeyPrefixAnchor
+                   1 byte
+
+   Value:
+       AnchorIndex + Available
+         4 bytes   +  1 byte
+
+   The hashes are computed as described in the utxo package.
+   The entry of the anchor milestone contains 32 zero bytes as diff hash and the ledger commitment at the anchor milestone.
+   If the milestone diffs to roll back the ledger to the anchor milestone are not available, no milestone commitments are stored.
+
+*/
+
+// MilestoneCommitment is the commitment over the changes of all milestones up to a milestone.
+type MilestoneCommitment struct {
+	// The index of the milestone.
+	MilestoneIndex milestone.Index
+	// The hash over the changes of the milestone.
+	DiffHash utxo.Commitment
+	// The commitment chained over the diff hashes of all milestones since the anchor milestone.
+	Commitment utxo.Commitment
+	// The index of the anchor milestone of the commitment chain.
+	AnchorIndex milestone.Index
+}
+
+func ledgerCommitmentsMilestoneKey(msIndex milestone.Index) []byte {
+	msIndexBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(msIndexBytes, uint32(msIndex))
+
+	return byteutils.ConcatBytes([]byte{ledgerCommitmentsKeyPrefixMilestone}, msIndexBytes)
+}
+
+// CommitmentAnchorIndex returns the milestone at which the milestone commitment chain is anchored.
+func (db *Database) CommitmentAnchorIndex() milestone.Index {
+	return db.commitmentAnchorIndex
+}
+
+// readLedgerCommitmentsAnchor returns the anchor milestone the ledger commitments index was built with
+// and whether the milestone commitments are available.
+func (db *Database) readLedgerCommitmentsAnchor() (milestone.Index, bool, error) {
+	value, err := db.ledgerCommitmentsStore.Get([]byte{ledgerCommitmentsKeyPrefixAnchor})
+	if err != nil {
+		return 0, false, err
+	}
+
+	marshalUtil := marshalutil.New(value)
+
+	anchorIndex, err := marshalUtil.ReadUint32()
+	if err != nil {
+		return 0, false, err
+	}
+
+	available, err := marshalUtil.ReadBool()
+	if err != nil {
+		return 0, false, err
+	}
+
+	return milestone.Index(anchorIndex), available, nil
+}
+
+// ledgerCommitmentsAnchorMatches checks whether the ledger commitments index was built with the configured anchor milestone.
+func (db *Database) ledgerCommitmentsAnchorMatches() (bool, error) {
+	anchorIndex, _, err := db.readLedgerCommitmentsAnchor()
+	if err != nil {
+		if errors.Is(err, kvstore.ErrKeyNotFound) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("reading ledger commitments anchor failed: %w", err)
+	}
+
+	return anchorIndex == db.commitmentAnchorIndex, nil
+}
+
+// createLedgerCommitmentsIndex computes the commitment of the ledger state and the commitments of all milestones
+// since the anchor milestone.
+func (db *Database) createLedgerCommitmentsIndex(ctx context.Context) error {
+	// first we need to delete the old index before we rebuild it
+	if err := db.resetIndex(IndexStorePrefixLedgerCommitments, db.ledgerCommitmentsStore); err != nil {
+		return fmt.Errorf("deleting ledger commitments index failed: %w", err)
+	}
+
+	ledgerIndex := db.utxoManager.ReadLedgerIndex()
+
+	available, err := db.storeMilestoneCommitments(ctx, ledgerIndex)
+	if err != nil {
+		return err
+	}
+
+	anchor := marshalutil.New(5)
+	anchor.WriteUint32(uint32(db.commitmentAnchorIndex))
+	anchor.WriteBool(available)
+
+	if err := db.ledgerCommitmentsStore.Set([]byte{ledgerCommitmentsKeyPrefixAnchor}, anchor.Bytes()); err != nil {
+		return fmt.Errorf("setting entry in ledger commitments index failed: %w", err)
+	}
+
+	ledgerCommitment, err := db.utxoManager.LedgerCommitment()
+	if err != nil {
+		return fmt.Errorf("computing ledger commitment failed: %w", err)
+	}
+
+	value := marshalutil.New(4 + 8 + utxo.CommitmentLength)
+	value.WriteUint32(uint32(ledgerCommitment.LedgerIndex))
+	value.WriteInt64(ledgerCommitment.UnspentOutputCount)
+	value.WriteBytes(ledgerCommitment.Commitment[:])
+
+	if err := db.ledgerCommitmentsStore.Set([]byte{ledgerCommitmentsKeyPrefixLedger}, value.Bytes()); err != nil {
+		return fmt.Errorf("setting entry in ledger commitments index failed: %w", err)
+	}
+
+	return nil
+}
+
+// storeMilestoneCommitments stores the commitments of the anchor milestone and all milestones up to the ledger index.
+// It returns false if the ledger can't be rolled back to the anchor milestone with the available milestone diffs.
+func (db *Database) storeMilestoneCommitments(ctx context.Context, ledgerIndex milestone.Index) (bool, error) {
+	if db.commitmentAnchorIndex > ledgerIndex {
+		db.LogWarnf("the commitment anchor milestone %d is above the ledger index %d, milestone commitments are not available", db.commitmentAnchorIndex, ledgerIndex)
+
+		return false, nil
+	}
+
+	anchorCommitment, err := db.utxoManager.LedgerCommitmentAtIndex(db.commitmentAnchorIndex)
+	if err != nil {
+		if errors.Is(err, utxo.ErrMilestoneDiffsNotAvailable) {
+			db.LogWarnf("the ledger can't be rolled back to the commitment anchor milestone %d, milestone commitments are not available: %s", db.commitmentAnchorIndex, err)
+
+			return false, nil
+		}
+
+		return false, fmt.Errorf("computing ledger commitment at the anchor milestone failed: %w", err)
+	}
+
+	var zeroDiffHash utxo.Commitment
+	if err := db.ledgerCommitmentsStore.Set(ledgerCommitmentsMilestoneKey(db.commitmentAnchorIndex), byteutils.ConcatBytes(zeroDiffHash[:], anchorCommitment.Commitment[:])); err != nil {
+		return false, fmt.Errorf("setting entry in ledger commitments index failed, msIndex: %d, error: %w", db.commitmentAnchorIndex, err)
+	}
+
+	var innerErr error
+	progress := db.newProgressLogger(ctx, "ledger commitments")
+
+	if err := db.utxoManager.ForEachMilestoneCommitment(anchorCommitment, ledgerIndex, func(msIndex milestone.Index, diffHash utxo.Commitment, commitment utxo.Commitment) bool {
+		if err := progress.Log("analyzed %d/%d milestones", msIndex, ledgerIndex); err != nil {
+			innerErr = err
+
+			return false
+		}
+
+		if err := db.ledgerCommitmentsStore.Set(ledgerCommitmentsMilestoneKey(msIndex), byteutils.ConcatBytes(diffHash[:], commitment[:])); err != nil {
+			innerErr = fmt.Errorf("setting entry in ledger commitments index failed, msIndex: %d, error: %w", msIndex, err)
+
+			return false
+		}
+
+		return true
+	}); err != nil {
+		return false, fmt.Errorf("computing milestone commitments failed: %w", err)
+	}
+	if innerErr != nil {
+		return false, innerErr
+	}
+
+	return true, nil
+}
+
+// LedgerCommitment returns the commitment over the unspent outputs and the treasury at the ledger index.
+func (db *Database) LedgerCommitment() (*utxo.LedgerCommitment, error) {
+	value, err := db.ledgerCommitmentsStore.Get([]byte{ledgerCommitmentsKeyPrefixLedger})
+	if err != nil {
+		return nil, err
+	}
+
+	marshalUtil := marshalutil.New(value)
+
+	ledgerIndex, err := marshalUtil.ReadUint32()
+	if err != nil {
+		return nil, err
+	}
+
+	unspentOutputCount, err := marshalUtil.ReadInt64()
+	if err != nil {
+		return nil, err
+	}
+
+	commitmentBytes, err := marshalUtil.ReadBytes(utxo.CommitmentLength)
+	if err != nil {
+		return nil, err
+	}
+
+	treasuryOutput, err := db.utxoManager.UnspentTreasuryOutput()
+	if err != nil {
+		return nil, err
+	}
+
+	ledgerCommitment := &utxo.LedgerCommitment{
+		LedgerIndex:        milestone.Index(ledgerIndex),
+		UnspentOutputCount: unspentOutputCount,
+		Treasury:           treasuryOutput,
+	}
+	copy(ledgerCommitment.Commitment[:], commitmentBytes)
+
+	return ledgerCommitment, nil
+}
+
+// MilestoneCommitment returns the commitment over the changes of all milestones since the anchor milestone up to the given milestone.
+// Milestones below the anchor milestone have no commitment.
+func (db *Database) MilestoneCommitment(msIndex milestone.Index) (*MilestoneCommitment, error) {
+	anchorIndex, available, err := db.readLedgerCommitmentsAnchor()
+	if err != nil {
+		return nil, fmt.Errorf("reading ledger commitments anchor failed: %w", err)
+	}
+
+	if msIndex < anchorIndex {
+		return nil, fmt.Errorf("%w: %d < %d", ErrMilestoneCommitmentBelowAnchor, msIndex, anchorIndex)
+	}
+
+	if !available {
+		return nil, ErrMilestoneCommitmentNotFound
+	}
+
+	value, err := db.ledgerCommitmentsStore.Get(ledgerCommitmentsMilestoneKey(msIndex))
+	if err != nil {
+		if errors.Is(err, kvstore.ErrKeyNotFound) {
+			return nil, ErrMilestoneCommitmentNotFound
+		}
+
+		return nil, err
+	}
+
+	if len(value) != 2*utxo.CommitmentLength {
+		return nil, fmt.Errorf("invalid ledger commitments value length: %d", len(value))
+	}
+
+	commitment := &MilestoneCommitment{
+		MilestoneIndex: msIndex,
+		AnchorIndex:    anchorIndex,
+	}
+	copy(commitment.DiffHash[:], value[:utxo.CommitmentLength])
+	copy(commitment.Commitment[:], value[utxo.CommitmentLength:])
+
+	return commitment, nil
+}
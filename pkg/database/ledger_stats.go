@@ -0,0 +1,201 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"github.com/iotaledger/inx-api-core-v1/pkg/milestone"
+	"github.com/iotaledger/inx-api-core-v1/pkg/utxo"
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+// BalanceHistogramBucket contains the addresses with a balance in the range of the bucket.
+type BalanceHistogramBucket struct {
+	// The inclusive lower bound of the balances in this bucket.
+	LowerBound uint64
+	// The exclusive upper bound of the balances in this bucket. Zero for the last bucket without upper bound.
+	UpperBound uint64
+	// The amount of addresses with a balance in this bucket.
+	AddressCount int64
+	// The sum of the balances of the addresses in this bucket.
+	TotalBalance uint64
+}
+
+// LedgerStats contains the supply and distribution statistics of the ledger.
+type LedgerStats struct {
+	// The ledger index at which the statistics were computed.
+	LedgerIndex milestone.Index
+	// The sum of the amounts of all unspent outputs.
+	SupplyInOutputs uint64
+	// The amount of the unspent treasury output.
+	TreasuryAmount uint64
+	// The sum of the supply in outputs and the treasury.
+	TotalSupply uint64
+	// The amount of addresses with a non-zero balance.
+	AddressesWithBalanceCount int64
+	// The amount of unspent outputs.
+	UnspentOutputCount int64
+	// The amount of unspent SigLockedSingleOutputs with an amount below the dust threshold.
+	DustOutputCount int64
+	// The amount of unspent SigLockedDustAllowanceOutputs.
+	DustAllowanceOutputCount int64
+	// The distribution of the balances of all addresses with a non-zero balance.
+	BalanceHistogram []*BalanceHistogramBucket
+}
+
+// newBalanceHistogram creates the histogram buckets for the given upper bounds.
+// A last bucket without upper bound is always added.
+func newBalanceHistogram(upperBounds []uint64) []*BalanceHistogramBucket {
+	bounds := make([]uint64, 0, len(upperBounds))
+	for _, bound := range upperBounds {
+		if bound > 0 {
+			bounds = append(bounds, bound)
+		}
+	}
+	sort.Slice(bounds, func(i, j int) bool { return bounds[i] < bounds[j] })
+
+	histogram := make([]*BalanceHistogramBucket, 0, len(bounds)+1)
+
+	var lowerBound uint64 = 1
+	for _, bound := range bounds {
+		if bound <= lowerBound {
+			// skip duplicate bounds
+			continue
+		}
+
+		histogram = append(histogram, &BalanceHistogramBucket{
+			LowerBound: lowerBound,
+			UpperBound: bound,
+		})
+		lowerBound = bound
+	}
+
+	return append(histogram, &BalanceHistogramBucket{
+		LowerBound: lowerBound,
+	})
+}
+
+// computeLedgerStats computes the supply and distribution statistics of the ledger.
+func (db *Database) computeLedgerStats(ctx context.Context, histogramUpperBounds []uint64) (*LedgerStats, error) {
+	stats := &LedgerStats{
+		LedgerIndex:      db.utxoManager.ReadLedgerIndex(),
+		BalanceHistogram: newBalanceHistogram(histogramUpperBounds),
+	}
+
+	var innerErr error
+	progress := db.newProgressLogger(ctx, "ledger stats")
+
+	if err := db.utxoManager.ForEachUnspentOutput(func(output *utxo.Output) bool {
+		stats.UnspentOutputCount++
+		if err := progress.Log("analyzed %d outputs", stats.UnspentOutputCount); err != nil {
+			innerErr = err
+
+			return false
+		}
+
+		stats.SupplyInOutputs += output.Amount()
+
+		switch output.OutputType() {
+		case iotago.OutputSigLockedSingleOutput:
+			if output.Amount() < iotago.OutputSigLockedDustAllowanceOutputMinDeposit {
+				stats.DustOutputCount++
+			}
+		case iotago.OutputSigLockedDustAllowanceOutput:
+			stats.DustAllowanceOutputCount++
+		}
+
+		return true
+	}); err != nil {
+		return nil, fmt.Errorf("iterating over unspent outputs failed: %w", err)
+	}
+	if innerErr != nil {
+		return nil, innerErr
+	}
+
+	var addressCounter int64
+	if err := db.utxoManager.ForEachBalance(func(_ iotago.Address, balance uint64, _ uint64, _ int64) bool {
+		addressCounter++
+		if err := progress.Log("analyzed %d addresses", addressCounter); err != nil {
+			innerErr = err
+
+			return false
+		}
+
+		if balance == 0 {
+			return true
+		}
+
+		stats.AddressesWithBalanceCount++
+
+		// the buckets are sorted and the last bucket has no upper bound
+		for _, bucket := range stats.BalanceHistogram {
+			if bucket.UpperBound == 0 || balance < bucket.UpperBound {
+				bucket.AddressCount++
+				bucket.TotalBalance += balance
+
+				break
+			}
+		}
+
+		return true
+	}); err != nil {
+		return nil, fmt.Errorf("iterating over balances failed: %w", err)
+	}
+	if innerErr != nil {
+		return nil, innerErr
+	}
+
+	treasuryOutput, err := db.utxoManager.UnspentTreasuryOutput()
+	if err != nil {
+		return nil, fmt.Errorf("reading unspent treasury output failed: %w", err)
+	}
+	stats.TreasuryAmount = treasuryOutput.Amount
+	stats.TotalSupply = stats.SupplyInOutputs + stats.TreasuryAmount
+
+	return stats, nil
+}
+
+// LedgerStatsCache holds the ledger statistics once they were computed,
+// because the ledger never changes.
+type LedgerStatsCache struct {
+	db                   *Database
+	histogramUpperBounds []uint64
+
+	computeLock sync.Mutex
+	stats       atomic.Pointer[LedgerStats]
+}
+
+// NewLedgerStatsCache creates a new cache for the ledger statistics with the given upper bounds of the histogram buckets.
+func NewLedgerStatsCache(db *Database, histogramUpperBounds []uint64) *LedgerStatsCache {
+	return &LedgerStatsCache{
+		db:                   db,
+		histogramUpperBounds: histogramUpperBounds,
+	}
+}
+
+// Compute computes the ledger statistics and caches the result.
+// Nothing is computed if the statistics are already cached.
+func (c *LedgerStatsCache) Compute(ctx context.Context) error {
+	c.computeLock.Lock()
+	defer c.computeLock.Unlock()
+
+	if c.stats.Load() != nil {
+		return nil
+	}
+
+	stats, err := c.db.computeLedgerStats(ctx, c.histogramUpperBounds)
+	if err != nil {
+		return err
+	}
+	c.stats.Store(stats)
+
+	return nil
+}
+
+// CachedStats returns the ledger statistics if they were already computed, nil otherwise.
+func (c *LedgerStatsCache) CachedStats() *LedgerStats {
+	return c.stats.Load()
+}
@@ -73,6 +73,25 @@ func (msg *Message) Milestone() *iotago.Milestone {
 	}
 }
 
+// Indexation returns the indexation payload of the message
+// or the indexation payload embedded in the transaction essence, nil if there is none.
+func (msg *Message) Indexation() *iotago.Indexation {
+	switch payload := msg.Message().Payload.(type) {
+	case *iotago.Indexation:
+		return payload
+	case *iotago.Transaction:
+		if essence := msg.TransactionEssence(); essence != nil {
+			if indexation, ok := essence.Payload.(*iotago.Indexation); ok {
+				return indexation
+			}
+		}
+
+		return nil
+	default:
+		return nil
+	}
+}
+
 func messageFactory(key []byte, data []byte) *Message {
 	return &Message{
 		messageID: hornet.MessageIDFromSlice(key[:iotago.MessageIDLength]),
@@ -0,0 +1,319 @@
+package database
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/serializer"
+	"github.com/iotaledger/hive.go/serializer/v2/byteutils"
+	"github.com/iotaledger/hive.go/serializer/v2/marshalutil"
+	"github.com/iotaledger/inx-api-core-v1/pkg/milestone"
+	"github.com/iotaledger/inx-api-core-v1/pkg/utxo"
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+const (
+	// MigrationsIndexVersion is the version of the layout of the migrations index.
+	MigrationsIndexVersion = 1
+)
+
+const (
+	migrationsKeyPrefixTailTransactionHash byte = 0
+	migrationsKeyPrefixAddress             byte = 1
+)
+
+var (
+	// ErrMigrationNotFound is returned if no migrated funds exist for a legacy tail transaction hash.
+	ErrMigrationNotFound = errors.New("migration not found")
+)
+
+/*
+
+   Migrations by tail transaction hash:
+   ====================================
+   Key:
+       migrationsKeyPrefixTailTransactionHash + TailTransactionHash
+                      1 byte                  +      49 bytes
+
+   Migrations by address:
+   ======================
+   Key:
+       migrationsKeyPrefixAddress + iotago.Ed25519Address.Serialized() + MilestoneIndex + OutputIndex
+                1 byte            +       1 byte type + 32 bytes       + 4 bytes (big endian) + 2 bytes (big endian)
+
+   Value (both):
+       MilestoneIndex + MigratedAt + OutputID + Deposit + TailTransactionHash + iotago.Ed25519Address.Serialized()
+          4 bytes     +  4 bytes   + 34 bytes + 8 bytes +      49 bytes       +       1 byte type + 32 bytes
+
+   The big endian milestone index in the address key sorts the migrations of an address by the milestone that included them.
+
+*/
+
+// MigratedFunds are funds that were migrated from the legacy network to a target address by a receipt.
+type MigratedFunds struct {
+	// The tail transaction hash of the migration bundle in the legacy network.
+	TailTransactionHash iotago.LegacyTailTransactionHash
+	// The target address of the migrated funds.
+	Address iotago.Address
+	// The amount of the deposit.
+	Deposit uint64
+	// The index of the milestone which included the receipt.
+	MilestoneIndex milestone.Index
+	// The milestone index at which the funds were migrated in the legacy network.
+	MigratedAt milestone.Index
+	// The ID of the output that was created for the migrated funds.
+	OutputID iotago.UTXOInputID
+}
+
+func (m *MigratedFunds) bytes() ([]byte, error) {
+	addrBytes, err := m.Address.Serialize(serializer.DeSeriModeNoValidation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize address: %s, error: %w", m.Address, err)
+	}
+
+	value := marshalutil.New(99 + len(addrBytes))
+	value.WriteUint32(uint32(m.MilestoneIndex))
+	value.WriteUint32(uint32(m.MigratedAt))
+	value.WriteBytes(m.OutputID[:])
+	value.WriteUint64(m.Deposit)
+	value.WriteBytes(m.TailTransactionHash[:])
+	value.WriteBytes(addrBytes)
+
+	return value.Bytes(), nil
+}
+
+func migratedFundsFromBytes(value []byte) (*MigratedFunds, error) {
+	m := &MigratedFunds{}
+	marshalUtil := marshalutil.New(value)
+
+	msIndex, err := marshalUtil.ReadUint32()
+	if err != nil {
+		return nil, err
+	}
+	m.MilestoneIndex = milestone.Index(msIndex)
+
+	migratedAt, err := marshalUtil.ReadUint32()
+	if err != nil {
+		return nil, err
+	}
+	m.MigratedAt = milestone.Index(migratedAt)
+
+	outputID, err := marshalUtil.ReadBytes(utxo.OutputIDLength)
+	if err != nil {
+		return nil, err
+	}
+	copy(m.OutputID[:], outputID)
+
+	if m.Deposit, err = marshalUtil.ReadUint64(); err != nil {
+		return nil, err
+	}
+
+	tailTransactionHash, err := marshalUtil.ReadBytes(len(m.TailTransactionHash))
+	if err != nil {
+		return nil, err
+	}
+	copy(m.TailTransactionHash[:], tailTransactionHash)
+
+	if m.Address, err = addressFromBytes(value[marshalUtil.ReadOffset():]); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func migrationsTailTransactionHashKey(tailTransactionHash iotago.LegacyTailTransactionHash) []byte {
+	return byteutils.ConcatBytes([]byte{migrationsKeyPrefixTailTransactionHash}, tailTransactionHash[:])
+}
+
+func migrationsAddressKey(addrBytes []byte, msIndex milestone.Index, outputIndex uint16) []byte {
+	key := make([]byte, 6)
+	binary.BigEndian.PutUint32(key[:4], uint32(msIndex))
+	binary.BigEndian.PutUint16(key[4:], outputIndex)
+
+	return byteutils.ConcatBytes([]byte{migrationsKeyPrefixAddress}, addrBytes, key)
+}
+
+// createMigrationsIndex creates the lookup tables from legacy tail transaction hashes
+// and target addresses to the funds migrated by the receipts.
+func (db *Database) createMigrationsIndex(ctx context.Context) error {
+	// first we need to delete the old index before we rebuild it
+	if err := db.resetIndex(IndexStorePrefixMigrations, db.migrationsStore); err != nil {
+		return fmt.Errorf("deleting migrations index failed: %w", err)
+	}
+
+	var innerErr error
+	progress := db.newProgressLogger(ctx, "migrations")
+
+	var entryCounter int64
+	if err := db.utxoManager.ForEachReceiptTuple(func(rt *utxo.ReceiptTuple) bool {
+		// the outputs of the migrated funds entries are derived from the ID of the milestone,
+		// which is only known by the created treasury output in the milestone diff.
+		diff, err := db.utxoManager.MilestoneDiff(rt.MilestoneIndex)
+		if err != nil {
+			if errors.Is(err, kvstore.ErrKeyNotFound) {
+				// the milestone diff is not available
+				return true
+			}
+			innerErr = fmt.Errorf("reading milestone diff %d failed: %w", rt.MilestoneIndex, err)
+
+			return false
+		}
+
+		if diff.TreasuryOutput == nil {
+			innerErr = fmt.Errorf("%w: milestone diff %d contains no treasury output", utxo.ErrInvalidTreasuryState, rt.MilestoneIndex)
+
+			return false
+		}
+		milestoneID := diff.TreasuryOutput.MilestoneID
+
+		for outputIndex, entry := range rt.Receipt.Funds {
+			entryCounter++
+			if err := progress.Log("analyzed %d migrated funds entries", entryCounter); err != nil {
+				innerErr = err
+
+				return false
+			}
+
+			migratedFundsEntry, ok := entry.(*iotago.MigratedFundsEntry)
+			if !ok {
+				innerErr = fmt.Errorf("receipt in milestone %d contains an unsupported funds entry", rt.MilestoneIndex)
+
+				return false
+			}
+
+			address, ok := migratedFundsEntry.Address.(iotago.Address)
+			if !ok {
+				innerErr = fmt.Errorf("receipt in milestone %d contains an unsupported address type", rt.MilestoneIndex)
+
+				return false
+			}
+
+			migratedFunds := &MigratedFunds{
+				TailTransactionHash: migratedFundsEntry.TailTransactionHash,
+				Address:             address,
+				Deposit:             migratedFundsEntry.Deposit,
+				MilestoneIndex:      rt.MilestoneIndex,
+				MigratedAt:          milestone.Index(rt.Receipt.MigratedAt),
+				OutputID:            *utxo.OutputIDForMigratedFunds(milestoneID, uint16(outputIndex)),
+			}
+
+			addrBytes, err := address.Serialize(serializer.DeSeriModeNoValidation)
+			if err != nil {
+				innerErr = fmt.Errorf("failed to serialize address: %s, error: %w", address, err)
+
+				return false
+			}
+
+			value, err := migratedFunds.bytes()
+			if err != nil {
+				innerErr = err
+
+				return false
+			}
+
+			if err := db.migrationsStore.Set(migrationsTailTransactionHashKey(migratedFunds.TailTransactionHash), value); err != nil {
+				innerErr = fmt.Errorf("setting entry in migrations index failed, milestone: %d, error: %w", rt.MilestoneIndex, err)
+
+				return false
+			}
+
+			if err := db.migrationsStore.Set(migrationsAddressKey(addrBytes, rt.MilestoneIndex, uint16(outputIndex)), value); err != nil {
+				innerErr = fmt.Errorf("setting entry in migrations index failed, milestone: %d, error: %w", rt.MilestoneIndex, err)
+
+				return false
+			}
+		}
+
+		return true
+	}); err != nil {
+		return fmt.Errorf("iterating over all receipts failed: %w", err)
+	}
+
+	return innerErr
+}
+
+// migratedOutputIDs returns the IDs of all outputs that were created for migrated funds.
+func (db *Database) migratedOutputIDs() (map[iotago.UTXOInputID]struct{}, error) {
+	var innerErr error
+	outputIDs := make(map[iotago.UTXOInputID]struct{})
+	if err := db.migrationsStore.Iterate([]byte{migrationsKeyPrefixTailTransactionHash}, func(_ kvstore.Key, value kvstore.Value) bool {
+		migratedFunds, err := migratedFundsFromBytes(value)
+		if err != nil {
+			innerErr = err
+
+			return false
+		}
+		outputIDs[migratedFunds.OutputID] = struct{}{}
+
+		return true
+	}); err != nil {
+		return nil, fmt.Errorf("iterating over migrations failed: %w", err)
+	}
+
+	if innerErr != nil {
+		return nil, innerErr
+	}
+
+	return outputIDs, nil
+}
+
+// MigrationByTailTransactionHash returns the funds migrated by the legacy bundle with the given tail transaction hash.
+// It returns ErrMigrationNotFound if the bundle was not migrated.
+func (db *Database) MigrationByTailTransactionHash(tailTransactionHash iotago.LegacyTailTransactionHash) (*MigratedFunds, error) {
+	value, err := db.migrationsStore.Get(migrationsTailTransactionHashKey(tailTransactionHash))
+	if err != nil {
+		if errors.Is(err, kvstore.ErrKeyNotFound) {
+			return nil, ErrMigrationNotFound
+		}
+
+		return nil, fmt.Errorf("reading entry in migrations index failed: %w", err)
+	}
+
+	return migratedFundsFromBytes(value)
+}
+
+// AddressMigrations returns the funds migrated to the given address, sorted by the milestone that included them,
+// starting at the given offset.
+func (db *Database) AddressMigrations(address iotago.Address, offset int, maxResults int) ([]*MigratedFunds, error) {
+	addrBytes, err := address.Serialize(serializer.DeSeriModeNoValidation)
+	if err != nil {
+		return nil, err
+	}
+
+	var innerErr error
+	var i int
+
+	migrations := make([]*MigratedFunds, 0)
+	if err := db.migrationsStore.Iterate(byteutils.ConcatBytes([]byte{migrationsKeyPrefixAddress}, addrBytes), func(_ kvstore.Key, value kvstore.Value) bool {
+		i++
+		if i <= offset {
+			return true
+		}
+
+		if len(migrations) >= maxResults {
+			return false
+		}
+
+		migratedFunds, err := migratedFundsFromBytes(value)
+		if err != nil {
+			innerErr = err
+
+			return false
+		}
+
+		migrations = append(migrations, migratedFunds)
+
+		return true
+	}); err != nil {
+		return nil, err
+	}
+
+	if innerErr != nil {
+		return nil, innerErr
+	}
+
+	return migrations, nil
+}
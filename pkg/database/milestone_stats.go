@@ -0,0 +1,119 @@
+package database
+
+import (
+	"fmt"
+
+	"github.com/iotaledger/inx-api-core-v1/pkg/hornet"
+	"github.com/iotaledger/inx-api-core-v1/pkg/milestone"
+)
+
+// MilestoneStats contains the statistics of the messages referenced by a milestone and its ledger changes.
+type MilestoneStats struct {
+	// The index of the milestone.
+	Index milestone.Index
+	// The unix timestamp of the milestone.
+	Timestamp int64
+	// The seconds since the previous milestone. Zero if the previous milestone is not available.
+	TimeSincePreviousMilestone int64
+	// The amount of messages referenced by the milestone, including the milestone message itself.
+	ReferencedMessagesCount int64
+	// The amount of referenced messages with a transaction that was included in the ledger.
+	IncludedTransactionsCount int64
+	// The amount of referenced messages with a conflicting transaction.
+	ConflictingTransactionsCount int64
+	// The amount of referenced messages without a transaction.
+	NoTransactionsCount int64
+	// The sum of the amounts of all outputs consumed by the included transactions.
+	TotalValueTransferred uint64
+	// The amount of outputs created by the milestone.
+	CreatedOutputsCount int64
+	// The amount of outputs consumed by the milestone.
+	ConsumedOutputsCount int64
+}
+
+// forEachMessageMetadataInMilestoneCone walks the past cone of the milestone message and calls the consumer
+// for all messages that were referenced by the milestone (the white-flag cone).
+// The walk stops at messages that were referenced by older milestones or that are not available.
+func (db *Database) forEachMessageMetadataInMilestoneCone(msIndex milestone.Index, consumer func(msgMeta *MessageMetadata)) error {
+	ms := db.MilestoneOrNil(msIndex)
+	if ms == nil {
+		return fmt.Errorf("%w: %d", ErrMilestoneNotFound, msIndex)
+	}
+
+	visited := make(map[string]struct{})
+	stack := hornet.MessageIDs{ms.MessageID}
+
+	for len(stack) > 0 {
+		messageID := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		if _, exists := visited[messageID.ToMapKey()]; exists {
+			continue
+		}
+		visited[messageID.ToMapKey()] = struct{}{}
+
+		msgMeta := db.MessageMetadataOrNil(messageID)
+		if msgMeta == nil {
+			// the message is a solid entry point or was pruned
+			continue
+		}
+
+		referenced, referencedIndex := msgMeta.ReferencedWithIndex()
+		if !referenced || referencedIndex != msIndex {
+			continue
+		}
+
+		consumer(msgMeta)
+
+		stack = append(stack, msgMeta.Parents()...)
+	}
+
+	return nil
+}
+
+// MilestoneStats computes the statistics of the messages referenced by the milestone and its ledger changes.
+func (db *Database) MilestoneStats(msIndex milestone.Index) (*MilestoneStats, error) {
+	ms := db.MilestoneOrNil(msIndex)
+	if ms == nil {
+		return nil, fmt.Errorf("%w: %d", ErrMilestoneNotFound, msIndex)
+	}
+
+	stats := &MilestoneStats{
+		Index:     msIndex,
+		Timestamp: ms.Timestamp.Unix(),
+	}
+
+	if msIndex > 0 {
+		if previousMs := db.MilestoneOrNil(msIndex - 1); previousMs != nil {
+			stats.TimeSincePreviousMilestone = stats.Timestamp - previousMs.Timestamp.Unix()
+		}
+	}
+
+	if err := db.forEachMessageMetadataInMilestoneCone(msIndex, func(msgMeta *MessageMetadata) {
+		stats.ReferencedMessagesCount++
+
+		switch {
+		case msgMeta.IsNoTransaction():
+			stats.NoTransactionsCount++
+		case msgMeta.IsConflictingTx():
+			stats.ConflictingTransactionsCount++
+		case msgMeta.IsIncludedTxInLedger():
+			stats.IncludedTransactionsCount++
+		}
+	}); err != nil {
+		return nil, err
+	}
+
+	diff, err := db.utxoManager.MilestoneDiff(msIndex)
+	if err != nil {
+		return nil, fmt.Errorf("reading milestone diff %d failed: %w", msIndex, err)
+	}
+
+	stats.CreatedOutputsCount = int64(len(diff.Outputs))
+	stats.ConsumedOutputsCount = int64(len(diff.Spents))
+	for _, spent := range diff.Spents {
+		stats.TotalValueTransferred += spent.Amount()
+	}
+
+	return stats, nil
+}
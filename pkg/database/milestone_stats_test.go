@@ -0,0 +1,88 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/inx-api-core-v1/pkg/hornet"
+)
+
+// storeTestMilestoneCone stores a small DAG around milestone 5:
+//
+//	M (milestone 5) -> A, B
+//	A (included)    -> C, X
+//	B (conflicting) -> C, D
+//	C (no tx)       -> Y
+//	D (included)    -> Z
+//	X (referenced by milestone 4) -> Y
+//	Y (referenced by milestone 3)
+//	Z is not available (pruned or a solid entry point).
+func storeTestMilestoneCone(t *testing.T, db *Database) map[string]hornet.MessageID {
+	t.Helper()
+
+	messageIDs := map[string]hornet.MessageID{
+		"M": testMessageID(1),
+		"A": testMessageID(2),
+		"B": testMessageID(3),
+		"C": testMessageID(4),
+		"D": testMessageID(5),
+		"X": testMessageID(6),
+		"Y": testMessageID(7),
+		"Z": testMessageID(8),
+	}
+
+	storeTestMessageMetadata(t, db, messageIDs["M"], 5, []uint{MessageMetadataMilestone, MessageMetadataNoTx}, messageIDs["A"], messageIDs["B"])
+	storeTestMessageMetadata(t, db, messageIDs["A"], 5, nil, messageIDs["C"], messageIDs["X"])
+	storeTestMessageMetadata(t, db, messageIDs["B"], 5, []uint{MessageMetadataConflictingTx}, messageIDs["C"], messageIDs["D"])
+	storeTestMessageMetadata(t, db, messageIDs["C"], 5, []uint{MessageMetadataNoTx}, messageIDs["Y"])
+	storeTestMessageMetadata(t, db, messageIDs["D"], 5, nil, messageIDs["Z"])
+	storeTestMessageMetadata(t, db, messageIDs["X"], 4, nil, messageIDs["Y"])
+	storeTestMessageMetadata(t, db, messageIDs["Y"], 3, nil)
+
+	storeTestMilestone(t, db, 4, testMessageID(100), time.Unix(1000, 0))
+	storeTestMilestone(t, db, 5, messageIDs["M"], time.Unix(1010, 0))
+
+	return messageIDs
+}
+
+func TestForEachMessageMetadataInMilestoneCone(t *testing.T) {
+	db := newTestDatabase(t)
+	messageIDs := storeTestMilestoneCone(t, db)
+
+	visited := make(map[string]int)
+	require.NoError(t, db.forEachMessageMetadataInMilestoneCone(5, func(msgMeta *MessageMetadata) {
+		visited[msgMeta.MessageID().ToMapKey()]++
+	}))
+
+	require.Len(t, visited, 5)
+	for _, name := range []string{"M", "A", "B", "C", "D"} {
+		require.Equal(t, 1, visited[messageIDs[name].ToMapKey()], "message %s must be visited exactly once", name)
+	}
+
+	// the walk stops at messages referenced by older milestones
+	require.NotContains(t, visited, messageIDs["X"].ToMapKey())
+	require.NotContains(t, visited, messageIDs["Y"].ToMapKey())
+
+	require.ErrorIs(t, db.forEachMessageMetadataInMilestoneCone(6, func(_ *MessageMetadata) {}), ErrMilestoneNotFound)
+}
+
+func TestMilestoneStats(t *testing.T) {
+	db := newTestDatabase(t)
+	storeTestMilestoneCone(t, db)
+	storeTestEmptyMilestoneDiff(t, db, 5)
+
+	stats, err := db.MilestoneStats(5)
+	require.NoError(t, err)
+
+	require.EqualValues(t, 5, stats.Index)
+	require.EqualValues(t, 1010, stats.Timestamp)
+	require.EqualValues(t, 10, stats.TimeSincePreviousMilestone)
+	require.EqualValues(t, 5, stats.ReferencedMessagesCount)
+	require.EqualValues(t, 2, stats.IncludedTransactionsCount)
+	require.EqualValues(t, 1, stats.ConflictingTransactionsCount)
+	require.EqualValues(t, 2, stats.NoTransactionsCount)
+	require.Zero(t, stats.CreatedOutputsCount)
+	require.Zero(t, stats.ConsumedOutputsCount)
+}
@@ -67,3 +67,29 @@ func (db *Database) MilestoneTimestampUnixByIndex(milestoneIndex milestone.Index
 
 	return ms.Timestamp.Unix(), nil
 }
+
+// MilestoneIndexByTimestamp returns the index of the first milestone after the pruning index
+// whose timestamp is not before the given time. If there is no such milestone, the index
+// following the ledger index is returned.
+func (db *Database) MilestoneIndexByTimestamp(timestamp time.Time) (milestone.Index, error) {
+	lowIndex := db.snapshot.PruningIndex + 1
+	highIndex := db.utxoManager.ReadLedgerIndex() + 1
+
+	// binary search over the milestones, the timestamps of milestones are monotonic
+	for lowIndex < highIndex {
+		midIndex := lowIndex + (highIndex-lowIndex)/2
+
+		ms := db.MilestoneOrNil(midIndex)
+		if ms == nil {
+			return 0, fmt.Errorf("%w: %d", ErrMilestoneNotFound, midIndex)
+		}
+
+		if ms.Timestamp.Before(timestamp) {
+			lowIndex = midIndex + 1
+		} else {
+			highIndex = midIndex
+		}
+	}
+
+	return lowIndex, nil
+}
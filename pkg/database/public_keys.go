@@ -0,0 +1,271 @@
+package database
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/serializer"
+	"github.com/iotaledger/hive.go/serializer/v2/byteutils"
+	"github.com/iotaledger/hive.go/serializer/v2/marshalutil"
+	"github.com/iotaledger/inx-api-core-v1/pkg/hornet"
+	"github.com/iotaledger/inx-api-core-v1/pkg/milestone"
+	iotago "github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/ed25519"
+)
+
+const (
+	// PublicKeysIndexVersion is the version of the layout of the public keys index.
+	PublicKeysIndexVersion = 1
+)
+
+/*
+
+   Public keys:
+   ============
+   Key:
+       iotago.Ed25519Address.Serialized() + PublicKey
+              1 byte type + 32 bytes      + 32 bytes
+
+   Value:
+       TransactionID + MessageID + MilestoneIndex + MilestoneTimestamp
+         32 bytes    + 32 bytes  +    4 bytes     +      8 bytes
+
+   The value contains the first transaction that revealed the public key.
+
+*/
+
+// PublicKeyReveal contains an ed25519 public key and the first transaction that revealed it.
+type PublicKeyReveal struct {
+	// The public key.
+	PublicKey ed25519.PublicKey
+	// The ID of the first transaction that revealed the public key.
+	TransactionID iotago.TransactionID
+	// The message that contains the transaction.
+	MessageID hornet.MessageID
+	// The milestone index that references the message.
+	MilestoneIndex milestone.Index
+	// The milestone timestamp that references the message.
+	MilestoneTimestamp int64
+}
+
+func (r *PublicKeyReveal) bytes() []byte {
+	m := marshalutil.New(76)
+	m.WriteBytes(r.TransactionID[:])
+	m.WriteBytes(r.MessageID)
+	m.WriteUint32(uint32(r.MilestoneIndex))
+	m.WriteInt64(r.MilestoneTimestamp)
+
+	return m.Bytes()
+}
+
+// revealedBefore returns true if the public key was revealed by r before it was revealed by other.
+func (r *PublicKeyReveal) revealedBefore(other *PublicKeyReveal) bool {
+	if r.MilestoneIndex != other.MilestoneIndex {
+		return r.MilestoneIndex < other.MilestoneIndex
+	}
+
+	return bytes.Compare(r.MessageID, other.MessageID) < 0
+}
+
+func publicKeyRevealFromKeyAndValue(addrLength int, key []byte, value []byte) (*PublicKeyReveal, error) {
+	if len(key) != addrLength+ed25519.PublicKeySize {
+		return nil, fmt.Errorf("invalid public key index key length: %d", len(key))
+	}
+
+	r := &PublicKeyReveal{
+		PublicKey: ed25519.PublicKey(byteutils.ConcatBytes(key[addrLength:])),
+	}
+
+	marshalUtil := marshalutil.New(value)
+
+	transactionID, err := marshalUtil.ReadBytes(iotago.TransactionIDLength)
+	if err != nil {
+		return nil, err
+	}
+	copy(r.TransactionID[:], transactionID)
+
+	messageID, err := marshalUtil.ReadBytes(iotago.MessageIDLength)
+	if err != nil {
+		return nil, err
+	}
+	r.MessageID = hornet.MessageIDFromSlice(messageID)
+
+	msIndex, err := marshalUtil.ReadUint32()
+	if err != nil {
+		return nil, err
+	}
+	r.MilestoneIndex = milestone.Index(msIndex)
+
+	if r.MilestoneTimestamp, err = marshalUtil.ReadInt64(); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// createPublicKeysIndex creates the lookup table from ed25519 addresses to the public keys
+// revealed in the signature unlock blocks of referenced transactions.
+func (db *Database) createPublicKeysIndex(ctx context.Context) error {
+	// first we need to delete the old index before we rebuild it
+	if err := db.resetIndex(IndexStorePrefixPublicKeys, db.publicKeysStore); err != nil {
+		return fmt.Errorf("deleting public keys index failed: %w", err)
+	}
+
+	// sets the entry if the public key was not revealed before
+	setPublicKeyReveal := func(reveal *PublicKeyReveal) error {
+		address := iotago.AddressFromEd25519PubKey(reveal.PublicKey)
+
+		addrBytes, err := address.Serialize(serializer.DeSeriModeNoValidation)
+		if err != nil {
+			return fmt.Errorf("failed to serialize address, msgID: %s, address: %s, error: %w", reveal.MessageID.ToHex(), address.String(), err)
+		}
+
+		key := byteutils.ConcatBytes(addrBytes, reveal.PublicKey)
+
+		value, err := db.publicKeysStore.Get(key)
+		if err != nil && !errors.Is(err, kvstore.ErrKeyNotFound) {
+			return fmt.Errorf("reading entry in public keys index failed, msgID: %s, error: %w", reveal.MessageID.ToHex(), err)
+		}
+
+		if err == nil {
+			existing, err := publicKeyRevealFromKeyAndValue(len(addrBytes), key, value)
+			if err != nil {
+				return err
+			}
+
+			if existing.revealedBefore(reveal) {
+				return nil
+			}
+		}
+
+		if err := db.publicKeysStore.Set(key, reveal.bytes()); err != nil {
+			return fmt.Errorf("setting entry in public keys index failed, msgID: %s, error: %w", reveal.MessageID.ToHex(), err)
+		}
+
+		return nil
+	}
+
+	var innerErr error
+	progress := db.newProgressLogger(ctx, "public keys")
+
+	var metadataCounter int64
+	if err := db.metadataStore.Iterate(kvstore.EmptyPrefix, func(key []byte, data []byte) bool {
+		metadataCounter++
+		if err := progress.Log("analyzed %d messages", metadataCounter); err != nil {
+			innerErr = err
+
+			return false
+		}
+
+		messageID := hornet.MessageIDFromSlice(key[:iotago.MessageIDLength])
+
+		msgMeta, err := metadataFactory(messageID, data)
+		if err != nil {
+			innerErr = fmt.Errorf("failed to deserialize message metadata: %s, error: %w", messageID.ToHex(), err)
+
+			return false
+		}
+
+		// only transactions that were referenced by a milestone are part of the history.
+		// the signatures of transactions that are conflicting because of an invalid signature can't be trusted.
+		referenced, referencedIndex := msgMeta.ReferencedWithIndex()
+		if !referenced || msgMeta.IsNoTransaction() || msgMeta.Conflict() == ConflictInvalidSignature {
+			return true
+		}
+
+		msg := db.MessageOrNil(messageID)
+		if msg == nil {
+			innerErr = fmt.Errorf("message not found: %s", messageID.ToHex())
+
+			return false
+		}
+
+		txPayload := msg.Transaction()
+		if txPayload == nil {
+			// milestones with receipts are not transactions
+			return true
+		}
+
+		transactionID, err := txPayload.ID()
+		if err != nil {
+			innerErr = fmt.Errorf("can't compute the transaction ID, msgID: %s, error: %w", messageID.ToHex(), err)
+
+			return false
+		}
+
+		milestoneTimestamp, err := db.MilestoneTimestampUnixByIndex(referencedIndex)
+		if err != nil {
+			innerErr = err
+
+			return false
+		}
+
+		for _, unlockBlock := range txPayload.UnlockBlocks {
+			signatureUnlockBlock, ok := unlockBlock.(*iotago.SignatureUnlockBlock)
+			if !ok {
+				// reference unlock blocks don't reveal new public keys
+				continue
+			}
+
+			signature, ok := signatureUnlockBlock.Signature.(*iotago.Ed25519Signature)
+			if !ok {
+				innerErr = fmt.Errorf("transaction contains an unsupported signature type: msgID: %s", messageID.ToHex())
+
+				return false
+			}
+
+			if err := setPublicKeyReveal(&PublicKeyReveal{
+				PublicKey:          byteutils.ConcatBytes(signature.PublicKey[:]),
+				TransactionID:      *transactionID,
+				MessageID:          messageID,
+				MilestoneIndex:     referencedIndex,
+				MilestoneTimestamp: milestoneTimestamp,
+			}); err != nil {
+				innerErr = err
+
+				return false
+			}
+		}
+
+		return true
+	}, kvstore.IterDirectionForward); err != nil {
+		return fmt.Errorf("iterating over all existing messages failed: %w", err)
+	}
+
+	return innerErr
+}
+
+// AddressPublicKeys returns the public keys that were revealed for the given ed25519 address
+// together with the first transaction that revealed them.
+func (db *Database) AddressPublicKeys(address *iotago.Ed25519Address) ([]*PublicKeyReveal, error) {
+	addrBytes, err := address.Serialize(serializer.DeSeriModeNoValidation)
+	if err != nil {
+		return nil, err
+	}
+
+	var innerErr error
+	reveals := make([]*PublicKeyReveal, 0)
+	if err := db.publicKeysStore.Iterate(addrBytes, func(key kvstore.Key, value kvstore.Value) bool {
+		reveal, err := publicKeyRevealFromKeyAndValue(len(addrBytes), key, value)
+		if err != nil {
+			innerErr = err
+
+			return false
+		}
+
+		reveals = append(reveals, reveal)
+
+		return true
+	}); err != nil {
+		return nil, err
+	}
+
+	if innerErr != nil {
+		return nil, innerErr
+	}
+
+	return reveals, nil
+}
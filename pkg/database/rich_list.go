@@ -0,0 +1,202 @@
+package database
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/serializer"
+	"github.com/iotaledger/hive.go/serializer/v2/byteutils"
+	"github.com/iotaledger/hive.go/serializer/v2/marshalutil"
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+const (
+	// RichListIndexVersion is the version of the layout of the rich list index.
+	RichListIndexVersion = 1
+)
+
+/*
+
+   Rich list:
+   ==========
+   Key:
+       (math.MaxUint64 - Balance) + iotago.Ed25519Address.Serialized()
+        8 bytes (big endian)      +       1 byte type + 32 bytes
+
+   Value:
+       DustAllowance + DustOutputCount
+          8 bytes    +    8 bytes
+
+   The inverted balance in the key sorts the addresses by highest balance.
+   Addresses without balance are not part of the rich list.
+
+*/
+
+// RichListEntry is an address in the rich list.
+type RichListEntry struct {
+	// The position of the address in the rich list, starting at 1.
+	Rank int
+	// The address.
+	Address iotago.Address
+	// The balance of the address.
+	Balance uint64
+	// The sum of the amounts of all dust allowance outputs on the address.
+	DustAllowanceBalance uint64
+	// The amount of dust outputs on the address.
+	DustOutputCount int64
+}
+
+// RichListSummary contains the totals of all addresses in the rich list.
+type RichListSummary struct {
+	// The amount of addresses with a balance.
+	AddressCount int
+	// The sum of the balances of all addresses.
+	TotalBalance uint64
+}
+
+func richListKey(addrBytes []byte, balance uint64) []byte {
+	invertedBalance := make([]byte, 8)
+	binary.BigEndian.PutUint64(invertedBalance, math.MaxUint64-balance)
+
+	return byteutils.ConcatBytes(invertedBalance, addrBytes)
+}
+
+func richListEntryFromKeyAndValue(key []byte, value []byte) (*RichListEntry, error) {
+	if len(key) < 8 {
+		return nil, fmt.Errorf("invalid rich list key length: %d", len(key))
+	}
+
+	address, err := addressFromBytes(key[8:])
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &RichListEntry{
+		Address: address,
+		Balance: math.MaxUint64 - binary.BigEndian.Uint64(key[:8]),
+	}
+
+	marshalUtil := marshalutil.New(value)
+
+	if entry.DustAllowanceBalance, err = marshalUtil.ReadUint64(); err != nil {
+		return nil, err
+	}
+
+	if entry.DustOutputCount, err = marshalUtil.ReadInt64(); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// createRichListIndex creates the list of all addresses with a balance sorted by highest balance.
+func (db *Database) createRichListIndex(ctx context.Context) error {
+	// first we need to delete the old index before we rebuild it
+	if err := db.resetIndex(IndexStorePrefixRichList, db.richListStore); err != nil {
+		return fmt.Errorf("deleting rich list index failed: %w", err)
+	}
+
+	var innerErr error
+	progress := db.newProgressLogger(ctx, "rich list")
+
+	var addressCounter int64
+	if err := db.utxoManager.ForEachBalance(func(address iotago.Address, balance uint64, dustAllowanceBalance uint64, dustOutputCount int64) bool {
+		addressCounter++
+		if err := progress.Log("analyzed %d addresses", addressCounter); err != nil {
+			innerErr = err
+
+			return false
+		}
+
+		if balance == 0 {
+			return true
+		}
+
+		addrBytes, err := address.Serialize(serializer.DeSeriModeNoValidation)
+		if err != nil {
+			innerErr = fmt.Errorf("failed to serialize address: %s, error: %w", address, err)
+
+			return false
+		}
+
+		value := marshalutil.New(16)
+		value.WriteUint64(dustAllowanceBalance)
+		value.WriteInt64(dustOutputCount)
+
+		if err := db.richListStore.Set(richListKey(addrBytes, balance), value.Bytes()); err != nil {
+			innerErr = fmt.Errorf("setting entry in rich list index failed, address: %s, error: %w", address, err)
+
+			return false
+		}
+
+		return true
+	}); err != nil {
+		return fmt.Errorf("iterating over all balances failed: %w", err)
+	}
+
+	return innerErr
+}
+
+// loadRichListSummary computes the totals of the rich list.
+func (db *Database) loadRichListSummary() error {
+	summary := &RichListSummary{}
+
+	if err := db.richListStore.IterateKeys(kvstore.EmptyPrefix, func(key kvstore.Key) bool {
+		summary.AddressCount++
+		summary.TotalBalance += math.MaxUint64 - binary.BigEndian.Uint64(key[:8])
+
+		return true
+	}); err != nil {
+		return fmt.Errorf("loading rich list summary failed: %w", err)
+	}
+
+	db.richListSummary = summary
+
+	return nil
+}
+
+// RichListSummary returns the totals of all addresses in the rich list.
+func (db *Database) RichListSummary() *RichListSummary {
+	return db.richListSummary
+}
+
+// RichList returns the addresses with the highest balances, starting at the given offset.
+func (db *Database) RichList(offset int, maxResults int) ([]*RichListEntry, error) {
+	var innerErr error
+	var i int
+
+	entries := make([]*RichListEntry, 0)
+	if err := db.richListStore.Iterate(kvstore.EmptyPrefix, func(key kvstore.Key, value kvstore.Value) bool {
+		i++
+		if i <= offset {
+			return true
+		}
+
+		if len(entries) >= maxResults {
+			return false
+		}
+
+		entry, err := richListEntryFromKeyAndValue(key, value)
+		if err != nil {
+			innerErr = err
+
+			return false
+		}
+		entry.Rank = i
+
+		entries = append(entries, entry)
+
+		return true
+	}); err != nil {
+		return nil, err
+	}
+
+	if innerErr != nil {
+		return nil, innerErr
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,302 @@
+package labels
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/iotaledger/hive.go/logger"
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+const (
+	// LabelFileExtension is the extension of the files in the labels directory that contain labels.
+	LabelFileExtension = ".csv"
+
+	// reloadDelay is the time to wait after the last change in the labels directory before the labels are reloaded.
+	// editors and spreadsheet exports often write files in several steps.
+	reloadDelay = time.Second
+)
+
+/*
+
+   Label files:
+   ============
+   Every CSV file in the labels directory contains one label per line:
+       Address,Name,Category,Note
+
+   The address is either bech32 encoded or a hex encoded ed25519 address.
+   The category and note columns are optional. If the category is empty, the name of the file is used instead.
+   A first line that starts with "address" is treated as header and ignored.
+   If an address is labeled in several files, the label of the last file in lexical order is used.
+
+*/
+
+// Label is the name and category of an address maintained in the label files.
+type Label struct {
+	// The labeled address.
+	Address iotago.Address
+	// The name of the owner of the address.
+	Name string
+	// The category of the address, e.g. exchange or foundation.
+	Category string
+	// An optional note about the address.
+	Note string
+	// The name of the file the label was loaded from.
+	Source string
+}
+
+// Manager loads the labels from the label files and reloads them if the files change.
+type Manager struct {
+	*logger.WrappedLogger
+
+	directory string
+	bech32HRP iotago.NetworkPrefix
+
+	labelsLock sync.RWMutex
+	labels     map[string]*Label
+}
+
+// NewManager creates a new label manager for the given directory.
+// If the directory is empty, no labels are loaded.
+func NewManager(log *logger.Logger, directory string, bech32HRP iotago.NetworkPrefix) *Manager {
+	return &Manager{
+		WrappedLogger: logger.NewWrappedLogger(log),
+		directory:     directory,
+		bech32HRP:     bech32HRP,
+		labels:        make(map[string]*Label),
+	}
+}
+
+// Enabled returns true if a labels directory was configured.
+func (m *Manager) Enabled() bool {
+	return m.directory != ""
+}
+
+// Load (re)loads all label files in the labels directory.
+// Invalid lines are skipped and logged, the previous labels stay active if the directory can't be read.
+func (m *Manager) Load() error {
+	if !m.Enabled() {
+		return nil
+	}
+
+	entries, err := os.ReadDir(m.directory)
+	if err != nil {
+		return fmt.Errorf("reading labels directory failed: %w", err)
+	}
+
+	// os.ReadDir returns the entries sorted by filename
+	labels := make(map[string]*Label)
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(entry.Name()), LabelFileExtension) {
+			continue
+		}
+
+		if err := m.loadFile(entry.Name(), labels); err != nil {
+			m.LogWarnf("loading label file %s failed: %s", entry.Name(), err)
+		}
+	}
+
+	m.labelsLock.Lock()
+	m.labels = labels
+	m.labelsLock.Unlock()
+
+	m.LogInfof("loaded %d labels from %s", len(labels), m.directory)
+
+	return nil
+}
+
+func (m *Manager) loadFile(fileName string, labels map[string]*Label) error {
+	file, err := os.Open(filepath.Join(m.directory, fileName))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.LazyQuotes = true
+
+	defaultCategory := strings.TrimSuffix(fileName, filepath.Ext(fileName))
+
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			return err
+		}
+
+		if line == 1 && len(record) > 0 && strings.EqualFold(strings.TrimSpace(record[0]), "address") {
+			// skip the header
+			continue
+		}
+
+		if len(record) < 2 {
+			m.LogWarnf("%s:%d: expected at least address and name", fileName, line)
+
+			continue
+		}
+
+		address, err := m.parseAddress(strings.TrimSpace(record[0]))
+		if err != nil {
+			m.LogWarnf("%s:%d: invalid address: %s", fileName, line, err)
+
+			continue
+		}
+
+		label := &Label{
+			Address:  address,
+			Name:     strings.TrimSpace(record[1]),
+			Category: defaultCategory,
+			Source:   fileName,
+		}
+
+		if len(record) > 2 && strings.TrimSpace(record[2]) != "" {
+			label.Category = strings.TrimSpace(record[2])
+		}
+
+		if len(record) > 3 {
+			label.Note = strings.TrimSpace(record[3])
+		}
+
+		labels[address.String()] = label
+	}
+}
+
+// parseAddress parses a bech32 or hex encoded ed25519 address.
+func (m *Manager) parseAddress(addressString string) (iotago.Address, error) {
+	addressString = strings.ToLower(addressString)
+
+	if hrp, address, err := iotago.ParseBech32(addressString); err == nil {
+		if hrp != m.bech32HRP {
+			return nil, fmt.Errorf("invalid bech32 address, expected prefix: %s", m.bech32HRP)
+		}
+
+		return address, nil
+	}
+
+	addressBytes, err := hex.DecodeString(strings.TrimPrefix(addressString, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("neither bech32 nor hex encoded: %s", addressString)
+	}
+
+	if len(addressBytes) != iotago.Ed25519AddressBytesLength {
+		return nil, fmt.Errorf("invalid ed25519 address length: %s", addressString)
+	}
+
+	var address iotago.Ed25519Address
+	copy(address[:], addressBytes)
+
+	return &address, nil
+}
+
+// Label returns the label of the given address or nil if the address is not labeled.
+func (m *Manager) Label(address iotago.Address) *Label {
+	m.labelsLock.RLock()
+	defer m.labelsLock.RUnlock()
+
+	return m.labels[address.String()]
+}
+
+// Search returns the labels whose address, name, category or note contain the given query (case-insensitive),
+// sorted by category and name. An empty query matches all labels.
+func (m *Manager) Search(query string, maxResults int) []*Label {
+	query = strings.ToLower(strings.TrimSpace(query))
+
+	m.labelsLock.RLock()
+	results := make([]*Label, 0)
+	for _, label := range m.labels {
+		if query == "" ||
+			strings.Contains(label.Address.String(), strings.TrimPrefix(query, "0x")) ||
+			strings.Contains(label.Address.Bech32(m.bech32HRP), query) ||
+			strings.Contains(strings.ToLower(label.Name), query) ||
+			strings.Contains(strings.ToLower(label.Category), query) ||
+			strings.Contains(strings.ToLower(label.Note), query) {
+			results = append(results, label)
+		}
+	}
+	m.labelsLock.RUnlock()
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Category != results[j].Category {
+			return results[i].Category < results[j].Category
+		}
+		if results[i].Name != results[j].Name {
+			return results[i].Name < results[j].Name
+		}
+
+		return results[i].Address.String() < results[j].Address.String()
+	})
+
+	if maxResults > 0 && len(results) > maxResults {
+		results = results[:maxResults]
+	}
+
+	return results
+}
+
+// WatchChanges reloads the labels whenever a file in the labels directory changes until the context is done.
+func (m *Manager) WatchChanges(ctx context.Context) error {
+	if !m.Enabled() {
+		return nil
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("creating labels directory watcher failed: %w", err)
+	}
+	defer watcher.Close()
+
+	if err := watcher.Add(m.directory); err != nil {
+		return fmt.Errorf("watching labels directory failed: %w", err)
+	}
+
+	reloadTimer := time.NewTimer(reloadDelay)
+	reloadTimer.Stop()
+	defer reloadTimer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+
+			if !strings.EqualFold(filepath.Ext(event.Name), LabelFileExtension) {
+				continue
+			}
+
+			// delay the reload until no further changes happen
+			reloadTimer.Reset(reloadDelay)
+
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			m.LogWarnf("watching labels directory failed: %s", err)
+
+		case <-reloadTimer.C:
+			if err := m.Load(); err != nil {
+				m.LogWarn(err)
+			}
+		}
+	}
+}
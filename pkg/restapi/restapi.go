@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/pkg/errors"
@@ -15,6 +16,11 @@ import (
 	iotago "github.com/iotaledger/iota.go/v2"
 )
 
+const (
+	// DateFormat is the format of dates in query parameters and responses.
+	DateFormat = "2006-01-02"
+)
+
 const (
 	// ParameterMessageID is used to identify a message by its ID.
 	ParameterMessageID = "messageID"
@@ -31,8 +37,56 @@ const (
 	// ParameterMilestoneIndex is used to identify a milestone.
 	ParameterMilestoneIndex = "milestoneIndex"
 
+	// ParameterTailTransactionHash is used to identify a migration bundle of the legacy network by its tail transaction hash.
+	ParameterTailTransactionHash = "tailTransactionHash"
+
+	// ParameterClusterID is used to identify an address cluster by its ID.
+	ParameterClusterID = "clusterID"
+
+	// QueryParameterIndex is used to search messages by their hex encoded indexation index.
+	QueryParameterIndex = "index"
+
+	// QueryParameterIndexPrefix is used to search messages by the hex encoded prefix of their indexation index.
+	QueryParameterIndexPrefix = "indexPrefix"
+
+	// QueryParameterIndexUTF8 is used to search messages by the UTF-8 encoded prefix of their indexation index.
+	QueryParameterIndexUTF8 = "indexUtf8"
+
+	// QueryParameterEnvelope is used to return the data embedded in a JSON envelope.
+	QueryParameterEnvelope = "envelope"
+
+	// QueryParameterExpanded is used to return the full details of the items instead of their IDs.
+	QueryParameterExpanded = "expanded"
+
+	// QueryParameterSearchQuery is used to define the words of a full-text search.
+	QueryParameterSearchQuery = "q"
+
 	// QueryParameterPageSize is used to define the page size for the results.
 	QueryParameterPageSize = "pageSize"
+
+	// QueryParameterLimit is used to define the maximum amount of results of a paginated request.
+	QueryParameterLimit = "limit"
+
+	// QueryParameterOffset is used to define the amount of results that are skipped in a paginated request.
+	QueryParameterOffset = "offset"
+
+	// QueryParameterFrom is used to define the inclusive start of a range.
+	QueryParameterFrom = "from"
+
+	// QueryParameterTo is used to define the inclusive end of a range.
+	QueryParameterTo = "to"
+
+	// QueryParameterMinAgeDays is used to define the minimum age in days.
+	QueryParameterMinAgeDays = "minAgeDays"
+
+	// QueryParameterMinAmount is used to define the minimum amount.
+	QueryParameterMinAmount = "minAmount"
+
+	// QueryParameterFinal is used to filter receipts by their final flag.
+	QueryParameterFinal = "final"
+
+	// QueryParameterLabelQuery is used to search the labels by address, name, category or note.
+	QueryParameterLabelQuery = "query"
 )
 
 var (
@@ -109,6 +163,24 @@ func ParseOutputIDParam(c echo.Context) (*iotago.UTXOInputID, error) {
 	return &outputID, nil
 }
 
+func ParseTailTransactionHashParam(c echo.Context) (iotago.LegacyTailTransactionHash, error) {
+	var tailTransactionHash iotago.LegacyTailTransactionHash
+
+	tailTransactionHashHex := strings.ToLower(c.Param(ParameterTailTransactionHash))
+
+	tailTransactionHashBytes, err := hex.DecodeString(tailTransactionHashHex)
+	if err != nil {
+		return tailTransactionHash, errors.WithMessagef(ErrInvalidParameter, "invalid tail transaction hash: %s, error: %s", tailTransactionHashHex, err)
+	}
+
+	if len(tailTransactionHashBytes) != len(tailTransactionHash) {
+		return tailTransactionHash, errors.WithMessagef(ErrInvalidParameter, "invalid tail transaction hash: %s, invalid length: %d", tailTransactionHashHex, len(tailTransactionHashBytes))
+	}
+	copy(tailTransactionHash[:], tailTransactionHashBytes)
+
+	return tailTransactionHash, nil
+}
+
 func ParseBech32AddressParam(c echo.Context, prefix iotago.NetworkPrefix) (iotago.Address, error) {
 	addressParam := strings.ToLower(c.Param(ParameterAddress))
 
@@ -155,3 +227,74 @@ func ParseMilestoneIndexParam(c echo.Context) (milestone.Index, error) {
 
 	return milestone.Index(msIndex), nil
 }
+
+func ParseClusterIDParam(c echo.Context) (uint32, error) {
+	clusterIDParam := c.Param(ParameterClusterID)
+
+	clusterID, err := strconv.ParseUint(clusterIDParam, 10, 32)
+	if err != nil {
+		return 0, errors.WithMessagef(ErrInvalidParameter, "invalid cluster ID: %s, error: %s", clusterIDParam, err)
+	}
+
+	return uint32(clusterID), nil
+}
+
+// ParseMilestoneIndexQueryParam parses a milestone index from the query parameter with the given name.
+func ParseMilestoneIndexQueryParam(c echo.Context, paramName string) (milestone.Index, error) {
+	milestoneIndex := strings.ToLower(c.QueryParam(paramName))
+	if milestoneIndex == "" {
+		return 0, errors.WithMessagef(ErrInvalidParameter, "parameter \"%s\" not specified", paramName)
+	}
+
+	msIndex, err := strconv.ParseUint(milestoneIndex, 10, 32)
+	if err != nil {
+		return 0, errors.WithMessagef(ErrInvalidParameter, "invalid milestone index: %s, error: %s", milestoneIndex, err)
+	}
+
+	return milestone.Index(msIndex), nil
+}
+
+// ParseBoolQueryParam parses the boolean query parameter with the given name. It returns false if the parameter is not given.
+func ParseBoolQueryParam(c echo.Context, paramName string) (bool, error) {
+	value := strings.ToLower(c.QueryParam(paramName))
+	if value == "" {
+		return false, nil
+	}
+
+	result, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, errors.WithMessagef(ErrInvalidParameter, "invalid value for query parameter \"%s\": %s, error: %s", paramName, value, err)
+	}
+
+	return result, nil
+}
+
+// ParseDateQueryParam parses the query parameter with the given name as date (YYYY-MM-DD, UTC).
+func ParseDateQueryParam(c echo.Context, paramName string) (time.Time, error) {
+	value := c.QueryParam(paramName)
+	if value == "" {
+		return time.Time{}, errors.WithMessagef(ErrInvalidParameter, "parameter \"%s\" not specified", paramName)
+	}
+
+	date, err := time.ParseInLocation(DateFormat, value, time.UTC)
+	if err != nil {
+		return time.Time{}, errors.WithMessagef(ErrInvalidParameter, "invalid date: %s, expected format: %s, error: %s", value, DateFormat, err)
+	}
+
+	return date, nil
+}
+
+// ParseUint64QueryParam parses the uint64 query parameter with the given name. It returns 0 if the parameter is not given.
+func ParseUint64QueryParam(c echo.Context, paramName string) (uint64, error) {
+	value := c.QueryParam(paramName)
+	if value == "" {
+		return 0, nil
+	}
+
+	result, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, errors.WithMessagef(ErrInvalidParameter, "invalid value for query parameter \"%s\": %s, error: %s", paramName, value, err)
+	}
+
+	return result, nil
+}
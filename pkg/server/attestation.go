@@ -0,0 +1,63 @@
+package server
+
+import (
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/inx-api-core-v1/pkg/attestation"
+	"github.com/iotaledger/inx-api-core-v1/pkg/database"
+	"github.com/iotaledger/inx-api-core-v1/pkg/restapi"
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+func (s *DatabaseServer) attestationBundle(c echo.Context) (*attestation.Bundle, error) {
+	if err := s.checkIndexReady(database.IndexStorePrefixLedgerCommitments); err != nil {
+		return nil, err
+	}
+
+	request := &attestationRequest{}
+	if err := c.Bind(request); err != nil {
+		return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid request, error: %s", err)
+	}
+
+	if len(request.Addresses) == 0 {
+		return nil, errors.WithMessage(restapi.ErrInvalidParameter, "no addresses given")
+	}
+
+	if len(request.Addresses) > s.RestAPILimitsMaxResults {
+		return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "too many addresses, max: %d", s.RestAPILimitsMaxResults)
+	}
+
+	addresses := make([]iotago.Address, 0, len(request.Addresses))
+	seenAddresses := make(map[string]struct{}, len(request.Addresses))
+	for _, bech32Address := range request.Addresses {
+		hrp, address, err := iotago.ParseBech32(strings.ToLower(bech32Address))
+		if err != nil {
+			return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid address: %s, error: %s", bech32Address, err)
+		}
+
+		if hrp != s.Bech32HRP {
+			return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid bech32 address, expected prefix: %s", s.Bech32HRP)
+		}
+
+		if _, exists := seenAddresses[address.String()]; exists {
+			continue
+		}
+		seenAddresses[address.String()] = struct{}{}
+
+		addresses = append(addresses, address)
+	}
+
+	bundle, err := s.Database.AttestationBundle(addresses, s.attestationMaxOutputs)
+	if err != nil {
+		if errors.Is(err, database.ErrAttestationTooManyOutputs) {
+			return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "%s", err)
+		}
+
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "building attestation bundle failed, error: %s", err)
+	}
+
+	return bundle, nil
+}
@@ -0,0 +1,91 @@
+package server
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/inx-api-core-v1/pkg/database"
+	"github.com/iotaledger/inx-api-core-v1/pkg/restapi"
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+func (s *DatabaseServer) clusterByAddress(_ echo.Context, address iotago.Address) (*addressClusterResponse, error) {
+	if err := s.checkIndexReady(database.IndexStorePrefixAddressClusters); err != nil {
+		return nil, err
+	}
+
+	cluster, err := s.Database.AddressClusterByAddress(address)
+	if err != nil {
+		if errors.Is(err, database.ErrAddressClusterNotFound) {
+			return nil, errors.WithMessagef(echo.ErrNotFound, "address is not part of a cluster: %s", address)
+		}
+
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "reading address cluster failed: %s, error: %s", address, err)
+	}
+
+	return &addressClusterResponse{
+		AddressType: address.Type(),
+		Address:     address.String(),
+		ClusterID:   cluster.ClusterID,
+		MemberCount: cluster.MemberCount,
+		Balance:     cluster.Balance,
+		Label:       s.addressLabel(address),
+		LedgerIndex: s.UTXOManager.ReadLedgerIndex(),
+	}, nil
+}
+
+func (s *DatabaseServer) clusterByID(c echo.Context) (*clusterResponse, error) {
+	if err := s.checkIndexReady(database.IndexStorePrefixAddressClusters); err != nil {
+		return nil, err
+	}
+
+	clusterID, err := restapi.ParseClusterIDParam(c)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, offset, err := s.limitAndOffsetFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	cluster, err := s.Database.AddressClusterByID(clusterID)
+	if err != nil {
+		if errors.Is(err, database.ErrAddressClusterNotFound) {
+			return nil, errors.WithMessagef(echo.ErrNotFound, "cluster not found: %d", clusterID)
+		}
+
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "reading cluster failed: %d, error: %s", clusterID, err)
+	}
+
+	addresses, err := s.Database.AddressClusterMembers(clusterID, offset, limit)
+	if err != nil {
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "reading cluster members failed: %d, error: %s", clusterID, err)
+	}
+
+	members := make([]*clusterMember, len(addresses))
+	for i, address := range addresses {
+		balance, _, _, err := s.UTXOManager.AddressBalance(address)
+		if err != nil {
+			return nil, errors.WithMessagef(echo.ErrInternalServerError, "reading address balance failed: %s, error: %s", address, err)
+		}
+
+		members[i] = &clusterMember{
+			AddressType: address.Type(),
+			Address:     address.String(),
+			Balance:     balance,
+			Label:       s.addressLabel(address),
+		}
+	}
+
+	return &clusterResponse{
+		ClusterID:   cluster.ClusterID,
+		MemberCount: cluster.MemberCount,
+		Balance:     cluster.Balance,
+		Offset:      uint32(offset),
+		Limit:       uint32(limit),
+		Count:       uint32(len(members)),
+		Members:     members,
+		LedgerIndex: s.UTXOManager.ReadLedgerIndex(),
+	}, nil
+}
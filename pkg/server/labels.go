@@ -0,0 +1,66 @@
+package server
+
+import (
+	"github.com/labstack/echo/v4"
+
+	"github.com/iotaledger/inx-api-core-v1/pkg/labels"
+	"github.com/iotaledger/inx-api-core-v1/pkg/restapi"
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+func newAddressLabel(label *labels.Label) *addressLabel {
+	if label == nil {
+		return nil
+	}
+
+	return &addressLabel{
+		Name:     label.Name,
+		Category: label.Category,
+		Note:     label.Note,
+	}
+}
+
+// addressLabel returns the label of the given address or nil if the address is not labeled.
+func (s *DatabaseServer) addressLabel(address iotago.Address) *addressLabel {
+	return newAddressLabel(s.LabelManager.Label(address))
+}
+
+func (s *DatabaseServer) counterparties(addresses []iotago.Address) []*counterparty {
+	counterparties := make([]*counterparty, len(addresses))
+	for i, address := range addresses {
+		counterparties[i] = &counterparty{
+			AddressType: address.Type(),
+			Address:     address.String(),
+			Label:       s.addressLabel(address),
+		}
+	}
+
+	return counterparties
+}
+
+func (s *DatabaseServer) searchLabels(c echo.Context) (*labelsResponse, error) {
+	query := c.QueryParam(restapi.QueryParameterLabelQuery)
+	maxResults := s.maxResultsFromContext(c)
+
+	foundLabels := s.LabelManager.Search(query, maxResults)
+
+	labeledAddresses := make([]*labeledAddress, len(foundLabels))
+	for i, label := range foundLabels {
+		labeledAddresses[i] = &labeledAddress{
+			AddressType:   label.Address.Type(),
+			Address:       label.Address.String(),
+			Bech32Address: label.Address.Bech32(s.Bech32HRP),
+			Name:          label.Name,
+			Category:      label.Category,
+			Note:          label.Note,
+			Source:        label.Source,
+		}
+	}
+
+	return &labelsResponse{
+		Query:      query,
+		MaxResults: uint32(maxResults),
+		Count:      uint32(len(labeledAddresses)),
+		Labels:     labeledAddresses,
+	}, nil
+}
@@ -0,0 +1,256 @@
+package server
+
+import (
+	"encoding/hex"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/inx-api-core-v1/pkg/database"
+	"github.com/iotaledger/inx-api-core-v1/pkg/restapi"
+	"github.com/iotaledger/inx-api-core-v1/pkg/utxo"
+	"github.com/iotaledger/inx-app/pkg/httpserver"
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+func (s *DatabaseServer) richList(c echo.Context) (*richListResponse, error) {
+	if err := s.checkIndexReady(database.IndexStorePrefixRichList); err != nil {
+		return nil, err
+	}
+
+	limit, offset, err := s.limitAndOffsetFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.Database.RichList(offset, limit)
+	if err != nil {
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "reading rich list failed, error: %s", err)
+	}
+
+	addresses := make([]*richListEntry, len(entries))
+	for i, entry := range entries {
+		addresses[i] = &richListEntry{
+			Rank:                 entry.Rank,
+			AddressType:          entry.Address.Type(),
+			Address:              entry.Address.String(),
+			Balance:              entry.Balance,
+			ShareOfTotalSupply:   float64(entry.Balance) / float64(iotago.TokenSupply),
+			DustAllowanceBalance: entry.DustAllowanceBalance,
+			DustOutputCount:      entry.DustOutputCount,
+			DustAllowed:          utxo.MaxDustOutputs(entry.DustAllowanceBalance) > entry.DustOutputCount,
+			Label:                s.addressLabel(entry.Address),
+		}
+	}
+
+	return &richListResponse{
+		TotalSupply:  iotago.TokenSupply,
+		AddressCount: s.Database.RichListSummary().AddressCount,
+		Offset:       uint32(offset),
+		Limit:        uint32(limit),
+		Count:        uint32(len(addresses)),
+		Addresses:    addresses,
+		LedgerIndex:  s.UTXOManager.ReadLedgerIndex(),
+	}, nil
+}
+
+func (s *DatabaseServer) ledgerStats(_ echo.Context) (*ledgerStatsResponse, error) {
+	// the statistics are only computed by the background worker, requests don't wait for the computation
+	stats := s.LedgerStats.CachedStats()
+	if stats == nil {
+		return nil, errors.WithMessage(echo.ErrServiceUnavailable, "ledger statistics are not computed yet, try again later")
+	}
+
+	histogram := make([]*balanceHistogramBucket, len(stats.BalanceHistogram))
+	for i, bucket := range stats.BalanceHistogram {
+		var upperBound *uint64
+		if bucket.UpperBound != 0 {
+			bound := bucket.UpperBound
+			upperBound = &bound
+		}
+
+		histogram[i] = &balanceHistogramBucket{
+			LowerBound:   bucket.LowerBound,
+			UpperBound:   upperBound,
+			AddressCount: bucket.AddressCount,
+			TotalBalance: bucket.TotalBalance,
+		}
+	}
+
+	return &ledgerStatsResponse{
+		TotalSupply:               stats.TotalSupply,
+		SupplyInOutputs:           stats.SupplyInOutputs,
+		TreasuryAmount:            stats.TreasuryAmount,
+		AddressesWithBalanceCount: stats.AddressesWithBalanceCount,
+		UnspentOutputCount:        stats.UnspentOutputCount,
+		DustOutputCount:           stats.DustOutputCount,
+		DustAllowanceOutputCount:  stats.DustAllowanceOutputCount,
+		BalanceHistogram:          histogram,
+		LedgerIndex:               stats.LedgerIndex,
+	}, nil
+}
+
+func (s *DatabaseServer) dormancy(_ echo.Context) (*dormancyResponse, error) {
+	if err := s.checkIndexReady(database.IndexStorePrefixDormancy); err != nil {
+		return nil, err
+	}
+
+	summary := s.Database.DormancySummary()
+
+	buckets := make([]*dormancyBucket, len(summary.Buckets))
+	for i, bucket := range summary.Buckets {
+		var maxAgeDays *int
+		if bucket.MaxAgeDays != 0 {
+			bound := bucket.MaxAgeDays
+			maxAgeDays = &bound
+		}
+
+		buckets[i] = &dormancyBucket{
+			MinAgeDays:          bucket.MinAgeDays,
+			MaxAgeDays:          maxAgeDays,
+			OutputCount:         bucket.OutputCount,
+			Amount:              bucket.Amount,
+			ShareOfTotalSupply:  float64(bucket.Amount) / float64(iotago.TokenSupply),
+			MigratedOutputCount: bucket.MigratedOutputCount,
+			MigratedAmount:      bucket.MigratedAmount,
+		}
+	}
+
+	return &dormancyResponse{
+		ReferenceTimestamp: summary.ReferenceTimestamp.Unix(),
+		PruningIndex:       summary.PruningIndex,
+		OutputCount:        summary.OutputCount,
+		Amount:             summary.Amount,
+		MigratedAmount:     summary.MigratedAmount,
+		Buckets:            buckets,
+		LedgerIndex:        summary.LedgerIndex,
+	}, nil
+}
+
+func (s *DatabaseServer) dormantOutputs(c echo.Context) (*dormantOutputsResponse, error) {
+	if err := s.checkIndexReady(database.IndexStorePrefixDormancy); err != nil {
+		return nil, err
+	}
+
+	if len(c.QueryParam(restapi.QueryParameterMinAgeDays)) == 0 {
+		return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "parameter \"%s\" not specified", restapi.QueryParameterMinAgeDays)
+	}
+
+	minAgeDays, err := httpserver.ParseUint32QueryParam(c, restapi.QueryParameterMinAgeDays, database.DormancyMaxAgeDays)
+	if err != nil {
+		return nil, err
+	}
+
+	minAmount, err := restapi.ParseUint64QueryParam(c, restapi.QueryParameterMinAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, offset, err := s.limitAndOffsetFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	// the scan over the dormancy index is limited by the milestone range worker pool
+	var entries []*database.DormantOutput
+	var innerErr error
+	if err := s.milestoneRangeWorkerPool.Run(c.Request().Context(), func() {
+		entries, innerErr = s.Database.DormantOutputs(c.Request().Context(), int(minAgeDays), minAmount, offset, limit)
+	}); err != nil {
+		return nil, err
+	}
+	if innerErr != nil {
+		if errors.Is(innerErr, database.ErrOperationAborted) {
+			return nil, errors.WithMessagef(echo.ErrServiceUnavailable, "reading dormant outputs aborted, error: %s", innerErr)
+		}
+
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "reading dormant outputs failed, error: %s", innerErr)
+	}
+
+	pruningIndex := s.Database.DormancySummary().PruningIndex
+
+	outputs := make([]*dormantOutput, len(entries))
+	for i, entry := range entries {
+		output, err := s.UTXOManager.ReadOutputByOutputID(entry.OutputID)
+		if err != nil {
+			return nil, errors.WithMessagef(echo.ErrInternalServerError, "reading output failed: %s, error: %s", entry.OutputID.ToHex(), err)
+		}
+
+		var createdAtTimestamp *int64
+		if entry.CreatedAtMilestoneIndex > pruningIndex {
+			timestamp, err := s.Database.MilestoneTimestampUnixByIndex(entry.CreatedAtMilestoneIndex)
+			if err != nil {
+				return nil, errors.WithMessagef(echo.ErrInternalServerError, "can't load milestone timestamp for index: %d, error: %s", entry.CreatedAtMilestoneIndex, err)
+			}
+			createdAtTimestamp = &timestamp
+		}
+
+		outputs[i] = &dormantOutput{
+			OutputID:                    entry.OutputID.ToHex(),
+			AddressType:                 output.Address().Type(),
+			Address:                     output.Address().String(),
+			Amount:                      entry.Amount,
+			CreatedAtMilestoneIndex:     entry.CreatedAtMilestoneIndex,
+			CreatedAtMilestoneTimestamp: createdAtTimestamp,
+			Migrated:                    entry.Migrated,
+			Label:                       s.addressLabel(output.Address()),
+		}
+	}
+
+	return &dormantOutputsResponse{
+		MinAgeDays:  minAgeDays,
+		MinAmount:   minAmount,
+		Offset:      uint32(offset),
+		Limit:       uint32(limit),
+		Count:       uint32(len(outputs)),
+		Outputs:     outputs,
+		LedgerIndex: s.Database.DormancySummary().LedgerIndex,
+	}, nil
+}
+
+func (s *DatabaseServer) ledgerCommitment(c echo.Context) (*ledgerCommitmentResponse, error) {
+	if err := s.checkIndexReady(database.IndexStorePrefixLedgerCommitments); err != nil {
+		return nil, err
+	}
+
+	ledgerCommitment, err := s.Database.LedgerCommitment()
+	if err != nil {
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "reading ledger commitment failed, error: %s", err)
+	}
+
+	msIndex := ledgerCommitment.LedgerIndex
+	if len(c.QueryParam(restapi.ParameterMilestoneIndex)) > 0 {
+		msIndex, err = restapi.ParseMilestoneIndexQueryParam(c, restapi.ParameterMilestoneIndex)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	msCommitment, err := s.Database.MilestoneCommitment(msIndex)
+	if err != nil {
+		if errors.Is(err, database.ErrMilestoneCommitmentNotFound) {
+			return nil, errors.WithMessagef(echo.ErrNotFound, "milestone commitment not found: %d", msIndex)
+		}
+		if errors.Is(err, database.ErrMilestoneCommitmentBelowAnchor) {
+			return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "milestone %d is below the commitment anchor milestone %d", msIndex, s.Database.CommitmentAnchorIndex())
+		}
+
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "reading milestone commitment failed: %d, error: %s", msIndex, err)
+	}
+
+	return &ledgerCommitmentResponse{
+		Commitment:         hex.EncodeToString(ledgerCommitment.Commitment[:]),
+		UnspentOutputCount: ledgerCommitment.UnspentOutputCount,
+		Treasury: &treasuryResponse{
+			MilestoneID: hex.EncodeToString(ledgerCommitment.Treasury.MilestoneID[:]),
+			Amount:      ledgerCommitment.Treasury.Amount,
+		},
+		MilestoneCommitment: &milestoneCommitment{
+			MilestoneIndex: msCommitment.MilestoneIndex,
+			DiffHash:       hex.EncodeToString(msCommitment.DiffHash[:]),
+			Commitment:     hex.EncodeToString(msCommitment.Commitment[:]),
+			AnchorIndex:    msCommitment.AnchorIndex,
+		},
+		LedgerIndex: ledgerCommitment.LedgerIndex,
+	}, nil
+}
@@ -0,0 +1,374 @@
+package server
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/inx-api-core-v1/pkg/milestone"
+	"github.com/iotaledger/inx-api-core-v1/pkg/restapi"
+	"github.com/iotaledger/inx-api-core-v1/pkg/utxo"
+	"github.com/iotaledger/inx-app/pkg/httpserver"
+)
+
+const (
+	// the amount of streamed items after which the response is flushed.
+	ledgerDiffStreamFlushInterval = 1000
+)
+
+// ledgerDiffRange is the key of a ledger diff in the cache.
+type ledgerDiffRange struct {
+	from milestone.Index
+	to   milestone.Index
+}
+
+// ledgerDiffRangeFromContext returns the milestone range of a ledger diff request.
+// Dates and timestamps refer to the ledger state at that time, so both are mapped to the last milestone at or before the given time.
+func (s *DatabaseServer) ledgerDiffRangeFromContext(c echo.Context) (*ledgerDiffRange, error) {
+	syncState := s.Database.LatestSyncState()
+
+	from, fromGiven, err := s.milestoneIndexFromRangeQueryParam(c, restapi.QueryParameterFrom, true)
+	if err != nil {
+		return nil, err
+	}
+	if !fromGiven {
+		return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "parameter \"%s\" not specified", restapi.QueryParameterFrom)
+	}
+
+	to, toGiven, err := s.milestoneIndexFromRangeQueryParam(c, restapi.QueryParameterTo, true)
+	if err != nil {
+		return nil, err
+	}
+	if !toGiven || to > syncState.ConfirmedMilestoneIndex {
+		to = syncState.ConfirmedMilestoneIndex
+	}
+
+	if from < syncState.PruningIndex {
+		// the milestone diffs are only available after the pruning index
+		return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid milestone range %d-%d, the ledger state is only available since milestone %d", from, to, syncState.PruningIndex)
+	}
+
+	if from > to {
+		return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid milestone range %d-%d", from, to)
+	}
+
+	if s.ledgerDiffMaxMilestones > 0 && int(to-from) > s.ledgerDiffMaxMilestones {
+		return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "milestone range %d-%d exceeds the maximum of %d milestones", from, to, s.ledgerDiffMaxMilestones)
+	}
+
+	return &ledgerDiffRange{from: from, to: to}, nil
+}
+
+// ledgerDiffResult is the result of a ledger diff computation that is shared by concurrent requests.
+type ledgerDiffResult struct {
+	ledgerDiff *utxo.LedgerDiff
+	// whether the ledger diff is contained in the cache
+	cached bool
+}
+
+// ledgerDiff returns the composed ledger diff of the milestone range and whether it is contained in the cache.
+// Ledger diffs that are heavier than the capacity of the cache are never cached.
+func (s *DatabaseServer) ledgerDiff(c echo.Context, diffRange *ledgerDiffRange) (*utxo.LedgerDiff, bool, error) {
+	// check if the entry already exists in the cache
+	if ledgerDiff, exists := s.ledgerDiffCache.Get(*diffRange); exists {
+		return ledgerDiff, true, nil
+	}
+
+	// concurrent requests for the same range wait for the result of the first request.
+	// the computation runs with the context of the server, so that it is not aborted if the first request is canceled.
+	resultChan := s.ledgerDiffGroup.DoChan(fmt.Sprintf("%d-%d", diffRange.from, diffRange.to), func() (interface{}, error) {
+		// check the cache again, the result could have been added while we were waiting
+		if ledgerDiff, exists := s.ledgerDiffCache.Get(*diffRange); exists {
+			return &ledgerDiffResult{ledgerDiff: ledgerDiff, cached: true}, nil
+		}
+
+		var ledgerDiff *utxo.LedgerDiff
+		var innerErr error
+		if err := s.milestoneRangeWorkerPool.Run(s.ctx, func() {
+			ledgerDiff, innerErr = s.UTXOManager.LedgerDiff(diffRange.from, diffRange.to)
+		}); err != nil {
+			return nil, err
+		}
+		if innerErr != nil {
+			if errors.Is(innerErr, kvstore.ErrKeyNotFound) {
+				return nil, errors.WithMessagef(echo.ErrNotFound, "can't load milestone diffs for range: %d-%d, error: %s", diffRange.from, diffRange.to, innerErr)
+			}
+
+			return nil, errors.WithMessagef(echo.ErrInternalServerError, "can't load milestone diffs for range: %d-%d, error: %s", diffRange.from, diffRange.to, innerErr)
+		}
+
+		// add the result in the cache, because it will never change.
+		return &ledgerDiffResult{ledgerDiff: ledgerDiff, cached: s.ledgerDiffCache.Add(*diffRange, ledgerDiff)}, nil
+	})
+
+	select {
+	case result := <-resultChan:
+		if result.Err != nil {
+			return nil, false, result.Err
+		}
+
+		//nolint:forcetypeassert // we only return *ledgerDiffResult
+		ledgerDiffResult := result.Val.(*ledgerDiffResult)
+
+		return ledgerDiffResult.ledgerDiff, ledgerDiffResult.cached, nil
+
+	case <-c.Request().Context().Done():
+		return nil, false, errors.WithMessagef(echo.ErrServiceUnavailable, "request aborted while waiting for the ledger diff: %s", c.Request().Context().Err())
+	}
+}
+
+func newLedgerDiffCreatedOutput(output *utxo.Output) *ledgerDiffOutput {
+	return &ledgerDiffOutput{
+		OutputID:    output.OutputID().ToHex(),
+		AddressType: output.Address().Type(),
+		Address:     output.Address().String(),
+		Amount:      output.Amount(),
+	}
+}
+
+func newLedgerDiffConsumedOutput(spent *utxo.Spent) *ledgerDiffOutput {
+	transactionID := spent.TargetTransactionID()
+	msIndexSpent := spent.ConfirmationIndex()
+
+	return &ledgerDiffOutput{
+		OutputID:            spent.OutputID().ToHex(),
+		AddressType:         spent.Address().Type(),
+		Address:             spent.Address().String(),
+		Amount:              spent.Amount(),
+		TransactionIDSpent:  hex.EncodeToString(transactionID[:]),
+		MilestoneIndexSpent: &msIndexSpent,
+	}
+}
+
+func (s *DatabaseServer) newLedgerDiffBalanceChange(balanceChange *utxo.AddressBalanceChange) *ledgerDiffBalanceChange {
+	return &ledgerDiffBalanceChange{
+		AddressType: balanceChange.Address.Type(),
+		Address:     balanceChange.Address.String(),
+		Change:      balanceChange.Change,
+		Label:       s.addressLabel(balanceChange.Address),
+	}
+}
+
+// newLedgerDiffResponse returns the response with the summary of the ledger diff, without the created and consumed outputs and the balance changes.
+func newLedgerDiffResponse(ledgerDiff *utxo.LedgerDiff) *ledgerDiffResponse {
+	resp := &ledgerDiffResponse{
+		From:                ledgerDiff.From,
+		To:                  ledgerDiff.To,
+		CreatedCount:        uint32(len(ledgerDiff.Created)),
+		ConsumedCount:       uint32(len(ledgerDiff.Consumed)),
+		BalanceChangesCount: uint32(len(ledgerDiff.BalanceChanges)),
+	}
+
+	for _, output := range ledgerDiff.Created {
+		resp.CreatedAmount += output.Amount()
+	}
+
+	for _, spent := range ledgerDiff.Consumed {
+		resp.ConsumedAmount += spent.Amount()
+	}
+
+	resp.Treasury = newLedgerDiffTreasury(ledgerDiff.TreasuryFrom, ledgerDiff.TreasuryTo)
+
+	return resp
+}
+
+// newLedgerDiffTreasury returns the movement of the treasury from the consumed to the created treasury output.
+// It returns nil if the treasury didn't change.
+func newLedgerDiffTreasury(treasuryFrom *utxo.TreasuryOutput, treasuryTo *utxo.TreasuryOutput) *ledgerDiffTreasury {
+	if treasuryFrom == nil && treasuryTo == nil {
+		return nil
+	}
+
+	treasury := &ledgerDiffTreasury{}
+
+	if treasuryFrom != nil {
+		treasury.FromMilestoneID = hex.EncodeToString(treasuryFrom.MilestoneID[:])
+		treasury.FromAmount = treasuryFrom.Amount
+		treasury.Change -= int64(treasuryFrom.Amount)
+	}
+
+	if treasuryTo != nil {
+		treasury.ToMilestoneID = hex.EncodeToString(treasuryTo.MilestoneID[:])
+		treasury.ToAmount = treasuryTo.Amount
+		treasury.Change += int64(treasuryTo.Amount)
+	}
+
+	return treasury
+}
+
+// pageBounds returns the bounds of the page of a list with the given length.
+func pageBounds(length int, offset int, limit int) (int, int) {
+	if offset > length {
+		offset = length
+	}
+
+	end := offset + limit
+	if end > length || end < offset {
+		end = length
+	}
+
+	return offset, end
+}
+
+// ledgerDiffNotPaginatableError returns the error for ledger diffs that don't fit into the cache and therefore can only be streamed.
+func ledgerDiffNotPaginatableError(diffRange *ledgerDiffRange) error {
+	return errors.WithMessagef(echo.ErrNotAcceptable, "ledger diff for range %d-%d is too large to be paginated, request it as newline delimited JSON (Accept: %s) or use a smaller range", diffRange.from, diffRange.to, MIMEApplicationNDJSON)
+}
+
+// checkLedgerDiffCacheable rejects ledger diffs that would not fit into the cache before they are computed.
+// The weight of the ledger diff is bounded by the sizes of the milestone diffs, which are known without loading the outputs.
+func (s *DatabaseServer) checkLedgerDiffCacheable(diffRange *ledgerDiffRange) error {
+	if _, exists := s.ledgerDiffCache.Get(*diffRange); exists {
+		return nil
+	}
+
+	weightBound, err := s.UTXOManager.LedgerDiffWeightBound(diffRange.from, diffRange.to, s.ledgerDiffCache.MaxWeight())
+	if err != nil {
+		if errors.Is(err, kvstore.ErrKeyNotFound) {
+			return errors.WithMessagef(echo.ErrNotFound, "can't load milestone diffs for range: %d-%d, error: %s", diffRange.from, diffRange.to, err)
+		}
+
+		return errors.WithMessagef(echo.ErrInternalServerError, "can't load milestone diffs for range: %d-%d, error: %s", diffRange.from, diffRange.to, err)
+	}
+
+	if weightBound > s.ledgerDiffCache.MaxWeight() {
+		return ledgerDiffNotPaginatableError(diffRange)
+	}
+
+	return nil
+}
+
+func (s *DatabaseServer) ledgerDiffPage(c echo.Context) (*ledgerDiffResponse, error) {
+	limit, offset, err := s.limitAndOffsetFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	diffRange, err := s.ledgerDiffRangeFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkLedgerDiffCacheable(diffRange); err != nil {
+		return nil, err
+	}
+
+	ledgerDiff, cached, err := s.ledgerDiff(c, diffRange)
+	if err != nil {
+		return nil, err
+	}
+
+	if !cached {
+		// the bound was not exceeded, but every page would compute the whole ledger diff again
+		return nil, ledgerDiffNotPaginatableError(diffRange)
+	}
+
+	resp := newLedgerDiffResponse(ledgerDiff)
+	resp.Offset = uint32(offset)
+	resp.Limit = uint32(limit)
+
+	start, end := pageBounds(len(ledgerDiff.Created), offset, limit)
+	resp.Created = make([]*ledgerDiffOutput, 0, end-start)
+	for _, output := range ledgerDiff.Created[start:end] {
+		resp.Created = append(resp.Created, newLedgerDiffCreatedOutput(output))
+	}
+
+	start, end = pageBounds(len(ledgerDiff.Consumed), offset, limit)
+	resp.Consumed = make([]*ledgerDiffOutput, 0, end-start)
+	for _, spent := range ledgerDiff.Consumed[start:end] {
+		resp.Consumed = append(resp.Consumed, newLedgerDiffConsumedOutput(spent))
+	}
+
+	start, end = pageBounds(len(ledgerDiff.BalanceChanges), offset, limit)
+	resp.BalanceChanges = make([]*ledgerDiffBalanceChange, 0, end-start)
+	for _, balanceChange := range ledgerDiff.BalanceChanges[start:end] {
+		resp.BalanceChanges = append(resp.BalanceChanges, s.newLedgerDiffBalanceChange(balanceChange))
+	}
+
+	return resp, nil
+}
+
+// streamLedgerDiff writes the complete ledger diff as newline delimited JSON.
+// The first line contains the summary, followed by the created outputs, the consumed outputs and the balance changes.
+func (s *DatabaseServer) streamLedgerDiff(c echo.Context) error {
+	diffRange, err := s.ledgerDiffRangeFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	ledgerDiff, _, err := s.ledgerDiff(c, diffRange)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set(echo.HeaderContentType, MIMEApplicationNDJSON)
+	c.Response().WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(c.Response())
+
+	var itemCounter int
+	writeItem := func(itemType string, data interface{}) error {
+		if err := encoder.Encode(&ledgerDiffStreamItem{Type: itemType, Data: data}); err != nil {
+			return err
+		}
+
+		itemCounter++
+		if itemCounter%ledgerDiffStreamFlushInterval == 0 {
+			c.Response().Flush()
+		}
+
+		return nil
+	}
+
+	if err := writeItem(ledgerDiffStreamItemSummary, newLedgerDiffResponse(ledgerDiff)); err != nil {
+		return err
+	}
+
+	for _, output := range ledgerDiff.Created {
+		if err := writeItem(ledgerDiffStreamItemCreated, newLedgerDiffCreatedOutput(output)); err != nil {
+			return err
+		}
+	}
+
+	for _, spent := range ledgerDiff.Consumed {
+		if err := writeItem(ledgerDiffStreamItemConsumed, newLedgerDiffConsumedOutput(spent)); err != nil {
+			return err
+		}
+	}
+
+	for _, balanceChange := range ledgerDiff.BalanceChanges {
+		if err := writeItem(ledgerDiffStreamItemBalanceChange, s.newLedgerDiffBalanceChange(balanceChange)); err != nil {
+			return err
+		}
+	}
+
+	c.Response().Flush()
+
+	return nil
+}
+
+func (s *DatabaseServer) ledgerDiffResponseByMimeType(c echo.Context) error {
+	mimeType, err := httpserver.GetAcceptHeaderContentType(c, MIMEApplicationNDJSON, echo.MIMEApplicationJSON)
+	if err != nil && !errors.Is(err, httpserver.ErrNotAcceptable) {
+		return err
+	}
+
+	switch mimeType {
+	case MIMEApplicationNDJSON:
+		return s.streamLedgerDiff(c)
+
+	default:
+		// default to echo.MIMEApplicationJSON
+		resp, err := s.ledgerDiffPage(c)
+		if err != nil {
+			return err
+		}
+
+		return restapi.JSONResponse(c, http.StatusOK, resp)
+	}
+}
@@ -1,8 +1,12 @@
 package server
 
 import (
+	"bytes"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/labstack/echo/v4"
 	"github.com/pkg/errors"
@@ -95,10 +99,65 @@ func (s *DatabaseServer) childrenIDsByMessageID(c echo.Context, messageID hornet
 	}, nil
 }
 
+func (s *DatabaseServer) messageIDsByIndexPrefix(c echo.Context) (*messageIDsByIndexPrefixResponse, error) {
+	limit, offset, err := s.limitAndOffsetFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	var prefix []byte
+	switch {
+	case len(c.QueryParam(restapi.QueryParameterIndexPrefix)) > 0:
+		prefix, err = hex.DecodeString(c.QueryParam(restapi.QueryParameterIndexPrefix))
+		if err != nil {
+			return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "query parameter %s invalid hex", restapi.QueryParameterIndexPrefix)
+		}
+
+	case len(c.QueryParam(restapi.QueryParameterIndexUTF8)) > 0:
+		prefix = []byte(c.QueryParam(restapi.QueryParameterIndexUTF8))
+
+	default:
+		return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "query parameter %s or %s empty", restapi.QueryParameterIndexPrefix, restapi.QueryParameterIndexUTF8)
+	}
+
+	if len(prefix) > database.IndexationIndexLength {
+		return nil, errors.WithMessage(restapi.ErrInvalidParameter, fmt.Sprintf("index prefix too long, max. %d bytes but is %d", database.IndexationIndexLength, len(prefix)))
+	}
+
+	groups, err := s.Database.IndexPrefixMessageIDs(prefix, offset, limit)
+	if err != nil {
+		return nil, errors.WithMessage(echo.ErrInternalServerError, err.Error())
+	}
+
+	var count int
+	indexes := make([]*indexMessageIDs, len(groups))
+	for i, group := range groups {
+		indexes[i] = &indexMessageIDs{
+			Index:      hex.EncodeToString(group.Index),
+			Count:      uint32(len(group.MessageIDs)),
+			MessageIDs: group.MessageIDs.ToHex(),
+		}
+
+		if utf8.Valid(group.Index) {
+			indexes[i].IndexUTF8 = string(group.Index)
+		}
+
+		count += len(group.MessageIDs)
+	}
+
+	return &messageIDsByIndexPrefixResponse{
+		IndexPrefix: hex.EncodeToString(prefix),
+		Offset:      uint32(offset),
+		Limit:       uint32(limit),
+		Count:       uint32(count),
+		Indexes:     indexes,
+	}, nil
+}
+
 func (s *DatabaseServer) messageIDsByIndex(c echo.Context) (*messageIDsByIndexResponse, error) {
 	maxResults := s.maxResultsFromContext(c)
 
-	index := c.QueryParam("index")
+	index := c.QueryParam(restapi.QueryParameterIndex)
 
 	if index == "" {
 		return nil, errors.WithMessage(restapi.ErrInvalidParameter, "query parameter index empty")
@@ -125,3 +184,148 @@ func (s *DatabaseServer) messageIDsByIndex(c echo.Context) (*messageIDsByIndexRe
 		MessageIDs: indexMessageIDs.ToHex(),
 	}, nil
 }
+
+// printableIndex returns the index as string if it is valid UTF-8 and only contains printable characters.
+func printableIndex(index []byte) (string, bool) {
+	if len(index) == 0 || !utf8.Valid(index) {
+		return "", false
+	}
+
+	for _, r := range string(index) {
+		if !unicode.IsPrint(r) {
+			return "", false
+		}
+	}
+
+	return string(index), true
+}
+
+func (s *DatabaseServer) indexationCatalog(c echo.Context) (*indexationCatalogResponse, error) {
+	if err := s.checkIndexReady(database.IndexStorePrefixIndexationCatalog); err != nil {
+		return nil, err
+	}
+
+	limit, offset, err := s.limitAndOffsetFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.Database.IndexationCatalog(offset, limit)
+	if err != nil {
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "reading indexation catalog failed: %s", err)
+	}
+
+	indexes := make([]*indexationCatalogEntry, len(entries))
+	for i, entry := range entries {
+		indexes[i] = &indexationCatalogEntry{
+			Index:                         hex.EncodeToString(entry.Index),
+			Encoding:                      indexEncodingHex,
+			IndexHex:                      hex.EncodeToString(entry.Index),
+			MessageCount:                  entry.MessageCount,
+			ReferencedMessageCount:        entry.ReferencedMessageCount,
+			FirstReferencedMilestoneIndex: entry.FirstReferencedMilestoneIndex,
+			LastReferencedMilestoneIndex:  entry.LastReferencedMilestoneIndex,
+		}
+
+		if index, printable := printableIndex(entry.Index); printable {
+			indexes[i].Index = index
+			indexes[i].Encoding = indexEncodingUTF8
+		}
+	}
+
+	return &indexationCatalogResponse{
+		Offset:  uint32(offset),
+		Limit:   uint32(limit),
+		Count:   uint32(len(indexes)),
+		Indexes: indexes,
+	}, nil
+}
+
+// indexationData is the data of an indexation payload with the sniffed content type.
+type indexationData struct {
+	index       []byte
+	contentType string
+	data        []byte
+}
+
+// sniffContentType detects whether the data is a JSON document, UTF-8 text or binary.
+func sniffContentType(data []byte) string {
+	switch {
+	case len(data) == 0:
+		return echo.MIMEOctetStream
+	case isJSONDocument(data):
+		return echo.MIMEApplicationJSON
+	case isText(data):
+		return echo.MIMETextPlainCharsetUTF8
+	default:
+		return echo.MIMEOctetStream
+	}
+}
+
+// isJSONDocument returns true if the data is a valid JSON object or array.
+// Bare JSON scalars like numbers or quoted strings are treated as text.
+func isJSONDocument(data []byte) bool {
+	trimmed := bytes.TrimLeft(data, " \t\r\n")
+	if len(trimmed) == 0 || (trimmed[0] != '{' && trimmed[0] != '[') {
+		return false
+	}
+
+	return json.Valid(data)
+}
+
+// isText returns true if the data is valid UTF-8 and doesn't contain control characters other than whitespace.
+func isText(data []byte) bool {
+	if !utf8.Valid(data) {
+		return false
+	}
+
+	for _, r := range string(data) {
+		if unicode.IsControl(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (s *DatabaseServer) indexationDataByMessageID(messageID hornet.MessageID) (*indexationData, error) {
+	msg := s.Database.MessageOrNil(messageID)
+	if msg == nil {
+		return nil, errors.WithMessagef(echo.ErrNotFound, "message not found: %s", messageID.ToHex())
+	}
+
+	indexation := msg.Indexation()
+	if indexation == nil {
+		return nil, errors.WithMessagef(echo.ErrNotFound, "message contains no indexation payload: %s", messageID.ToHex())
+	}
+
+	return &indexationData{
+		index:       indexation.Index,
+		contentType: sniffContentType(indexation.Data),
+		data:        indexation.Data,
+	}, nil
+}
+
+func newMessageDataResponse(messageID hornet.MessageID, data *indexationData) *messageDataResponse {
+	response := &messageDataResponse{
+		MessageID:   messageID.ToHex(),
+		Index:       hex.EncodeToString(data.index),
+		ContentType: data.contentType,
+		Size:        len(data.data),
+	}
+
+	if index, printable := printableIndex(data.index); printable {
+		response.IndexUTF8 = index
+	}
+
+	switch data.contentType {
+	case echo.MIMEApplicationJSON:
+		response.Data = json.RawMessage(data.data)
+	case echo.MIMETextPlainCharsetUTF8:
+		response.Data = string(data.data)
+	default:
+		response.Data = hex.EncodeToString(data.data)
+	}
+
+	return response
+}
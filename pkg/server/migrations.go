@@ -0,0 +1,87 @@
+package server
+
+import (
+	"encoding/hex"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/inx-api-core-v1/pkg/database"
+	"github.com/iotaledger/inx-api-core-v1/pkg/restapi"
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+func (s *DatabaseServer) newMigratedFundsResponse(migratedFunds *database.MigratedFunds) (*migratedFundsResponse, error) {
+	output, err := s.outputResponseByOutputID(&migratedFunds.OutputID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &migratedFundsResponse{
+		TailTransactionHash: hex.EncodeToString(migratedFunds.TailTransactionHash[:]),
+		AddressType:         migratedFunds.Address.Type(),
+		Address:             migratedFunds.Address.String(),
+		Deposit:             migratedFunds.Deposit,
+		MilestoneIndex:      migratedFunds.MilestoneIndex,
+		MigratedAt:          migratedFunds.MigratedAt,
+		OutputID:            migratedFunds.OutputID.ToHex(),
+		Output:              output,
+	}, nil
+}
+
+func (s *DatabaseServer) migrationByTailTransactionHash(c echo.Context) (*migratedFundsResponse, error) {
+	if err := s.checkIndexReady(database.IndexStorePrefixMigrations); err != nil {
+		return nil, err
+	}
+
+	tailTransactionHash, err := restapi.ParseTailTransactionHashParam(c)
+	if err != nil {
+		return nil, err
+	}
+
+	migratedFunds, err := s.Database.MigrationByTailTransactionHash(tailTransactionHash)
+	if err != nil {
+		if errors.Is(err, database.ErrMigrationNotFound) {
+			return nil, errors.WithMessagef(echo.ErrNotFound, "migration not found: %s", hex.EncodeToString(tailTransactionHash[:]))
+		}
+
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "reading migration failed: %s, error: %s", hex.EncodeToString(tailTransactionHash[:]), err)
+	}
+
+	return s.newMigratedFundsResponse(migratedFunds)
+}
+
+func (s *DatabaseServer) migrationsByAddress(c echo.Context, address iotago.Address) (*addressMigrationsResponse, error) {
+	if err := s.checkIndexReady(database.IndexStorePrefixMigrations); err != nil {
+		return nil, err
+	}
+
+	limit, offset, err := s.limitAndOffsetFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	migrations, err := s.Database.AddressMigrations(address, offset, limit)
+	if err != nil {
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "reading migrations failed: %s, error: %s", address, err)
+	}
+
+	migrationResponses := make([]*migratedFundsResponse, len(migrations))
+	for i, migratedFunds := range migrations {
+		migrationResponse, err := s.newMigratedFundsResponse(migratedFunds)
+		if err != nil {
+			return nil, err
+		}
+		migrationResponses[i] = migrationResponse
+	}
+
+	return &addressMigrationsResponse{
+		AddressType: address.Type(),
+		Address:     address.String(),
+		Offset:      uint32(offset),
+		Limit:       uint32(limit),
+		Count:       uint32(len(migrationResponses)),
+		Migrations:  migrationResponses,
+		LedgerIndex: s.UTXOManager.ReadLedgerIndex(),
+	}, nil
+}
@@ -1,12 +1,17 @@
 package server
 
 import (
+	"encoding/hex"
+	"sort"
+
 	"github.com/labstack/echo/v4"
 	"github.com/pkg/errors"
 
+	"github.com/iotaledger/inx-api-core-v1/pkg/database"
 	"github.com/iotaledger/inx-api-core-v1/pkg/restapi"
 
 	"github.com/iotaledger/hive.go/kvstore"
+	iotago "github.com/iotaledger/iota.go/v2"
 )
 
 func (s *DatabaseServer) milestoneByIndex(c echo.Context) (*milestoneResponse, error) {
@@ -61,3 +66,113 @@ func (s *DatabaseServer) milestoneUTXOChangesByIndex(c echo.Context) (*milestone
 		ConsumedOutputs: consumedOutputs,
 	}, nil
 }
+
+func (s *DatabaseServer) milestoneUTXOChangesExpandedByIndex(c echo.Context) (*milestoneUTXOChangesExpandedResponse, error) {
+
+	msIndex, err := restapi.ParseMilestoneIndexParam(c)
+	if err != nil {
+		return nil, err
+	}
+
+	diff, err := s.UTXOManager.MilestoneDiff(msIndex)
+	if err != nil {
+		if errors.Is(err, kvstore.ErrKeyNotFound) {
+			return nil, errors.WithMessagef(echo.ErrNotFound, "can't load milestone diff for index: %d, error: %s", msIndex, err)
+		}
+
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "can't load milestone diff for index: %d, error: %s", msIndex, err)
+	}
+
+	flowsMap := make(map[string]*addressFlow)
+	flowOfAddress := func(address iotago.Address) *addressFlow {
+		flow, exists := flowsMap[address.String()]
+		if !exists {
+			flow = &addressFlow{
+				AddressType: address.Type(),
+				Address:     address.String(),
+				Label:       s.addressLabel(address),
+			}
+			flowsMap[address.String()] = flow
+		}
+
+		return flow
+	}
+
+	createdOutputs := make([]*milestoneUTXOChange, len(diff.Outputs))
+	for i, output := range diff.Outputs {
+		createdOutputs[i] = &milestoneUTXOChange{
+			OutputID:    output.OutputID().ToHex(),
+			OutputType:  output.OutputType(),
+			AddressType: output.Address().Type(),
+			Address:     output.Address().String(),
+			Amount:      output.Amount(),
+			MessageID:   output.MessageID().ToHex(),
+			Label:       s.addressLabel(output.Address()),
+		}
+
+		flowOfAddress(output.Address()).Received += output.Amount()
+	}
+
+	consumedOutputs := make([]*milestoneUTXOChange, len(diff.Spents))
+	for i, spent := range diff.Spents {
+		consumedOutputs[i] = &milestoneUTXOChange{
+			OutputID:           spent.OutputID().ToHex(),
+			OutputType:         spent.OutputType(),
+			AddressType:        spent.Address().Type(),
+			Address:            spent.Address().String(),
+			Amount:             spent.Amount(),
+			MessageID:          spent.MessageID().ToHex(),
+			TransactionIDSpent: hex.EncodeToString(spent.TargetTransactionID()[:]),
+			Label:              s.addressLabel(spent.Address()),
+		}
+
+		flowOfAddress(spent.Address()).Sent += spent.Amount()
+	}
+
+	addressFlows := make([]*addressFlow, 0, len(flowsMap))
+	for _, flow := range flowsMap {
+		flow.Net = int64(flow.Received) - int64(flow.Sent)
+		addressFlows = append(addressFlows, flow)
+	}
+	sort.Slice(addressFlows, func(i, j int) bool {
+		return addressFlows[i].Address < addressFlows[j].Address
+	})
+
+	return &milestoneUTXOChangesExpandedResponse{
+		Index:           uint32(msIndex),
+		CreatedOutputs:  createdOutputs,
+		ConsumedOutputs: consumedOutputs,
+		AddressFlows:    addressFlows,
+		Treasury:        newLedgerDiffTreasury(diff.SpentTreasuryOutput, diff.TreasuryOutput),
+	}, nil
+}
+
+func (s *DatabaseServer) milestoneStatsByIndex(c echo.Context) (*milestoneStatsResponse, error) {
+
+	msIndex, err := restapi.ParseMilestoneIndexParam(c)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.Database.MilestoneStats(msIndex)
+	if err != nil {
+		if errors.Is(err, database.ErrMilestoneNotFound) || errors.Is(err, kvstore.ErrKeyNotFound) {
+			return nil, errors.WithMessagef(echo.ErrNotFound, "can't compute milestone stats for index: %d, error: %s", msIndex, err)
+		}
+
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "can't compute milestone stats for index: %d, error: %s", msIndex, err)
+	}
+
+	return &milestoneStatsResponse{
+		Index:                        uint32(stats.Index),
+		Time:                         stats.Timestamp,
+		TimeSincePreviousMilestone:   stats.TimeSincePreviousMilestone,
+		ReferencedMessagesCount:      stats.ReferencedMessagesCount,
+		IncludedTransactionsCount:    stats.IncludedTransactionsCount,
+		ConflictingTransactionsCount: stats.ConflictingTransactionsCount,
+		NoTransactionsCount:          stats.NoTransactionsCount,
+		TotalValueTransferred:        stats.TotalValueTransferred,
+		CreatedOutputsCount:          stats.CreatedOutputsCount,
+		ConsumedOutputsCount:         stats.ConsumedOutputsCount,
+	}, nil
+}
@@ -4,21 +4,119 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/pkg/errors"
 
+	"github.com/iotaledger/inx-api-core-v1/pkg/milestone"
 	"github.com/iotaledger/inx-api-core-v1/pkg/restapi"
 	"github.com/iotaledger/inx-api-core-v1/pkg/utxo"
 )
 
-func (s *DatabaseServer) receipts(_ echo.Context) (*receiptsResponse, error) {
+// receiptsFilter filters the receipts by the milestone that included them and by their final flag.
+type receiptsFilter struct {
+	fromMilestoneIndex milestone.Index
+	toMilestoneIndex   milestone.Index
+	final              *bool
+}
+
+func receiptsFilterFromContext(c echo.Context) (*receiptsFilter, error) {
+	filter := &receiptsFilter{
+		fromMilestoneIndex: 0,
+		toMilestoneIndex:   milestone.Index(^uint32(0)),
+		final:              nil,
+	}
+
+	if len(c.QueryParam(restapi.QueryParameterFrom)) > 0 {
+		fromMilestoneIndex, err := restapi.ParseMilestoneIndexQueryParam(c, restapi.QueryParameterFrom)
+		if err != nil {
+			return nil, err
+		}
+		filter.fromMilestoneIndex = fromMilestoneIndex
+	}
+
+	if len(c.QueryParam(restapi.QueryParameterTo)) > 0 {
+		toMilestoneIndex, err := restapi.ParseMilestoneIndexQueryParam(c, restapi.QueryParameterTo)
+		if err != nil {
+			return nil, err
+		}
+		filter.toMilestoneIndex = toMilestoneIndex
+	}
+
+	if filter.fromMilestoneIndex > filter.toMilestoneIndex {
+		return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid milestone range: %d-%d", filter.fromMilestoneIndex, filter.toMilestoneIndex)
+	}
+
+	if len(c.QueryParam(restapi.QueryParameterFinal)) > 0 {
+		final, err := restapi.ParseBoolQueryParam(c, restapi.QueryParameterFinal)
+		if err != nil {
+			return nil, err
+		}
+		filter.final = &final
+	}
+
+	return filter, nil
+}
+
+func (f *receiptsFilter) matches(rt *utxo.ReceiptTuple) bool {
+	if rt.MilestoneIndex < f.fromMilestoneIndex || rt.MilestoneIndex > f.toMilestoneIndex {
+		return false
+	}
+
+	return f.final == nil || rt.Receipt.Final == *f.final
+}
+
+// receiptsResponseFromContext collects the receipts that match the filter of the request, starting at the offset.
+// All matching receipts are counted, so that clients can detect that the result was limited.
+func (s *DatabaseServer) receiptsResponseFromContext(c echo.Context, forEachReceiptTuple func(consumer utxo.ReceiptTupleConsumer) error) (*receiptsResponse, error) {
+	limit, offset, err := s.limitAndOffsetFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := receiptsFilterFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	var total int
 	receipts := make([]*utxo.ReceiptTuple, 0)
-	if err := s.UTXOManager.ForEachReceiptTuple(func(rt *utxo.ReceiptTuple) bool {
+	if err := forEachReceiptTuple(func(rt *utxo.ReceiptTuple) bool {
+		if !filter.matches(rt) {
+			return true
+		}
+
+		total++
+		if total <= offset || len(receipts) >= limit {
+			return true
+		}
+
 		receipts = append(receipts, rt)
 
 		return true
 	}); err != nil {
+		return nil, err
+	}
+
+	return &receiptsResponse{
+		Receipts: receipts,
+		Offset:   uint32(offset),
+		Limit:    uint32(limit),
+		Count:    uint32(len(receipts)),
+		Total:    uint32(total),
+	}, nil
+}
+
+func (s *DatabaseServer) receipts(c echo.Context) (*receiptsResponse, error) {
+	resp, err := s.receiptsResponseFromContext(c, func(consumer utxo.ReceiptTupleConsumer) error {
+		return s.UTXOManager.ForEachReceiptTuple(consumer)
+	})
+	if err != nil {
+		var httpErr *echo.HTTPError
+		if errors.As(err, &httpErr) {
+			return nil, err
+		}
+
 		return nil, errors.WithMessagef(echo.ErrInternalServerError, "unable to retrieve receipts: %s", err)
 	}
 
-	return &receiptsResponse{Receipts: receipts}, nil
+	return resp, nil
 }
 
 func (s *DatabaseServer) receiptsByMigratedAtIndex(c echo.Context) (*receiptsResponse, error) {
@@ -27,14 +125,43 @@ func (s *DatabaseServer) receiptsByMigratedAtIndex(c echo.Context) (*receiptsRes
 		return nil, err
 	}
 
-	receipts := make([]*utxo.ReceiptTuple, 0)
-	if err := s.UTXOManager.ForEachReceiptTupleMigratedAt(migratedAt, func(rt *utxo.ReceiptTuple) bool {
-		receipts = append(receipts, rt)
+	resp, err := s.receiptsResponseFromContext(c, func(consumer utxo.ReceiptTupleConsumer) error {
+		return s.UTXOManager.ForEachReceiptTupleMigratedAt(migratedAt, consumer)
+	})
+	if err != nil {
+		var httpErr *echo.HTTPError
+		if errors.As(err, &httpErr) {
+			return nil, err
+		}
 
-		return true
-	}); err != nil {
 		return nil, errors.WithMessagef(echo.ErrInternalServerError, "unable to retrieve receipts for migrated at index %d: %s", migratedAt, err)
 	}
 
-	return &receiptsResponse{Receipts: receipts}, nil
+	return resp, nil
+}
+
+func (s *DatabaseServer) receiptsSummaryByMigratedAtIndex(c echo.Context) (*receiptsSummaryResponse, error) {
+	migratedAt, err := restapi.ParseMilestoneIndexParam(c)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := s.UTXOManager.ReceiptsSummaryMigratedAt(migratedAt)
+	if err != nil {
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "unable to summarize receipts for migrated at index %d: %s", migratedAt, err)
+	}
+
+	if summary == nil {
+		return nil, errors.WithMessagef(echo.ErrNotFound, "no receipts found for migrated at index %d", migratedAt)
+	}
+
+	return &receiptsSummaryResponse{
+		MigratedAt:              summary.MigratedAt,
+		ReceiptCount:            summary.ReceiptCount,
+		Final:                   summary.Final,
+		EntryCount:              summary.EntryCount,
+		TotalMigrated:           summary.TotalMigrated,
+		TreasuryDecrease:        summary.TreasuryDecrease,
+		MatchesTreasuryDecrease: summary.TotalMigrated == summary.TreasuryDecrease,
+	}, nil
 }
@@ -27,15 +27,36 @@ const (
 	// GET returns raw message data (bytes).
 	RouteMessageBytes = RouteMessageData + "/raw"
 
+	// RouteMessageIndexationData is the route for getting the data of the indexation payload of a message.
+	// GET returns the raw data with the detected content type (JSON, UTF-8 text or octet-stream)
+	// or the data embedded in a JSON envelope (optional query parameters: "envelope").
+	RouteMessageIndexationData = RouteMessageData + "/data"
+
 	// RouteMessageChildren is the route for getting message IDs of the children of a message, identified by its messageID.
 	// GET returns the message IDs of all children.
 	RouteMessageChildren = RouteMessageData + "/children"
 
 	// RouteMessages is the route for getting message IDs or creating new messages.
 	// GET with query parameter (mandatory) returns all message IDs that fit these filter criteria (query parameters: "index").
+	// GET with query parameter "indexPrefix" (hex) or "indexUtf8" returns the message IDs of all indexes with this prefix,
+	// grouped by the full index (optional query parameters: "limit", "offset").
 	// POST creates a single new message and returns the new message ID.
 	RouteMessages = "/messages"
 
+	// RouteIndexationCatalog is the route for getting all distinct indexation indexes.
+	// GET returns the indexes with their message counts and the range of milestones that referenced them (optional query parameters: "limit", "offset").
+	// The catalog is unavailable (503) while the index is built in the background.
+	RouteIndexationCatalog = "/indexation/catalog"
+
+	// RouteSearchData is the route for searching the UTF-8 text in the data of indexation payloads.
+	// GET returns the messages whose data contains all words of the query (query parameters: "q", optional: "limit", "offset").
+	RouteSearchData = "/search/data"
+
+	// RouteTransactionsLargest is the route for getting the highest-value transactions of a milestone range.
+	// GET returns the transactions sorted by their net value moved (optional query parameters: "from", "to" as milestone index,
+	// YYYY-MM-DD or RFC3339 timestamp, "limit", "offset"). Without a range, the transactions of the latest milestone are returned.
+	RouteTransactionsLargest = "/transactions/largest"
+
 	// RouteTransactionsIncludedMessageData is the route for getting the message that was included in the ledger for a given transaction ID.
 	// GET returns message data (json).
 	RouteTransactionsIncludedMessageData = "/transactions/:" + restapipkg.ParameterTransactionID + "/included-message"
@@ -58,8 +79,13 @@ const (
 
 	// RouteMilestoneUTXOChanges is the route for getting all UTXO changes of a milestone by its milestoneIndex.
 	// GET returns the output IDs of all UTXO changes.
+	// GET with query parameter "expanded=true" returns the details of all UTXO changes, the net flows per address and the treasury change.
 	RouteMilestoneUTXOChanges = RouteMilestone + "/utxo-changes"
 
+	// RouteMilestoneStats is the route for getting the statistics of a milestone by its milestoneIndex.
+	// GET returns the counts of the referenced messages, the transferred value and the counts of the UTXO changes.
+	RouteMilestoneStats = RouteMilestone + "/stats"
+
 	// RouteOutput is the route for getting outputs by their outputID (transactionHash + outputIndex).
 	// GET returns the output.
 	RouteOutput = "/outputs/:" + restapipkg.ParameterOutputID
@@ -94,14 +120,115 @@ const (
 	// GET returns the tx-history of this address.
 	RouteAddressEd25519History = "/addresses/ed25519/:" + restapipkg.ParameterAddress + "/tx-history"
 
+	// RouteAddressBech32DustAllowance is the route for getting the dust allowance details of an address.
+	// The address must be encoded in bech32.
+	// GET returns the dust allowance balance, the dust output counts and the dust allowance outputs of this address.
+	RouteAddressBech32DustAllowance = "/addresses/:" + restapipkg.ParameterAddress + "/dust"
+
+	// RouteAddressEd25519DustAllowance is the route for getting the dust allowance details of an ed25519 address.
+	// The ed25519 address must be encoded in hex.
+	// GET returns the dust allowance balance, the dust output counts and the dust allowance outputs of this address.
+	RouteAddressEd25519DustAllowance = "/addresses/ed25519/:" + restapipkg.ParameterAddress + "/dust"
+
+	// RouteAddressBech32PublicKey is the route for getting the public key of an address.
+	// The address must be encoded in bech32.
+	// GET returns the public keys revealed for this address and the first transaction that revealed them.
+	RouteAddressBech32PublicKey = "/addresses/:" + restapipkg.ParameterAddress + "/public-key"
+
+	// RouteAddressEd25519PublicKey is the route for getting the public key of an ed25519 address.
+	// The ed25519 address must be encoded in hex.
+	// GET returns the public keys revealed for this address and the first transaction that revealed them.
+	RouteAddressEd25519PublicKey = "/addresses/ed25519/:" + restapipkg.ParameterAddress + "/public-key"
+
+	// RouteAddressBech32Migrations is the route for getting the funds migrated from the legacy network to an address.
+	// The address must be encoded in bech32.
+	// GET returns the migrated funds and the resulting outputs (optional query parameters: "limit", "offset").
+	RouteAddressBech32Migrations = "/addresses/:" + restapipkg.ParameterAddress + "/migrations"
+
+	// RouteAddressEd25519Migrations is the route for getting the funds migrated from the legacy network to an ed25519 address.
+	// The ed25519 address must be encoded in hex.
+	// GET returns the migrated funds and the resulting outputs (optional query parameters: "limit", "offset").
+	RouteAddressEd25519Migrations = "/addresses/ed25519/:" + restapipkg.ParameterAddress + "/migrations"
+
+	// RouteAddressBech32Cluster is the route for getting the cluster of addresses that are likely owned by the same entity as an address.
+	// The address must be encoded in bech32.
+	// GET returns the cluster with its aggregate balance.
+	RouteAddressBech32Cluster = "/addresses/:" + restapipkg.ParameterAddress + "/cluster"
+
+	// RouteAddressEd25519Cluster is the route for getting the cluster of addresses that are likely owned by the same entity as an ed25519 address.
+	// The ed25519 address must be encoded in hex.
+	// GET returns the cluster with its aggregate balance.
+	RouteAddressEd25519Cluster = "/addresses/ed25519/:" + restapipkg.ParameterAddress + "/cluster"
+
+	// RouteCluster is the route for getting an address cluster by its ID.
+	// GET returns the cluster with its aggregate balance and its member addresses (optional query parameters: "limit", "offset").
+	RouteCluster = "/clusters/:" + restapipkg.ParameterClusterID
+
+	// RouteMigration is the route for getting the funds migrated by a legacy bundle.
+	// The tail transaction hash must be encoded in hex.
+	// GET returns the receipt milestone, the migrated at index and the resulting output.
+	RouteMigration = "/migrations/:" + restapipkg.ParameterTailTransactionHash
+
+	// RouteLabels is the route for searching the address labels.
+	// GET returns the labels whose address, name, category or note match the query (optional query parameters: "query").
+	RouteLabels = "/labels"
+
+	// RouteLedgerRichList is the route for getting the addresses with the highest balances.
+	// GET returns the addresses sorted by highest balance (optional query parameters: "limit", "offset").
+	RouteLedgerRichList = "/ledger/richlist"
+
+	// RouteLedgerStats is the route for getting the supply and distribution statistics of the ledger.
+	// GET returns the supply, the address and output counts and the balance distribution histogram.
+	RouteLedgerStats = "/ledger/stats"
+
+	// RouteLedgerDormancy is the route for getting the unspent supply bucketed by the age of the outputs.
+	// GET returns the amounts and output counts per age bucket, relative to the timestamp of the ledger milestone.
+	RouteLedgerDormancy = "/ledger/dormancy"
+
+	// RouteLedgerDormantOutputs is the route for getting the unspent outputs that are older than a threshold.
+	// GET returns the outputs sorted by age, oldest first (query parameters: "minAgeDays", optional: "minAmount", "limit", "offset").
+	RouteLedgerDormantOutputs = RouteLedgerDormancy + "/outputs"
+
+	// RouteLedgerDiff is the route for getting the net result of all milestone diffs between two ledger states.
+	// GET returns the created and consumed outputs, the net balance changes per address and the treasury movement
+	// (query parameters: "from", optional: "to" as milestone index, YYYY-MM-DD or RFC3339 timestamp, "limit", "offset").
+	// If the Accept header is "application/x-ndjson", the complete diff is streamed as newline delimited JSON.
+	// Diffs that may not fit into the cache can only be streamed, paginated requests for them are answered with 406.
+	RouteLedgerDiff = "/ledger/diff"
+
+	// RouteLedgerCommitment is the route for getting the deterministic commitments over the ledger state.
+	// GET returns the commitment over the unspent outputs and the treasury at the ledger index and the milestone commitment
+	// at the ledger index or at the given milestone (optional query parameter: "milestoneIndex").
+	RouteLedgerCommitment = "/ledger/commitment"
+
+	// RouteAttestations is the route for creating a balance attestation bundle for a set of addresses.
+	// POST returns a self-contained bundle with the unspent outputs of the addresses, the messages that created them,
+	// the confirming milestones and the inclusion audit paths (body: {"addresses": [bech32 addresses]}).
+	// The bundle can be checked offline with the "attestation-verify" tool.
+	RouteAttestations = "/attestations"
+
+	// RouteStatsDaily is the route for getting the aggregated network statistics per day (UTC).
+	// GET returns the daily statistics as JSON or CSV, depending on the Accept header (optional query parameters: "from", "to" as YYYY-MM-DD).
+	RouteStatsDaily = "/stats/daily"
+
 	// RouteTreasury is the route for getting the current treasury output.
 	RouteTreasury = "/treasury"
 
+	// RouteTreasuryHistory is the route for getting all spent and unspent treasury outputs.
+	// GET returns the treasury outputs with the milestones that created and consumed them and the associated receipts.
+	RouteTreasuryHistory = "/treasury/history"
+
 	// RouteReceipts is the route for getting all stored receipts.
+	// GET returns the receipts (optional query parameters: "from", "to", "final", "limit", "offset").
 	RouteReceipts = "/receipts"
 
 	// RouteReceiptsMigratedAtIndex is the route for getting all receipts for a given migrated at index.
+	// GET returns the receipts (optional query parameters: "from", "to", "final", "limit", "offset").
 	RouteReceiptsMigratedAtIndex = "/receipts/:" + restapipkg.ParameterMilestoneIndex
+
+	// RouteReceiptsMigratedAtIndexSummary is the route for getting the summary of all receipts for a given migrated at index.
+	// GET returns the total migrated tokens, the entry count and whether the sum matches the decrease of the treasury.
+	RouteReceiptsMigratedAtIndexSummary = "/receipts/:" + restapipkg.ParameterMilestoneIndex + "/summary"
 )
 
 func (s *DatabaseServer) configureRoutes(routeGroup echoswagger.ApiGroup) {
@@ -157,6 +284,29 @@ func (s *DatabaseServer) configureRoutes(routeGroup echoswagger.ApiGroup) {
 		return c.Blob(http.StatusOK, echo.MIMEOctetStream, resp)
 	})
 
+	routeGroup.GET(RouteMessageIndexationData, func(c echo.Context) error {
+		messageID, err := restapipkg.ParseMessageIDParam(c)
+		if err != nil {
+			return err
+		}
+
+		envelope, err := restapipkg.ParseBoolQueryParam(c, restapipkg.QueryParameterEnvelope)
+		if err != nil {
+			return err
+		}
+
+		data, err := s.indexationDataByMessageID(messageID)
+		if err != nil {
+			return err
+		}
+
+		if envelope {
+			return restapipkg.JSONResponse(c, http.StatusOK, newMessageDataResponse(messageID, data))
+		}
+
+		return c.Blob(http.StatusOK, data.contentType, data.data)
+	})
+
 	routeGroup.GET(RouteMessageChildren, func(c echo.Context) error {
 		messageID, err := restapipkg.ParseMessageIDParam(c)
 		if err != nil {
@@ -172,6 +322,15 @@ func (s *DatabaseServer) configureRoutes(routeGroup echoswagger.ApiGroup) {
 	})
 
 	routeGroup.GET(RouteMessages, func(c echo.Context) error {
+		if len(c.QueryParam(restapipkg.QueryParameterIndexPrefix)) > 0 || len(c.QueryParam(restapipkg.QueryParameterIndexUTF8)) > 0 {
+			resp, err := s.messageIDsByIndexPrefix(c)
+			if err != nil {
+				return err
+			}
+
+			return restapipkg.JSONResponse(c, http.StatusOK, resp)
+		}
+
 		resp, err := s.messageIDsByIndex(c)
 		if err != nil {
 			return err
@@ -180,6 +339,24 @@ func (s *DatabaseServer) configureRoutes(routeGroup echoswagger.ApiGroup) {
 		return restapipkg.JSONResponse(c, http.StatusOK, resp)
 	})
 
+	routeGroup.GET(RouteIndexationCatalog, func(c echo.Context) error {
+		resp, err := s.indexationCatalog(c)
+		if err != nil {
+			return err
+		}
+
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.GET(RouteSearchData, func(c echo.Context) error {
+		resp, err := s.searchData(c)
+		if err != nil {
+			return err
+		}
+
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
 	routeGroup.GET(RouteTransactionsIncludedMessageData, func(c echo.Context) error {
 		messageID, err := s.messageIDByTransactionID(c)
 		if err != nil {
@@ -246,6 +423,20 @@ func (s *DatabaseServer) configureRoutes(routeGroup echoswagger.ApiGroup) {
 	})
 
 	routeGroup.GET(RouteMilestoneUTXOChanges, func(c echo.Context) error {
+		expanded, err := restapipkg.ParseBoolQueryParam(c, restapipkg.QueryParameterExpanded)
+		if err != nil {
+			return err
+		}
+
+		if expanded {
+			resp, err := s.milestoneUTXOChangesExpandedByIndex(c)
+			if err != nil {
+				return err
+			}
+
+			return restapipkg.JSONResponse(c, http.StatusOK, resp)
+		}
+
 		resp, err := s.milestoneUTXOChangesByIndex(c)
 		if err != nil {
 			return err
@@ -254,6 +445,15 @@ func (s *DatabaseServer) configureRoutes(routeGroup echoswagger.ApiGroup) {
 		return restapipkg.JSONResponse(c, http.StatusOK, resp)
 	})
 
+	routeGroup.GET(RouteMilestoneStats, func(c echo.Context) error {
+		resp, err := s.milestoneStatsByIndex(c)
+		if err != nil {
+			return err
+		}
+
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
 	routeGroup.GET(RouteOutput, func(c echo.Context) error {
 		resp, err := s.outputByID(c)
 		if err != nil {
@@ -327,6 +527,203 @@ func (s *DatabaseServer) configureRoutes(routeGroup echoswagger.ApiGroup) {
 		return s.transactionHistoryResponseByAddressAndMimeType(c, address)
 	})
 
+	routeGroup.GET(RouteAddressBech32DustAllowance, func(c echo.Context) error {
+		address, err := restapipkg.ParseBech32AddressParam(c, s.Bech32HRP)
+		if err != nil {
+			return err
+		}
+
+		resp, err := s.dustAllowanceByAddress(c, address)
+		if err != nil {
+			return err
+		}
+
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.GET(RouteAddressEd25519DustAllowance, func(c echo.Context) error {
+		address, err := restapipkg.ParseEd25519AddressParam(c)
+		if err != nil {
+			return err
+		}
+
+		resp, err := s.dustAllowanceByAddress(c, address)
+		if err != nil {
+			return err
+		}
+
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.GET(RouteAddressBech32PublicKey, func(c echo.Context) error {
+		address, err := restapipkg.ParseBech32AddressParam(c, s.Bech32HRP)
+		if err != nil {
+			return err
+		}
+
+		resp, err := s.publicKeyByAddress(c, address)
+		if err != nil {
+			return err
+		}
+
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.GET(RouteAddressEd25519PublicKey, func(c echo.Context) error {
+		address, err := restapipkg.ParseEd25519AddressParam(c)
+		if err != nil {
+			return err
+		}
+
+		resp, err := s.publicKeyByAddress(c, address)
+		if err != nil {
+			return err
+		}
+
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.GET(RouteAddressBech32Migrations, func(c echo.Context) error {
+		address, err := restapipkg.ParseBech32AddressParam(c, s.Bech32HRP)
+		if err != nil {
+			return err
+		}
+
+		resp, err := s.migrationsByAddress(c, address)
+		if err != nil {
+			return err
+		}
+
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.GET(RouteAddressEd25519Migrations, func(c echo.Context) error {
+		address, err := restapipkg.ParseEd25519AddressParam(c)
+		if err != nil {
+			return err
+		}
+
+		resp, err := s.migrationsByAddress(c, address)
+		if err != nil {
+			return err
+		}
+
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.GET(RouteAddressBech32Cluster, func(c echo.Context) error {
+		address, err := restapipkg.ParseBech32AddressParam(c, s.Bech32HRP)
+		if err != nil {
+			return err
+		}
+
+		resp, err := s.clusterByAddress(c, address)
+		if err != nil {
+			return err
+		}
+
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.GET(RouteAddressEd25519Cluster, func(c echo.Context) error {
+		address, err := restapipkg.ParseEd25519AddressParam(c)
+		if err != nil {
+			return err
+		}
+
+		resp, err := s.clusterByAddress(c, address)
+		if err != nil {
+			return err
+		}
+
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.GET(RouteCluster, func(c echo.Context) error {
+		resp, err := s.clusterByID(c)
+		if err != nil {
+			return err
+		}
+
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.GET(RouteMigration, func(c echo.Context) error {
+		resp, err := s.migrationByTailTransactionHash(c)
+		if err != nil {
+			return err
+		}
+
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.GET(RouteLabels, func(c echo.Context) error {
+		resp, err := s.searchLabels(c)
+		if err != nil {
+			return err
+		}
+
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.GET(RouteLedgerRichList, func(c echo.Context) error {
+		resp, err := s.richList(c)
+		if err != nil {
+			return err
+		}
+
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.GET(RouteLedgerStats, func(c echo.Context) error {
+		resp, err := s.ledgerStats(c)
+		if err != nil {
+			return err
+		}
+
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.GET(RouteLedgerDormancy, func(c echo.Context) error {
+		resp, err := s.dormancy(c)
+		if err != nil {
+			return err
+		}
+
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.GET(RouteLedgerDormantOutputs, func(c echo.Context) error {
+		resp, err := s.dormantOutputs(c)
+		if err != nil {
+			return err
+		}
+
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.GET(RouteLedgerDiff, func(c echo.Context) error {
+		return s.ledgerDiffResponseByMimeType(c)
+	})
+
+	routeGroup.GET(RouteLedgerCommitment, func(c echo.Context) error {
+		resp, err := s.ledgerCommitment(c)
+		if err != nil {
+			return err
+		}
+
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.POST(RouteAttestations, func(c echo.Context) error {
+		resp, err := s.attestationBundle(c)
+		if err != nil {
+			return err
+		}
+
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
 	routeGroup.GET(RouteTreasury, func(c echo.Context) error {
 		resp, err := s.treasury(c)
 		if err != nil {
@@ -336,6 +733,28 @@ func (s *DatabaseServer) configureRoutes(routeGroup echoswagger.ApiGroup) {
 		return restapipkg.JSONResponse(c, http.StatusOK, resp)
 	})
 
+	routeGroup.GET(RouteTransactionsLargest, func(c echo.Context) error {
+		resp, err := s.largestTransactions(c)
+		if err != nil {
+			return err
+		}
+
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
+	routeGroup.GET(RouteStatsDaily, func(c echo.Context) error {
+		return s.dailyStatsResponseByMimeType(c)
+	})
+
+	routeGroup.GET(RouteTreasuryHistory, func(c echo.Context) error {
+		resp, err := s.treasuryHistory(c)
+		if err != nil {
+			return err
+		}
+
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
+
 	routeGroup.GET(RouteReceipts, func(c echo.Context) error {
 		resp, err := s.receipts(c)
 		if err != nil {
@@ -353,4 +772,13 @@ func (s *DatabaseServer) configureRoutes(routeGroup echoswagger.ApiGroup) {
 
 		return restapipkg.JSONResponse(c, http.StatusOK, resp)
 	})
+
+	routeGroup.GET(RouteReceiptsMigratedAtIndexSummary, func(c echo.Context) error {
+		resp, err := s.receiptsSummaryByMigratedAtIndex(c)
+		if err != nil {
+			return err
+		}
+
+		return restapipkg.JSONResponse(c, http.StatusOK, resp)
+	})
 }
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/inx-api-core-v1/pkg/database"
+	"github.com/iotaledger/inx-api-core-v1/pkg/restapi"
+)
+
+func (s *DatabaseServer) searchData(c echo.Context) (*dataSearchResponse, error) {
+	// the data search is not available at all if it is disabled by the configuration
+	if !s.Database.DataSearchEnabled() {
+		return nil, errors.WithMessage(echo.ErrNotFound, "data search is disabled")
+	}
+
+	if err := s.checkIndexReady(database.IndexStorePrefixDataSearch); err != nil {
+		return nil, err
+	}
+
+	query := c.QueryParam(restapi.QueryParameterSearchQuery)
+	if query == "" {
+		return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "query parameter %s empty", restapi.QueryParameterSearchQuery)
+	}
+
+	limit, offset, err := s.limitAndOffsetFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := s.Database.SearchData(query, offset, limit)
+	if err != nil {
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "searching data failed: %s", err)
+	}
+
+	messages := make([]*dataSearchResult, len(results))
+	for i, result := range results {
+		milestoneTimestamp, err := s.Database.MilestoneTimestampUnixByIndex(result.MilestoneIndex)
+		if err != nil {
+			return nil, errors.WithMessagef(echo.ErrInternalServerError, "reading milestone %d failed: %s", result.MilestoneIndex, err)
+		}
+
+		messages[i] = &dataSearchResult{
+			MessageID:                    result.MessageID.ToHex(),
+			ReferencedByMilestoneIndex:   result.MilestoneIndex,
+			MilestoneTimestampReferenced: milestoneTimestamp,
+		}
+	}
+
+	return &dataSearchResponse{
+		Query:    query,
+		Offset:   uint32(offset),
+		Limit:    uint32(limit),
+		Count:    uint32(len(messages)),
+		Messages: messages,
+	}, nil
+}
@@ -1,15 +1,19 @@
 package server
 
 import (
+	"context"
 	"math"
 
-	lru "github.com/hashicorp/golang-lru/v2"
 	"github.com/labstack/echo/v4"
 	"github.com/pangpanglabs/echoswagger/v2"
+	"github.com/pkg/errors"
+	"golang.org/x/sync/singleflight"
 
 	"github.com/iotaledger/hive.go/app"
-	"github.com/iotaledger/hive.go/lo"
+	"github.com/iotaledger/inx-api-core-v1/pkg/cache"
 	"github.com/iotaledger/inx-api-core-v1/pkg/database"
+	"github.com/iotaledger/inx-api-core-v1/pkg/labels"
+	"github.com/iotaledger/inx-api-core-v1/pkg/milestone"
 	restapipkg "github.com/iotaledger/inx-api-core-v1/pkg/restapi"
 	"github.com/iotaledger/inx-api-core-v1/pkg/utxo"
 	"github.com/iotaledger/inx-app/pkg/httpserver"
@@ -17,30 +21,105 @@ import (
 )
 
 const (
-	APIRoute    = "/api/core/v1"
-	MIMETextCSV = "text/csv"
+	APIRoute              = "/api/core/v1"
+	MIMETextCSV           = "text/csv"
+	MIMEApplicationNDJSON = "application/x-ndjson"
 )
 
 type DatabaseServer struct {
+	// the context of the server, shared computations of concurrent requests run with it
+	ctx                     context.Context
 	AppInfo                 *app.Info
 	Database                *database.Database
 	UTXOManager             *utxo.Manager
+	LabelManager            *labels.Manager
+	LedgerStats             *database.LedgerStatsCache
 	NetworkIDName           string
 	Bech32HRP               iotago.NetworkPrefix
 	RestAPILimitsMaxResults int
 
-	txHistoryCache *lru.TwoQueueCache[string, []*transactionHistoryItem]
+	// the cache is weighted by the amount of history items per address
+	txHistoryCache *cache.WeightedLRU[string, []*database.TransactionHistoryItem]
+	// concurrent requests for the same address are coalesced into a single computation
+	txHistoryGroup singleflight.Group
+	// the computations of different addresses are limited by a bounded worker pool
+	txHistoryWorkerPool *boundedWorkerPool
+
+	// the computations over milestone ranges are limited by a bounded worker pool
+	milestoneRangeWorkerPool *boundedWorkerPool
+
+	// the maximum amount of milestones a largest transactions request may cover
+	largestTransfersMaxMilestones int
+	// the cache contains the largest transfers of completed milestone range buckets and is weighted by the amount of transfers
+	largestTransfersCache *cache.WeightedLRU[milestone.Index, []*utxo.Transfer]
+	// concurrent requests for the same bucket are coalesced into a single computation
+	largestTransfersGroup singleflight.Group
+
+	// the maximum amount of milestones a ledger diff request may cover
+	ledgerDiffMaxMilestones int
+	// the cache is weighted by the amount of created and consumed outputs and balance changes per ledger diff
+	ledgerDiffCache *cache.WeightedLRU[ledgerDiffRange, *utxo.LedgerDiff]
+	// concurrent requests for the same range are coalesced into a single computation
+	ledgerDiffGroup singleflight.Group
+
+	// the maximum amount of unspent outputs an attestation bundle may contain
+	attestationMaxOutputs int
 }
 
-func NewDatabaseServer(swagger echoswagger.ApiRoot, appInfo *app.Info, db *database.Database, utxoManager *utxo.Manager, networkIDName string, bech32HRP iotago.NetworkPrefix, maxResults int, txHistoryCacheSize int) *DatabaseServer {
+// ServerLimits contains the limits and the cache sizes of the DatabaseServer.
+type ServerLimits struct {
+	// the maximum number of results that may be returned by an endpoint (0 for disabled)
+	MaxResults int
+	// the maximum number of transaction history items (summed over all addresses) in the LRU cache
+	TransactionHistoryCacheSize int
+	// the maximum number of transaction histories that are computed in parallel
+	TransactionHistoryWorkers int
+	// the maximum number of transaction history requests that wait for a free worker
+	TransactionHistoryQueueSize int
+	// the maximum number of computations over milestone ranges that run in parallel
+	MilestoneRangeWorkers int
+	// the maximum number of computations over milestone ranges that wait for a free worker
+	MilestoneRangeQueueSize int
+	// the maximum number of largest transactions (summed over all milestone range buckets) in the LRU cache
+	LargestTransactionsCacheSize int
+	// the maximum number of milestones that may be covered by a largest transactions request
+	LargestTransactionsMaxMilestones int
+	// the maximum number of ledger diff items (summed over all ranges) in the LRU cache
+	LedgerDiffCacheSize int
+	// the maximum number of milestones that may be covered by a ledger diff request
+	LedgerDiffMaxMilestones int
+	// the maximum number of unspent outputs that may be contained in an attestation bundle (0 for disabled)
+	AttestationMaxOutputs int
+}
+
+func NewDatabaseServer(ctx context.Context, swagger echoswagger.ApiRoot, appInfo *app.Info, db *database.Database, utxoManager *utxo.Manager, labelManager *labels.Manager, ledgerStats *database.LedgerStatsCache, networkIDName string, bech32HRP iotago.NetworkPrefix, limits *ServerLimits) *DatabaseServer {
 	s := &DatabaseServer{
+		ctx:                     ctx,
 		AppInfo:                 appInfo,
 		Database:                db,
 		UTXOManager:             utxoManager,
+		LabelManager:            labelManager,
+		LedgerStats:             ledgerStats,
 		NetworkIDName:           networkIDName,
 		Bech32HRP:               bech32HRP,
-		RestAPILimitsMaxResults: maxResults,
-		txHistoryCache:          lo.PanicOnErr(lru.New2Q[string, []*transactionHistoryItem](txHistoryCacheSize)),
+		RestAPILimitsMaxResults: limits.MaxResults,
+		txHistoryCache: cache.NewWeightedLRU[string, []*database.TransactionHistoryItem](limits.TransactionHistoryCacheSize, func(items []*database.TransactionHistoryItem) int {
+			return len(items)
+		}),
+		txHistoryGroup:                singleflight.Group{},
+		txHistoryWorkerPool:           newBoundedWorkerPool(limits.TransactionHistoryWorkers, limits.TransactionHistoryQueueSize),
+		milestoneRangeWorkerPool:      newBoundedWorkerPool(limits.MilestoneRangeWorkers, limits.MilestoneRangeQueueSize),
+		largestTransfersMaxMilestones: limits.LargestTransactionsMaxMilestones,
+		largestTransfersCache: cache.NewWeightedLRU[milestone.Index, []*utxo.Transfer](limits.LargestTransactionsCacheSize, func(transfers []*utxo.Transfer) int {
+			return len(transfers)
+		}),
+		largestTransfersGroup:   singleflight.Group{},
+		ledgerDiffMaxMilestones: limits.LedgerDiffMaxMilestones,
+		ledgerDiffCache: cache.NewWeightedLRU[ledgerDiffRange, *utxo.LedgerDiff](limits.LedgerDiffCacheSize, func(ledgerDiff *utxo.LedgerDiff) int {
+			return len(ledgerDiff.Created) + len(ledgerDiff.Consumed) + len(ledgerDiff.BalanceChanges)
+		}),
+		ledgerDiffGroup:       singleflight.Group{},
+		attestationMaxOutputs: limits.AttestationMaxOutputs,
 	}
 
 	s.configureRoutes(swagger.Group("root", APIRoute))
@@ -68,6 +147,15 @@ func CreateEchoSwagger(e *echo.Echo, version string, enabled bool) echoswagger.A
 	return echoSwagger
 }
 
+// checkIndexReady returns an echo.ErrServiceUnavailable error if the index with the given prefix is still being built.
+func (s *DatabaseServer) checkIndexReady(indexStorePrefix byte) error {
+	if err := s.Database.CheckIndexReady(indexStorePrefix); err != nil {
+		return errors.WithMessagef(echo.ErrServiceUnavailable, "%s, try again later", err)
+	}
+
+	return nil
+}
+
 func (s *DatabaseServer) maxResultsFromContext(c echo.Context) int {
 	maxPageSize := uint32(s.RestAPILimitsMaxResults)
 	if maxPageSize <= 0 {
@@ -88,3 +176,35 @@ func (s *DatabaseServer) maxResultsFromContext(c echo.Context) int {
 
 	return int(maxPageSize)
 }
+
+// limitAndOffsetFromContext returns the limit and the offset of a paginated request.
+// The limit defaults to, and is capped at, the maximum results of the API.
+func (s *DatabaseServer) limitAndOffsetFromContext(c echo.Context) (int, int, error) {
+	maxLimit := uint32(s.RestAPILimitsMaxResults)
+	if maxLimit <= 0 {
+		maxLimit = math.MaxUint32
+	}
+
+	limit := maxLimit
+	if len(c.QueryParam(restapipkg.QueryParameterLimit)) > 0 {
+		limitQueryParam, err := httpserver.ParseUint32QueryParam(c, restapipkg.QueryParameterLimit)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		if limitQueryParam < maxLimit {
+			limit = limitQueryParam
+		}
+	}
+
+	var offset uint32
+	if len(c.QueryParam(restapipkg.QueryParameterOffset)) > 0 {
+		offsetQueryParam, err := httpserver.ParseUint32QueryParam(c, restapipkg.QueryParameterOffset)
+		if err != nil {
+			return 0, 0, err
+		}
+		offset = offsetQueryParam
+	}
+
+	return int(limit), int(offset), nil
+}
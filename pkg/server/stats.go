@@ -0,0 +1,109 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/inx-api-core-v1/pkg/database"
+	"github.com/iotaledger/inx-api-core-v1/pkg/restapi"
+	"github.com/iotaledger/inx-app/pkg/httpserver"
+)
+
+func (s *DatabaseServer) dailyStats(c echo.Context) (*dailyStatsResponse, error) {
+	if err := s.checkIndexReady(database.IndexStorePrefixDailyStats); err != nil {
+		return nil, err
+	}
+
+	from := time.Unix(0, 0).UTC()
+	to := time.Now().UTC()
+
+	if len(c.QueryParam(restapi.QueryParameterFrom)) > 0 {
+		date, err := restapi.ParseDateQueryParam(c, restapi.QueryParameterFrom)
+		if err != nil {
+			return nil, err
+		}
+		from = date
+	}
+
+	if len(c.QueryParam(restapi.QueryParameterTo)) > 0 {
+		date, err := restapi.ParseDateQueryParam(c, restapi.QueryParameterTo)
+		if err != nil {
+			return nil, err
+		}
+		to = date
+	}
+
+	if from.After(to) {
+		return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid date range: %s-%s", from.Format(restapi.DateFormat), to.Format(restapi.DateFormat))
+	}
+
+	stats, err := s.Database.DailyStats(from, to)
+	if err != nil {
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "reading daily stats failed: %s", err)
+	}
+
+	days := make([]*dailyStatsItem, len(stats))
+	for i, dayStats := range stats {
+		days[i] = &dailyStatsItem{
+			Date:                        dayStats.Date.Format(restapi.DateFormat),
+			MilestoneCount:              dayStats.MilestoneCount,
+			TransactionCount:            dayStats.TransactionCount,
+			ConflictingTransactionCount: dayStats.ConflictingTransactionCount,
+			IndexationMessageCount:      dayStats.IndexationMessageCount,
+			ValueMoved:                  dayStats.ValueMoved,
+			ActiveAddressCount:          dayStats.ActiveAddressCount,
+			NewAddressCount:             dayStats.NewAddressCount,
+		}
+	}
+
+	return &dailyStatsResponse{
+		From: from.Format(restapi.DateFormat),
+		To:   to.Format(restapi.DateFormat),
+		Days: days,
+	}, nil
+}
+
+func dailyStatsCSV(resp *dailyStatsResponse) string {
+	var csvBuilder strings.Builder
+
+	csvBuilder.WriteString("\"Date\",\"Milestones\",\"Transactions\",\"ConflictingTransactions\",\"IndexationMessages\",\"ValueMoved\",\"ActiveAddresses\",\"NewAddresses\"\n")
+
+	for _, day := range resp.Days {
+		csvBuilder.WriteString(fmt.Sprintf("\"%s\",", day.Date))
+		csvBuilder.WriteString(fmt.Sprintf("%d,", day.MilestoneCount))
+		csvBuilder.WriteString(fmt.Sprintf("%d,", day.TransactionCount))
+		csvBuilder.WriteString(fmt.Sprintf("%d,", day.ConflictingTransactionCount))
+		csvBuilder.WriteString(fmt.Sprintf("%d,", day.IndexationMessageCount))
+		csvBuilder.WriteString(fmt.Sprintf("%d,", day.ValueMoved))
+		csvBuilder.WriteString(fmt.Sprintf("%d,", day.ActiveAddressCount))
+		csvBuilder.WriteString(fmt.Sprintf("%d\n", day.NewAddressCount))
+	}
+
+	return csvBuilder.String()
+}
+
+func (s *DatabaseServer) dailyStatsResponseByMimeType(c echo.Context) error {
+	resp, err := s.dailyStats(c)
+	if err != nil {
+		return err
+	}
+
+	mimeType, err := httpserver.GetAcceptHeaderContentType(c, MIMETextCSV, echo.MIMEApplicationJSON)
+	if err != nil && !errors.Is(err, httpserver.ErrNotAcceptable) {
+		return err
+	}
+
+	switch mimeType {
+	case MIMETextCSV:
+		return c.Blob(http.StatusOK, MIMETextCSV, []byte(dailyStatsCSV(resp)))
+
+	default:
+		// default to echo.MIMEApplicationJSON
+		return restapi.JSONResponse(c, http.StatusOK, resp)
+	}
+}
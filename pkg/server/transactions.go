@@ -14,9 +14,7 @@ import (
 	"github.com/iotaledger/hive.go/kvstore"
 	"github.com/iotaledger/inx-api-core-v1/pkg/database"
 	"github.com/iotaledger/inx-api-core-v1/pkg/hornet"
-	"github.com/iotaledger/inx-api-core-v1/pkg/milestone"
 	"github.com/iotaledger/inx-api-core-v1/pkg/restapi"
-	"github.com/iotaledger/inx-api-core-v1/pkg/utxo"
 	"github.com/iotaledger/inx-app/pkg/httpserver"
 	iotago "github.com/iotaledger/iota.go/v2"
 )
@@ -43,254 +41,85 @@ func (s *DatabaseServer) messageIDByTransactionID(c echo.Context) (hornet.Messag
 	return output.MessageID(), nil
 }
 
-func (s *DatabaseServer) transactionHistoryByAddress(c echo.Context, address iotago.Address) (*transactionHistoryResponse, error) {
-
-	// helper function to get the message ID of the transaction that spent the output
-	getSpendingMessageID := func(transactionID *iotago.TransactionID) (hornet.MessageID, error) {
-		// get the first output of that transaction (using index 0)
-		outputID := &iotago.UTXOInputID{}
-		copy(outputID[:], transactionID[:])
-
-		output, err := s.UTXOManager.ReadOutputByOutputID(outputID)
-		if err != nil {
-			if errors.Is(err, kvstore.ErrKeyNotFound) {
-				// if we don't have the output, we don't have the history, which is fine.
-				//nolint:nilnil
-				return nil, nil
-			}
-
-			return nil, errors.WithMessagef(echo.ErrInternalServerError, "failed to load output for transaction: %s", hex.EncodeToString(transactionID[:]))
-		}
-
-		return output.MessageID(), nil
+func (s *DatabaseServer) newTransactionHistoryItem(item *database.TransactionHistoryItem) *transactionHistoryItem {
+	var conflictReason *database.Conflict
+	if item.Conflict != database.ConflictNone {
+		conflict := item.Conflict
+		conflictReason = &conflict
 	}
 
-	getTransactionHistoryItem := func(address iotago.Address, messageID hornet.MessageID) (*transactionHistoryItem, error) {
-		msg := s.Database.MessageOrNil(messageID)
-		if msg == nil {
-			// if we don't have the message, we don't have the history, which is fine.
-			//nolint:nilnil
-			return nil, nil
-		}
-
-		msgMeta := s.Database.MessageMetadataOrNil(messageID)
-		if msgMeta == nil {
-			return nil, fmt.Errorf("message not found: %s", messageID.ToHex())
-		}
-
-		var referencedByMilestoneIndex milestone.Index
-		if referenced, referencedIndex := msgMeta.ReferencedWithIndex(); referenced {
-			referencedByMilestoneIndex = referencedIndex
-		}
-
-		milestoneTimestampReferenced, err := s.Database.MilestoneTimestampUnixByIndex(referencedByMilestoneIndex)
-		if err != nil {
-			return nil, err
-		}
-
-		ledgerInclusionState := "noTransaction"
-		conflict := msgMeta.Conflict()
-		var conflictReason *database.Conflict
-
-		if conflict != database.ConflictNone {
-			ledgerInclusionState = "conflicting"
-			conflictReason = &conflict
-		} else if msgMeta.IsIncludedTxInLedger() {
-			ledgerInclusionState = "included"
-		}
-
-		txPayload := msg.Transaction()
-		if txPayload == nil {
-			// not a transaction payload. check if it is a milestone payload
-			msPayload := msg.Milestone()
-			//nolint:forcetypeassert
-			if msPayload == nil || msPayload.Receipt == nil || msPayload.Receipt.(*iotago.Receipt).Transaction == nil {
-				return nil, fmt.Errorf("message does not contain a transaction or milestone payload: %s", messageID.ToHex())
-			}
-
-			// we need to signal that this was a migration from the legacy network
-			ledgerInclusionState = "migrated"
-
-			//nolint:forcetypeassert
-			receipt := msPayload.Receipt.(*iotago.Receipt)
-			//nolint:forcetypeassert
-			treasuryInput := receipt.Transaction.(*iotago.TreasuryTransaction).Input.(*iotago.TreasuryInput)
-
-			var addressBalanceOutputs int64
-			for _, input := range receipt.Funds {
-				//nolint:forcetypeassert
-				migratedFundEntry := input.(*iotago.MigratedFundsEntry)
-				//nolint:forcetypeassert
-				if migratedFundEntry.Address.(iotago.Address).String() != address.String() {
-					continue
-				}
-
-				addressBalanceOutputs += int64(migratedFundEntry.Deposit)
-			}
+	return &transactionHistoryItem{
+		MessageID:                    item.MessageID.ToHex(),
+		TransactionID:                hex.EncodeToString(item.TransactionID[:]),
+		ReferencedByMilestoneIndex:   item.ReferencedByMilestoneIndex,
+		MilestoneTimestampReferenced: item.MilestoneTimestampReferenced,
+		LedgerInclusionState:         item.LedgerInclusionState.String(),
+		ConflictReason:               conflictReason,
+		InputsCount:                  item.InputsCount,
+		OutputsCount:                 item.OutputsCount,
+		AddressBalanceChange:         item.AddressBalanceChange,
+		Counterparties:               s.counterparties(item.Counterparties),
+	}
+}
 
-			return &transactionHistoryItem{
-				MessageID:                    messageID.ToHex(),
-				TransactionID:                hex.EncodeToString(treasuryInput[:]), // milestone ID of the legacy network
-				ReferencedByMilestoneIndex:   referencedByMilestoneIndex,
-				MilestoneTimestampReferenced: milestoneTimestampReferenced,
-				LedgerInclusionState:         ledgerInclusionState,
-				ConflictReason:               conflictReason,
-				InputsCount:                  1,
-				OutputsCount:                 len(receipt.Funds),
-				AddressBalanceChange:         addressBalanceOutputs,
-			}, nil
+func (s *DatabaseServer) transactionHistoryByAddress(c echo.Context, address iotago.Address) (*transactionHistoryResponse, error) {
 
-		}
+	if err := s.checkIndexReady(database.IndexStorePrefixAddressHistory); err != nil {
+		return nil, err
+	}
 
-		transactionID, err := txPayload.ID()
+	computeTransactionHistoryItems := func(address iotago.Address) ([]*database.TransactionHistoryItem, error) {
+		// the items in the index are already sorted by highest milestone index and lowest messageID
+		txHistoryItems, err := s.Database.AddressTransactionHistory(address)
 		if err != nil {
-			return nil, fmt.Errorf("can't compute the transaction ID, msgID: %s, error: %w", messageID.ToHex(), err)
-		}
-		txID := *transactionID
-
-		txEssence := msg.TransactionEssence()
-		if txEssence == nil {
-			return nil, fmt.Errorf("transaction does not contain a valid transactionEssence: msgID: %s", messageID.ToHex())
-		}
-
-		var addressBalanceInputs int64
-		for _, input := range txEssence.Inputs {
-			utxoInput, ok := input.(*iotago.UTXOInput)
-			if !ok {
-				return nil, fmt.Errorf("transaction contains an unsupported input type: msgID: %s", messageID.ToHex())
-			}
-
-			utxoInputID := utxoInput.ID()
-			output, err := s.UTXOManager.ReadOutputByOutputID(&utxoInputID)
-			if err != nil {
-				// if we don't have the input, we don't have the history, which is fine.
-				//nolint:nilnil,nilerr
-				return nil, nil
-			}
-
-			if output.Address().String() != address.String() {
-				continue
-			}
-
-			addressBalanceInputs += int64(output.Amount())
+			return nil, errors.WithMessagef(echo.ErrInternalServerError, "reading transaction history failed: %s, error: %s", address, err)
 		}
 
-		var addressBalanceOutputs int64
-		for _, txOutput := range txEssence.Outputs {
-			switch output := txOutput.(type) {
-			case *iotago.SigLockedSingleOutput:
-				//nolint:forcetypeassert
-				if output.Address.(iotago.Address).String() != address.String() {
-					continue
-				}
-				addressBalanceOutputs += int64(output.Amount)
-			case *iotago.SigLockedDustAllowanceOutput:
-				//nolint:forcetypeassert
-				if output.Address.(iotago.Address).String() != address.String() {
-					continue
-				}
-				addressBalanceOutputs += int64(output.Amount)
-			default:
-				return nil, fmt.Errorf("transaction contains an unsupported output type: msgID: %s", messageID.ToHex())
-			}
-		}
+		// add the result in the cache, because it will never change.
+		// the labels are added per request, because they are reloaded if the label files change.
+		s.txHistoryCache.Add(address.String(), txHistoryItems)
 
-		return &transactionHistoryItem{
-			MessageID:                    messageID.ToHex(),
-			TransactionID:                hex.EncodeToString(txID[:]),
-			ReferencedByMilestoneIndex:   referencedByMilestoneIndex,
-			MilestoneTimestampReferenced: milestoneTimestampReferenced,
-			LedgerInclusionState:         ledgerInclusionState,
-			ConflictReason:               conflictReason,
-			InputsCount:                  len(txEssence.Inputs),
-			OutputsCount:                 len(txEssence.Outputs),
-			AddressBalanceChange:         addressBalanceOutputs - addressBalanceInputs,
-		}, nil
+		return txHistoryItems, nil
 	}
 
-	getTransactionHistoryItems := func(address iotago.Address) ([]*transactionHistoryItem, error) {
+	getTransactionHistoryItems := func(address iotago.Address) ([]*database.TransactionHistoryItem, error) {
 		// check if the entry already exists in the cache
 		txHistoryItems, exists := s.txHistoryCache.Get(address.String())
 		if exists {
 			return txHistoryItems, nil
 		}
 
-		messageIDs := make(map[string]struct{}, 0)
-		if err := s.UTXOManager.ForEachUnspentOutput(func(output *utxo.Output) bool {
-			// add the message that contains the transaction which created this output
-			messageIDs[output.MessageID().ToMapKey()] = struct{}{}
-
-			// we always collect all results and cap to the maximum later to have deterministic responses
-			return true
-		}, utxo.FilterAddress(address)); err != nil {
-			return nil, errors.WithMessagef(echo.ErrInternalServerError, "reading unspent outputs failed: %s, error: %s", address, err)
-		}
-
-		var innerErr error
-		if err := s.UTXOManager.ForEachSpentOutput(func(spent *utxo.Spent) bool {
-			// add the message that contains the transaction which created this output
-			messageIDs[spent.MessageID().ToMapKey()] = struct{}{}
-
-			// also add the message that contains the transaction that spent this output
-			spendingMessageID, err := getSpendingMessageID(spent.TargetTransactionID())
-			if err != nil {
-				innerErr = errors.WithMessagef(echo.ErrInternalServerError, "reading spent outputs failed: %s, error: %s", address, err)
-				return false
-			}
-			messageIDs[spendingMessageID.ToMapKey()] = struct{}{}
-
-			// we always collect all results and cap to the maximum later to have deterministic responses
-			return true
-		}, utxo.FilterAddress(address)); err != nil {
-			return nil, errors.WithMessagef(echo.ErrInternalServerError, "reading spent outputs failed: %s, error: %s", address, err)
-		}
-		if innerErr != nil {
-			return nil, innerErr
-		}
-
-		// add the messages that contain conflicting transactions for the given address
-		conflictingTransactionsMessageIDs, err := s.Database.ConflictingTransactionsMessageIDs(address)
-		if err != nil {
-			return nil, errors.WithMessagef(echo.ErrInternalServerError, "reading conflicting transaction messageIDs failed: %s, error: %s", address, err)
-		}
-
-		for _, conflictingTransactionsMessageID := range conflictingTransactionsMessageIDs {
-			messageIDs[conflictingTransactionsMessageID.ToMapKey()] = struct{}{}
-		}
-
-		txHistoryItems = make([]*transactionHistoryItem, 0, len(messageIDs))
-		for messageID := range messageIDs {
-			txHistoryItem, err := getTransactionHistoryItem(address, hornet.MessageIDFromMapKey(messageID))
-			if err != nil {
-				return nil, errors.WithMessagef(echo.ErrInternalServerError, "get transaction history failed: %s, error: %s", address, err)
+		// concurrent requests for the same address wait for the result of the first request.
+		// the computation runs with the context of the server, so that it is not aborted if the first request is canceled,
+		// while every request stops waiting if its own context is done.
+		resultChan := s.txHistoryGroup.DoChan(address.String(), func() (interface{}, error) {
+			// check the cache again, the result could have been added while we were waiting
+			if txHistoryItems, exists := s.txHistoryCache.Get(address.String()); exists {
+				return txHistoryItems, nil
 			}
 
-			if txHistoryItem == nil {
-				// skip if we don't have the history
-				continue
+			var innerErr error
+			if err := s.txHistoryWorkerPool.Run(s.ctx, func() {
+				txHistoryItems, innerErr = computeTransactionHistoryItems(address)
+			}); err != nil {
+				return nil, err
 			}
 
-			txHistoryItems = append(txHistoryItems, txHistoryItem)
-		}
-
-		// sort the results by highest milestone index and lowest messageID
-		sort.Slice(txHistoryItems, func(i, j int) bool {
-			historyItemLeft := txHistoryItems[i]
-			historyItemRight := txHistoryItems[j]
+			return txHistoryItems, innerErr
+		})
 
-			// if both are referenced by the same milestone, sort by messageID
-			if historyItemLeft.ReferencedByMilestoneIndex == historyItemRight.ReferencedByMilestoneIndex {
-				return strings.Compare(historyItemLeft.MessageID, historyItemRight.MessageID) < 0
+		select {
+		case result := <-resultChan:
+			if result.Err != nil {
+				return nil, result.Err
 			}
 
-			// sort by milestone index
-			return historyItemLeft.ReferencedByMilestoneIndex > historyItemRight.ReferencedByMilestoneIndex
-		})
+			//nolint:forcetypeassert // we only return []*database.TransactionHistoryItem
+			return result.Val.([]*database.TransactionHistoryItem), nil
 
-		// add the result in the cache, because it will never change
-		s.txHistoryCache.Add(address.String(), txHistoryItems)
-
-		return txHistoryItems, nil
+		case <-c.Request().Context().Done():
+			return nil, errors.WithMessagef(echo.ErrServiceUnavailable, "request aborted while waiting for the transaction history: %s", c.Request().Context().Err())
+		}
 	}
 
 	txHistoryItems, err := getTransactionHistoryItems(address)
@@ -303,12 +132,18 @@ func (s *DatabaseServer) transactionHistoryByAddress(c echo.Context, address iot
 		txHistoryItems = txHistoryItems[:maxResults]
 	}
 
+	history := make([]*transactionHistoryItem, len(txHistoryItems))
+	for i, item := range txHistoryItems {
+		history[i] = s.newTransactionHistoryItem(item)
+	}
+
 	return &transactionHistoryResponse{
 		AddressType: address.Type(),
 		Address:     address.String(),
 		MaxResults:  uint32(maxResults),
-		Count:       uint32(len(txHistoryItems)),
-		History:     txHistoryItems,
+		Count:       uint32(len(history)),
+		Label:       s.addressLabel(address),
+		History:     history,
 		LedgerIndex: s.UTXOManager.ReadLedgerIndex(),
 	}, nil
 }
@@ -319,15 +154,21 @@ func transactionHistoryCSV(resp *transactionHistoryResponse) string {
 	csvBuilder.WriteString("\"Transaction History\"\n\n")
 
 	csvBuilder.WriteString(fmt.Sprintf("\"Address:\",\"0x%s\"\n", resp.Address))
+	if resp.Label != nil {
+		csvBuilder.WriteString(fmt.Sprintf("\"Label:\",%s\n", csvQuote(resp.Label.Name)))
+		csvBuilder.WriteString(fmt.Sprintf("\"Category:\",%s\n", csvQuote(resp.Label.Category)))
+	}
 	csvBuilder.WriteString(fmt.Sprintf("\"LedgerIndex:\",%d\n", resp.LedgerIndex))
 	csvBuilder.WriteString(fmt.Sprintf("\"MaxResultsLimitReached:\",\"%t\"\n", resp.MaxResults != 0 && resp.Count == resp.MaxResults))
 	csvBuilder.WriteString(fmt.Sprintf("\"Date:\",\"%s\"\n", time.Now().Format(time.RFC3339)))
-	csvBuilder.WriteString("\n\"MessageID\",\"TransactionID\",\"ReferencedByMilestoneIndex\",\"MilestoneTimestampReferenced\",\"LedgerInclusionState\",\"ConflictReason\",\"InputsCount\",\"OutputsCount\",\"AddressBalanceChange\"\n")
+	csvBuilder.WriteString("\n\"MessageID\",\"TransactionID\",\"ReferencedByMilestoneIndex\",\"MilestoneTimestampReferenced\",\"LedgerInclusionState\",\"ConflictReason\",\"InputsCount\",\"OutputsCount\",\"AddressBalanceChange\",\"Counterparties\",\"CounterpartyLabels\"\n")
 
 	// sort the history items by milestoneIndex and messageID to have a deterministic CSV file
-	sort.Slice(resp.History, func(i, j int) bool {
-		historyItemLeft := resp.History[i]
-		historyItemRight := resp.History[j]
+	history := resp.History
+
+	sort.Slice(history, func(i, j int) bool {
+		historyItemLeft := history[i]
+		historyItemRight := history[j]
 
 		// if both are referenced by the same milestone, sort by messageID
 		if historyItemLeft.ReferencedByMilestoneIndex == historyItemRight.ReferencedByMilestoneIndex {
@@ -338,7 +179,7 @@ func transactionHistoryCSV(resp *transactionHistoryResponse) string {
 		return historyItemLeft.ReferencedByMilestoneIndex < historyItemRight.ReferencedByMilestoneIndex
 	})
 
-	for _, historyItem := range resp.History {
+	for _, historyItem := range history {
 		csvBuilder.WriteString(fmt.Sprintf("\"%s\",", historyItem.MessageID))
 		csvBuilder.WriteString(fmt.Sprintf("\"%s\",", historyItem.TransactionID))
 		csvBuilder.WriteString(fmt.Sprintf("%d,", historyItem.ReferencedByMilestoneIndex))
@@ -347,12 +188,28 @@ func transactionHistoryCSV(resp *transactionHistoryResponse) string {
 		csvBuilder.WriteString(fmt.Sprintf("%d,", historyItem.ConflictReason))
 		csvBuilder.WriteString(fmt.Sprintf("%d,", historyItem.InputsCount))
 		csvBuilder.WriteString(fmt.Sprintf("%d,", historyItem.OutputsCount))
-		csvBuilder.WriteString(fmt.Sprintf("%d\n", historyItem.AddressBalanceChange))
+		csvBuilder.WriteString(fmt.Sprintf("%d,", historyItem.AddressBalanceChange))
+
+		counterpartyAddresses := make([]string, 0, len(historyItem.Counterparties))
+		counterpartyLabels := make([]string, 0, len(historyItem.Counterparties))
+		for _, counterparty := range historyItem.Counterparties {
+			counterpartyAddresses = append(counterpartyAddresses, "0x"+counterparty.Address)
+			if counterparty.Label != nil {
+				counterpartyLabels = append(counterpartyLabels, fmt.Sprintf("0x%s: %s (%s)", counterparty.Address, counterparty.Label.Name, counterparty.Label.Category))
+			}
+		}
+		csvBuilder.WriteString(fmt.Sprintf("%s,", csvQuote(strings.Join(counterpartyAddresses, " "))))
+		csvBuilder.WriteString(fmt.Sprintf("%s\n", csvQuote(strings.Join(counterpartyLabels, "; "))))
 	}
 
 	return csvBuilder.String()
 }
 
+// csvQuote quotes a field of the CSV file and escapes the quotes inside the field.
+func csvQuote(field string) string {
+	return "\"" + strings.ReplaceAll(field, "\"", "\"\"") + "\""
+}
+
 func (s *DatabaseServer) transactionHistoryResponseByAddressAndMimeType(c echo.Context, address iotago.Address) error {
 	resp, err := s.transactionHistoryByAddress(c, address)
 	if err != nil {
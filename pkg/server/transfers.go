@@ -0,0 +1,272 @@
+package server
+
+import (
+	"context"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/inx-api-core-v1/pkg/milestone"
+	"github.com/iotaledger/inx-api-core-v1/pkg/restapi"
+	"github.com/iotaledger/inx-api-core-v1/pkg/utxo"
+)
+
+const (
+	// the amount of milestones in a bucket of the largest transfers cache.
+	largestTransfersBucketSize = 1000
+)
+
+// milestoneIndexFromRangeQueryParam parses the query parameter with the given name as milestone index,
+// as date (YYYY-MM-DD, UTC) or as RFC3339 timestamp. Dates and timestamps are mapped to the first milestone
+// at or after the given time, or the last milestone at or before the given time if the parameter defines the end of the range.
+// It returns false if the parameter is not given.
+func (s *DatabaseServer) milestoneIndexFromRangeQueryParam(c echo.Context, paramName string, isEnd bool) (milestone.Index, bool, error) {
+	value := c.QueryParam(paramName)
+	if value == "" {
+		return 0, false, nil
+	}
+
+	if msIndex, err := strconv.ParseUint(value, 10, 32); err == nil {
+		return milestone.Index(msIndex), true, nil
+	}
+
+	var start, end time.Time
+	if date, err := time.ParseInLocation(restapi.DateFormat, value, time.UTC); err == nil {
+		start, end = date, date.Add(24*time.Hour)
+	} else if timestamp, err := time.Parse(time.RFC3339, value); err == nil {
+		start, end = timestamp, timestamp.Add(time.Second)
+	} else {
+		return 0, false, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid value for query parameter \"%s\": %s, expected milestone index, date (%s) or RFC3339 timestamp", paramName, value, restapi.DateFormat)
+	}
+
+	if !isEnd {
+		msIndex, err := s.Database.MilestoneIndexByTimestamp(start)
+		if err != nil {
+			return 0, false, errors.WithMessagef(echo.ErrInternalServerError, "failed to search milestone by timestamp: %s, error: %s", value, err)
+		}
+
+		return msIndex, true, nil
+	}
+
+	// the end of the range is the milestone before the first milestone after the given time
+	msIndex, err := s.Database.MilestoneIndexByTimestamp(end)
+	if err != nil {
+		return 0, false, errors.WithMessagef(echo.ErrInternalServerError, "failed to search milestone by timestamp: %s, error: %s", value, err)
+	}
+
+	return msIndex - 1, true, nil
+}
+
+// computeLargestTransfers returns the transfers of the given milestone range sorted by their net value.
+// If maxCount is greater than zero, only the largest maxCount transfers are returned.
+// The computation is limited by the milestone range worker pool.
+func (s *DatabaseServer) computeLargestTransfers(ctx context.Context, from milestone.Index, to milestone.Index, maxCount int) ([]*utxo.Transfer, error) {
+	var transfers []*utxo.Transfer
+	var innerErr error
+	if err := s.milestoneRangeWorkerPool.Run(ctx, func() {
+		transfers, innerErr = s.collectLargestTransfers(from, to, maxCount)
+	}); err != nil {
+		return nil, err
+	}
+
+	return transfers, innerErr
+}
+
+// collectLargestTransfers collects the largest transfers of the milestone range.
+func (s *DatabaseServer) collectLargestTransfers(from milestone.Index, to milestone.Index, maxCount int) ([]*utxo.Transfer, error) {
+	transfers := make([]*utxo.Transfer, 0)
+
+	for msIndex := from; msIndex <= to; msIndex++ {
+		milestoneTransfers, err := s.UTXOManager.MilestoneTransfers(msIndex)
+		if err != nil {
+			if errors.Is(err, kvstore.ErrKeyNotFound) {
+				return nil, errors.WithMessagef(echo.ErrNotFound, "can't load milestone diff for index: %d, error: %s", msIndex, err)
+			}
+
+			return nil, errors.WithMessagef(echo.ErrInternalServerError, "can't load milestone diff for index: %d, error: %s", msIndex, err)
+		}
+		transfers = append(transfers, milestoneTransfers...)
+
+		// only keep the largest transfers to limit the memory usage for big ranges
+		if maxCount > 0 && len(transfers) > 2*maxCount {
+			utxo.SortTransfersByNetValue(transfers)
+			transfers = transfers[:maxCount]
+		}
+	}
+
+	utxo.SortTransfersByNetValue(transfers)
+	if maxCount > 0 && len(transfers) > maxCount {
+		transfers = transfers[:maxCount]
+	}
+
+	return transfers, nil
+}
+
+// largestTransfersOfBucket returns the largest transfers of the completed milestone range bucket that starts at the given index.
+func (s *DatabaseServer) largestTransfersOfBucket(c echo.Context, bucketStart milestone.Index, maxCount int) ([]*utxo.Transfer, error) {
+	// check if the entry already exists in the cache
+	if transfers, exists := s.largestTransfersCache.Get(bucketStart); exists {
+		return transfers, nil
+	}
+
+	// concurrent requests for the same bucket wait for the result of the first request.
+	// the computation runs with the context of the server, so that it is not aborted if the first request is canceled.
+	resultChan := s.largestTransfersGroup.DoChan(strconv.FormatUint(uint64(bucketStart), 10), func() (interface{}, error) {
+		// check the cache again, the result could have been added while we were waiting
+		if transfers, exists := s.largestTransfersCache.Get(bucketStart); exists {
+			return transfers, nil
+		}
+
+		transfers, err := s.computeLargestTransfers(s.ctx, bucketStart, bucketStart+largestTransfersBucketSize-1, maxCount)
+		if err != nil {
+			return nil, err
+		}
+
+		// add the result in the cache, because the milestones of a completed bucket will never change.
+		s.largestTransfersCache.Add(bucketStart, transfers)
+
+		return transfers, nil
+	})
+
+	select {
+	case result := <-resultChan:
+		if result.Err != nil {
+			return nil, result.Err
+		}
+
+		//nolint:forcetypeassert // we only return []*utxo.Transfer
+		return result.Val.([]*utxo.Transfer), nil
+
+	case <-c.Request().Context().Done():
+		return nil, errors.WithMessagef(echo.ErrServiceUnavailable, "request aborted while waiting for the largest transactions: %s", c.Request().Context().Err())
+	}
+}
+
+func (s *DatabaseServer) newTransferAmounts(amounts []*utxo.TransferAmount) []*transferAmount {
+	result := make([]*transferAmount, len(amounts))
+	for i, amount := range amounts {
+		result[i] = &transferAmount{
+			AddressType: amount.Address.Type(),
+			Address:     amount.Address.String(),
+			Amount:      amount.Amount,
+			Label:       s.addressLabel(amount.Address),
+		}
+	}
+
+	return result
+}
+
+func (s *DatabaseServer) largestTransactions(c echo.Context) (*largestTransactionsResponse, error) {
+	limit, offset, err := s.limitAndOffsetFromContext(c)
+	if err != nil {
+		return nil, err
+	}
+
+	syncState := s.Database.LatestSyncState()
+
+	to, toGiven, err := s.milestoneIndexFromRangeQueryParam(c, restapi.QueryParameterTo, true)
+	if err != nil {
+		return nil, err
+	}
+	if !toGiven || to > syncState.ConfirmedMilestoneIndex {
+		to = syncState.ConfirmedMilestoneIndex
+	}
+
+	from, fromGiven, err := s.milestoneIndexFromRangeQueryParam(c, restapi.QueryParameterFrom, false)
+	if err != nil {
+		return nil, err
+	}
+	if !fromGiven {
+		// only the given milestone
+		from = to
+	}
+	if from <= syncState.PruningIndex {
+		// the milestone diffs are only available after the pruning index
+		from = syncState.PruningIndex + 1
+	}
+
+	if from <= to && s.largestTransfersMaxMilestones > 0 && int(to-from)+1 > s.largestTransfersMaxMilestones {
+		return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "milestone range %d-%d exceeds the maximum of %d milestones", from, to, s.largestTransfersMaxMilestones)
+	}
+
+	// the cached buckets contain the largest transfers up to the maximum results of the API,
+	// the transfers after that position are not known without computing the whole bucket.
+	maxCount := s.RestAPILimitsMaxResults
+
+	transfers := make([]*utxo.Transfer, 0)
+	if from <= to {
+		for bucketStart := from - from%largestTransfersBucketSize; bucketStart <= to; bucketStart += largestTransfersBucketSize {
+			bucketEnd := bucketStart + largestTransfersBucketSize - 1
+
+			var bucketTransfers []*utxo.Transfer
+			if bucketStart >= from && bucketEnd <= to {
+				bucketTransfers, err = s.largestTransfersOfBucket(c, bucketStart, maxCount)
+			} else {
+				// partial buckets are not cached
+				rangeStart, rangeEnd := bucketStart, bucketEnd
+				if rangeStart < from {
+					rangeStart = from
+				}
+				if rangeEnd > to {
+					rangeEnd = to
+				}
+				bucketTransfers, err = s.computeLargestTransfers(c.Request().Context(), rangeStart, rangeEnd, maxCount)
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			transfers = append(transfers, bucketTransfers...)
+		}
+	}
+
+	utxo.SortTransfersByNetValue(transfers)
+	if maxCount > 0 && len(transfers) > maxCount {
+		transfers = transfers[:maxCount]
+	}
+
+	if offset >= len(transfers) {
+		transfers = transfers[:0]
+	} else {
+		transfers = transfers[offset:]
+	}
+	if len(transfers) > limit {
+		transfers = transfers[:limit]
+	}
+
+	milestoneTimestamps := make(map[milestone.Index]int64)
+	transactions := make([]*largestTransaction, len(transfers))
+	for i, transfer := range transfers {
+		timestamp, exists := milestoneTimestamps[transfer.MilestoneIndex]
+		if !exists {
+			timestamp, err = s.Database.MilestoneTimestampUnixByIndex(transfer.MilestoneIndex)
+			if err != nil {
+				return nil, errors.WithMessagef(echo.ErrInternalServerError, "can't load milestone timestamp for index: %d, error: %s", transfer.MilestoneIndex, err)
+			}
+			milestoneTimestamps[transfer.MilestoneIndex] = timestamp
+		}
+
+		transactions[i] = &largestTransaction{
+			TransactionID:      hex.EncodeToString(transfer.TransactionID[:]),
+			MilestoneIndex:     transfer.MilestoneIndex,
+			MilestoneTimestamp: timestamp,
+			TotalValue:         transfer.TotalValue,
+			NetValueMoved:      transfer.NetValue,
+			Inputs:             s.newTransferAmounts(transfer.Inputs),
+			Outputs:            s.newTransferAmounts(transfer.Outputs),
+		}
+	}
+
+	return &largestTransactionsResponse{
+		From:         from,
+		To:           to,
+		Offset:       uint32(offset),
+		Limit:        uint32(limit),
+		Count:        uint32(len(transactions)),
+		Transactions: transactions,
+	}, nil
+}
@@ -43,7 +43,34 @@ type infoResponse struct {
 
 // receiptsResponse defines the response of a receipts REST API call.
 type receiptsResponse struct {
+	// The receipts that match the filter of the request.
 	Receipts []*utxo.ReceiptTuple `json:"receipts"`
+	// The amount of matching receipts that were skipped.
+	Offset uint32 `json:"offset"`
+	// The maximum count of results that are returned by the node.
+	Limit uint32 `json:"limit"`
+	// The actual count of results that are returned.
+	Count uint32 `json:"count"`
+	// The amount of receipts that match the filter of the request.
+	Total uint32 `json:"total"`
+}
+
+// receiptsSummaryResponse defines the response of a GET receipts summary REST API call.
+type receiptsSummaryResponse struct {
+	// The milestone index at which the funds were migrated in the legacy network.
+	MigratedAt milestone.Index `json:"migratedAt"`
+	// The amount of receipts for the migrated at index.
+	ReceiptCount int `json:"receiptCount"`
+	// Whether a final receipt exists for the migrated at index.
+	Final bool `json:"final"`
+	// The amount of migrated funds entries in all receipts.
+	EntryCount int `json:"entryCount"`
+	// The sum of the deposits of all migrated funds entries.
+	TotalMigrated uint64 `json:"totalMigrated"`
+	// The sum of the amounts the treasury decreased by the receipts.
+	TreasuryDecrease uint64 `json:"treasuryDecrease"`
+	// Whether the total migrated tokens match the decrease of the treasury.
+	MatchesTreasuryDecrease bool `json:"matchesTreasuryDecrease"`
 }
 
 // messageMetadataResponse defines the response of a GET message metadata REST API call.
@@ -80,6 +107,22 @@ type childrenResponse struct {
 	Children []string `json:"childrenMessageIds"`
 }
 
+// messageDataResponse defines the response of a GET message data REST API call with an envelope.
+type messageDataResponse struct {
+	// The hex encoded message ID of the message.
+	MessageID string `json:"messageId"`
+	// The hex encoded index of the indexation payload.
+	Index string `json:"index"`
+	// The index of the indexation payload, if it is printable UTF-8.
+	IndexUTF8 string `json:"indexUtf8,omitempty"`
+	// The detected content type of the data.
+	ContentType string `json:"contentType"`
+	// The size of the data in bytes.
+	Size int `json:"size"`
+	// The data, embedded as JSON if it is JSON, as string if it is UTF-8 text and hex encoded otherwise.
+	Data any `json:"data"`
+}
+
 // messageIDsByIndexResponse defines the response of a GET messages REST API call.
 type messageIDsByIndexResponse struct {
 	// The index of the messages.
@@ -92,6 +135,93 @@ type messageIDsByIndexResponse struct {
 	MessageIDs []string `json:"messageIds"`
 }
 
+// indexMessageIDs is an item of the messageIDsByIndexPrefixResponse.
+type indexMessageIDs struct {
+	// The hex encoded full index of the messages, without trailing zeros.
+	Index string `json:"index"`
+	// The full index of the messages as UTF-8 string, if it is valid UTF-8.
+	IndexUTF8 string `json:"indexUtf8,omitempty"`
+	// The count of message IDs of this index that are returned.
+	Count uint32 `json:"count"`
+	// The hex encoded message IDs of the found messages with this index.
+	MessageIDs []string `json:"messageIds"`
+}
+
+// messageIDsByIndexPrefixResponse defines the response of a GET messages REST API call with an index prefix.
+type messageIDsByIndexPrefixResponse struct {
+	// The hex encoded prefix of the indexes.
+	IndexPrefix string `json:"indexPrefix"`
+	// The amount of matching message IDs that were skipped.
+	Offset uint32 `json:"offset"`
+	// The maximum count of message IDs that are returned by the node.
+	Limit uint32 `json:"limit"`
+	// The actual count of message IDs that are returned.
+	Count uint32 `json:"count"`
+	// The message IDs grouped by the matched full index.
+	Indexes []*indexMessageIDs `json:"indexes"`
+}
+
+const (
+	// indexEncodingUTF8 denotes an index that is rendered as UTF-8 string.
+	indexEncodingUTF8 = "utf8"
+	// indexEncodingHex denotes an index that is rendered hex encoded.
+	indexEncodingHex = "hex"
+)
+
+// indexationCatalogEntry is an item of the indexationCatalogResponse.
+type indexationCatalogEntry struct {
+	// The index, rendered as UTF-8 string if it is printable, hex encoded otherwise.
+	Index string `json:"index"`
+	// The encoding of the rendered index ("utf8" or "hex").
+	Encoding string `json:"encoding"`
+	// The hex encoded index without trailing zeros.
+	IndexHex string `json:"indexHex"`
+	// The amount of messages with this index.
+	MessageCount int64 `json:"messageCount"`
+	// The amount of messages with this index that were referenced by a milestone.
+	ReferencedMessageCount int64 `json:"referencedMessageCount"`
+	// The index of the first milestone that referenced a message with this index.
+	FirstReferencedMilestoneIndex milestone.Index `json:"firstReferencedMilestoneIndex"`
+	// The index of the last milestone that referenced a message with this index.
+	LastReferencedMilestoneIndex milestone.Index `json:"lastReferencedMilestoneIndex"`
+}
+
+// indexationCatalogResponse defines the response of a GET indexation catalog REST API call.
+type indexationCatalogResponse struct {
+	// The amount of indexes that were skipped.
+	Offset uint32 `json:"offset"`
+	// The maximum count of results that are returned by the node.
+	Limit uint32 `json:"limit"`
+	// The actual count of results that are returned.
+	Count uint32 `json:"count"`
+	// The distinct indexes sorted by their hex encoding.
+	Indexes []*indexationCatalogEntry `json:"indexes"`
+}
+
+// dataSearchResult is an item of the dataSearchResponse.
+type dataSearchResult struct {
+	// The hex encoded message ID of the message.
+	MessageID string `json:"messageId"`
+	// The milestone index that references this message.
+	ReferencedByMilestoneIndex milestone.Index `json:"referencedByMilestoneIndex"`
+	// The milestone timestamp that references this message.
+	MilestoneTimestampReferenced int64 `json:"milestoneTimestampReferenced"`
+}
+
+// dataSearchResponse defines the response of a GET data search REST API call.
+type dataSearchResponse struct {
+	// The search query.
+	Query string `json:"query"`
+	// The amount of matching messages that were skipped.
+	Offset uint32 `json:"offset"`
+	// The maximum count of results that are returned by the node.
+	Limit uint32 `json:"limit"`
+	// The actual count of results that are returned.
+	Count uint32 `json:"count"`
+	// The messages whose data contains all words of the query, sorted by the milestone that referenced them.
+	Messages []*dataSearchResult `json:"messages"`
+}
+
 // milestoneResponse defines the response of a GET milestones REST API call.
 type milestoneResponse struct {
 	// The index of the milestone.
@@ -112,6 +242,80 @@ type milestoneUTXOChangesResponse struct {
 	ConsumedOutputs []string `json:"consumedOutputs"`
 }
 
+// milestoneUTXOChange is a created or consumed output of the milestoneUTXOChangesExpandedResponse.
+type milestoneUTXOChange struct {
+	// The hex encoded output ID.
+	OutputID string `json:"outputId"`
+	// The type of the output.
+	OutputType byte `json:"outputType"`
+	// The type of the address (0=Ed25519).
+	AddressType byte `json:"addressType"`
+	// The hex encoded address.
+	Address string `json:"address"`
+	// The amount of the output.
+	Amount uint64 `json:"amount"`
+	// The hex encoded ID of the message that created the output.
+	MessageID string `json:"messageId"`
+	// The hex encoded ID of the transaction that consumed the output. Omitted for created outputs.
+	TransactionIDSpent string `json:"transactionIdSpent,omitempty"`
+	// The label of the address.
+	Label *addressLabel `json:"label,omitempty"`
+}
+
+// addressFlow is the net flow of an address in the milestoneUTXOChangesExpandedResponse.
+type addressFlow struct {
+	// The type of the address (0=Ed25519).
+	AddressType byte `json:"addressType"`
+	// The hex encoded address.
+	Address string `json:"address"`
+	// The sum of the amounts of the outputs created on the address.
+	Received uint64 `json:"received"`
+	// The sum of the amounts of the outputs consumed from the address.
+	Sent uint64 `json:"sent"`
+	// The net balance change of the address.
+	Net int64 `json:"net"`
+	// The label of the address.
+	Label *addressLabel `json:"label,omitempty"`
+}
+
+// milestoneUTXOChangesExpandedResponse defines the response of a GET milestone UTXO changes REST API call in expanded mode.
+type milestoneUTXOChangesExpandedResponse struct {
+	// The index of the milestone.
+	Index uint32 `json:"index"`
+	// The newly created outputs.
+	CreatedOutputs []*milestoneUTXOChange `json:"createdOutputs"`
+	// The consumed (spent) outputs.
+	ConsumedOutputs []*milestoneUTXOChange `json:"consumedOutputs"`
+	// The net flows of all addresses with created or consumed outputs, sorted by address.
+	AddressFlows []*addressFlow `json:"addressFlows"`
+	// The change of the treasury. Omitted if the milestone didn't change the treasury.
+	Treasury *ledgerDiffTreasury `json:"treasury,omitempty"`
+}
+
+// milestoneStatsResponse defines the response of a GET milestone stats REST API call.
+type milestoneStatsResponse struct {
+	// The index of the milestone.
+	Index uint32 `json:"index"`
+	// The unix time of the milestone payload.
+	Time int64 `json:"timestamp"`
+	// The seconds since the previous milestone. Zero if the previous milestone is not available.
+	TimeSincePreviousMilestone int64 `json:"timeSincePreviousMilestone"`
+	// The amount of messages referenced by the milestone, including the milestone message itself.
+	ReferencedMessagesCount int64 `json:"referencedMessagesCount"`
+	// The amount of referenced messages with a transaction that was included in the ledger.
+	IncludedTransactionsCount int64 `json:"includedTransactionsCount"`
+	// The amount of referenced messages with a conflicting transaction.
+	ConflictingTransactionsCount int64 `json:"conflictingTransactionsCount"`
+	// The amount of referenced messages without a transaction.
+	NoTransactionsCount int64 `json:"noTransactionsCount"`
+	// The sum of the amounts of all outputs consumed by the included transactions.
+	TotalValueTransferred uint64 `json:"totalValueTransferred"`
+	// The amount of outputs created by the milestone.
+	CreatedOutputsCount int64 `json:"createdOutputsCount"`
+	// The amount of outputs consumed by the milestone.
+	ConsumedOutputsCount int64 `json:"consumedOutputsCount"`
+}
+
 // OutputResponse defines the response of a GET outputs REST API call.
 type OutputResponse struct {
 	// The hex encoded message ID of the message.
@@ -130,6 +334,8 @@ type OutputResponse struct {
 	LedgerIndex milestone.Index `json:"ledgerIndex"`
 	// The output in its serialized form.
 	RawOutput *json.RawMessage `json:"output"`
+	// The label of the address of the output.
+	Label *addressLabel `json:"label,omitempty"`
 }
 
 // addressBalanceResponse defines the response of a GET addresses REST API call.
@@ -142,10 +348,160 @@ type addressBalanceResponse struct {
 	Balance uint64 `json:"balance"`
 	// Indicates if dust is allowed on this address.
 	DustAllowed bool `json:"dustAllowed"`
+	// The label of the address.
+	Label *addressLabel `json:"label,omitempty"`
 	// The ledger index at which this balance was queried at.
 	LedgerIndex milestone.Index `json:"ledgerIndex"`
 }
 
+// dustAllowanceOutput is an item of the addressDustAllowanceResponse.
+type dustAllowanceOutput struct {
+	// The output ID (transaction hash + output index) of the dust allowance output.
+	OutputID string `json:"outputId"`
+	// The amount of the dust allowance output.
+	Amount uint64 `json:"amount"`
+}
+
+// addressDustAllowanceResponse defines the response of a GET address dust allowance REST API call.
+type addressDustAllowanceResponse struct {
+	// The type of the address (0=Ed25519).
+	AddressType byte `json:"addressType"`
+	// The hex encoded address.
+	Address string `json:"address"`
+	// The sum of the amounts of all dust allowance outputs on this address.
+	DustAllowanceBalance uint64 `json:"dustAllowanceBalance"`
+	// The amount of dust outputs on this address.
+	DustOutputCount int64 `json:"dustOutputCount"`
+	// The maximum amount of dust outputs allowed on this address.
+	MaxDustOutputCount int64 `json:"maxDustOutputCount"`
+	// The amount of dust outputs that can still be created on this address.
+	RemainingDustOutputCount int64 `json:"remainingDustOutputCount"`
+	// Indicates if dust is allowed on this address.
+	DustAllowed bool `json:"dustAllowed"`
+	// The maximum count of dust allowance outputs that are returned by the node.
+	MaxResults uint32 `json:"maxResults"`
+	// The actual count of dust allowance outputs that are returned.
+	Count uint32 `json:"count"`
+	// The dust allowance outputs on this address.
+	DustAllowanceOutputs []*dustAllowanceOutput `json:"dustAllowanceOutputs"`
+	// The ledger index at which the dust allowance was queried at.
+	LedgerIndex milestone.Index `json:"ledgerIndex"`
+}
+
+// publicKeyReveal is an item of the addressPublicKeyResponse.
+type publicKeyReveal struct {
+	// The hex encoded ed25519 public key.
+	PublicKey string `json:"publicKey"`
+	// The hex encoded transaction id of the first transaction that revealed the public key.
+	TransactionID string `json:"transactionId"`
+	// The hex encoded message ID of the message in which the transaction payload was included.
+	MessageID string `json:"messageId"`
+	// The milestone index that references this message.
+	ReferencedByMilestoneIndex milestone.Index `json:"referencedByMilestoneIndex"`
+	// The milestone timestamp that references this message.
+	MilestoneTimestampReferenced int64 `json:"milestoneTimestampReferenced"`
+}
+
+// addressPublicKeyResponse defines the response of a GET address public key REST API call.
+type addressPublicKeyResponse struct {
+	// The type of the address (0=Ed25519).
+	AddressType byte `json:"addressType"`
+	// The hex encoded address.
+	Address string `json:"address"`
+	// The public keys that were revealed for this address.
+	PublicKeys []*publicKeyReveal `json:"publicKeys"`
+	// The ledger index at which the public keys were queried at.
+	LedgerIndex milestone.Index `json:"ledgerIndex"`
+}
+
+// migratedFundsResponse defines the response of a GET migration REST API call.
+type migratedFundsResponse struct {
+	// The hex encoded tail transaction hash of the migration bundle in the legacy network.
+	TailTransactionHash string `json:"tailTransactionHash"`
+	// The type of the target address (0=Ed25519).
+	AddressType byte `json:"addressType"`
+	// The hex encoded target address.
+	Address string `json:"address"`
+	// The amount of the deposit.
+	Deposit uint64 `json:"deposit"`
+	// The index of the milestone which included the receipt.
+	MilestoneIndex milestone.Index `json:"milestoneIndex"`
+	// The milestone index at which the funds were migrated in the legacy network.
+	MigratedAt milestone.Index `json:"migratedAt"`
+	// The hex encoded ID of the output that was created for the migrated funds.
+	OutputID string `json:"outputId"`
+	// The output that was created for the migrated funds.
+	Output *OutputResponse `json:"output"`
+}
+
+// addressMigrationsResponse defines the response of a GET address migrations REST API call.
+type addressMigrationsResponse struct {
+	// The type of the address (0=Ed25519).
+	AddressType byte `json:"addressType"`
+	// The hex encoded address.
+	Address string `json:"address"`
+	// The amount of migrations that were skipped.
+	Offset uint32 `json:"offset"`
+	// The maximum count of results that are returned by the node.
+	Limit uint32 `json:"limit"`
+	// The actual count of results that are returned.
+	Count uint32 `json:"count"`
+	// The funds migrated to this address.
+	Migrations []*migratedFundsResponse `json:"migrations"`
+	// The ledger index at which the migrations were queried at.
+	LedgerIndex milestone.Index `json:"ledgerIndex"`
+}
+
+// addressClusterResponse defines the response of a GET address cluster REST API call.
+type addressClusterResponse struct {
+	// The type of the address (0=Ed25519).
+	AddressType byte `json:"addressType"`
+	// The hex encoded address.
+	Address string `json:"address"`
+	// The ID of the cluster of the address.
+	ClusterID uint32 `json:"clusterId"`
+	// The amount of addresses in the cluster.
+	MemberCount int64 `json:"memberCount"`
+	// The sum of the balances of all addresses in the cluster.
+	Balance uint64 `json:"balance"`
+	// The label of the address.
+	Label *addressLabel `json:"label,omitempty"`
+	// The ledger index at which the cluster was computed.
+	LedgerIndex milestone.Index `json:"ledgerIndex"`
+}
+
+// clusterMember is an item of the clusterResponse.
+type clusterMember struct {
+	// The type of the address (0=Ed25519).
+	AddressType byte `json:"addressType"`
+	// The hex encoded address.
+	Address string `json:"address"`
+	// The balance of the address.
+	Balance uint64 `json:"balance"`
+	// The label of the address.
+	Label *addressLabel `json:"label,omitempty"`
+}
+
+// clusterResponse defines the response of a GET cluster REST API call.
+type clusterResponse struct {
+	// The ID of the cluster.
+	ClusterID uint32 `json:"clusterId"`
+	// The amount of addresses in the cluster.
+	MemberCount int64 `json:"memberCount"`
+	// The sum of the balances of all addresses in the cluster.
+	Balance uint64 `json:"balance"`
+	// The amount of members that were skipped.
+	Offset uint32 `json:"offset"`
+	// The maximum count of results that are returned by the node.
+	Limit uint32 `json:"limit"`
+	// The actual count of results that are returned.
+	Count uint32 `json:"count"`
+	// The addresses of the cluster sorted by address.
+	Members []*clusterMember `json:"members"`
+	// The ledger index at which the cluster was computed.
+	LedgerIndex milestone.Index `json:"ledgerIndex"`
+}
+
 // addressOutputsResponse defines the response of a GET outputs by address REST API call.
 type addressOutputsResponse struct {
 	// The type of the address (0=Ed25519).
@@ -158,16 +514,320 @@ type addressOutputsResponse struct {
 	Count uint32 `json:"count"`
 	// The output IDs (transaction hash + output index) of the outputs on this address.
 	OutputIDs []string `json:"outputIds"`
+	// The label of the address.
+	Label *addressLabel `json:"label,omitempty"`
 	// The ledger index at which these outputs where queried at.
 	LedgerIndex milestone.Index `json:"ledgerIndex"`
 }
 
+// richListEntry is an item of the richListResponse.
+type richListEntry struct {
+	// The position of the address in the rich list, starting at 1.
+	Rank int `json:"rank"`
+	// The type of the address (0=Ed25519).
+	AddressType byte `json:"addressType"`
+	// The hex encoded address.
+	Address string `json:"address"`
+	// The balance of the address.
+	Balance uint64 `json:"balance"`
+	// The share of the balance in the total supply.
+	ShareOfTotalSupply float64 `json:"shareOfTotalSupply"`
+	// The sum of the amounts of all dust allowance outputs on this address.
+	DustAllowanceBalance uint64 `json:"dustAllowanceBalance"`
+	// The amount of dust outputs on this address.
+	DustOutputCount int64 `json:"dustOutputCount"`
+	// Indicates if dust is allowed on this address.
+	DustAllowed bool `json:"dustAllowed"`
+	// The label of the address.
+	Label *addressLabel `json:"label,omitempty"`
+}
+
+// richListResponse defines the response of a GET rich list REST API call.
+type richListResponse struct {
+	// The total supply of tokens.
+	TotalSupply uint64 `json:"totalSupply"`
+	// The amount of addresses with a balance.
+	AddressCount int `json:"addressCount"`
+	// The amount of addresses that were skipped.
+	Offset uint32 `json:"offset"`
+	// The maximum count of results that are returned by the node.
+	Limit uint32 `json:"limit"`
+	// The actual count of results that are returned.
+	Count uint32 `json:"count"`
+	// The addresses with the highest balances.
+	Addresses []*richListEntry `json:"addresses"`
+	// The ledger index at which the rich list was computed.
+	LedgerIndex milestone.Index `json:"ledgerIndex"`
+}
+
+// attestationRequest defines the request of a POST attestations REST API call.
+type attestationRequest struct {
+	// The bech32 encoded addresses to attest.
+	Addresses []string `json:"addresses"`
+}
+
+// milestoneCommitment is the milestone commitment of the ledgerCommitmentResponse.
+type milestoneCommitment struct {
+	// The index of the milestone.
+	MilestoneIndex milestone.Index `json:"milestoneIndex"`
+	// The hex encoded hash over the created and consumed outputs and the treasury change of the milestone.
+	DiffHash string `json:"diffHash"`
+	// The hex encoded commitment chained over the diff hashes of all milestones since the anchor milestone.
+	Commitment string `json:"commitment"`
+	// The index of the well-known anchor milestone, whose commitment is the ledger commitment at the anchor milestone.
+	AnchorIndex milestone.Index `json:"anchorIndex"`
+}
+
+// ledgerCommitmentResponse defines the response of a GET ledger commitment REST API call.
+type ledgerCommitmentResponse struct {
+	// The hex encoded commitment over the sorted unspent outputs and the treasury.
+	Commitment string `json:"commitment"`
+	// The amount of unspent outputs.
+	UnspentOutputCount int64 `json:"unspentOutputCount"`
+	// The unspent treasury output.
+	Treasury *treasuryResponse `json:"treasury"`
+	// The milestone commitment at the ledger index or at the requested milestone.
+	MilestoneCommitment *milestoneCommitment `json:"milestoneCommitment"`
+	// The ledger index at which the commitment was computed.
+	LedgerIndex milestone.Index `json:"ledgerIndex"`
+}
+
+// balanceHistogramBucket is an item of the ledgerStatsResponse.
+type balanceHistogramBucket struct {
+	// The inclusive lower bound of the balances in this bucket.
+	LowerBound uint64 `json:"lowerBound"`
+	// The exclusive upper bound of the balances in this bucket. Omitted for the last bucket.
+	UpperBound *uint64 `json:"upperBound,omitempty"`
+	// The amount of addresses with a balance in this bucket.
+	AddressCount int64 `json:"addressCount"`
+	// The sum of the balances of the addresses in this bucket.
+	TotalBalance uint64 `json:"totalBalance"`
+}
+
+// ledgerStatsResponse defines the response of a GET ledger stats REST API call.
+type ledgerStatsResponse struct {
+	// The sum of the supply in outputs and the treasury.
+	TotalSupply uint64 `json:"totalSupply"`
+	// The sum of the amounts of all unspent outputs.
+	SupplyInOutputs uint64 `json:"supplyInOutputs"`
+	// The amount of the unspent treasury output.
+	TreasuryAmount uint64 `json:"treasuryAmount"`
+	// The amount of addresses with a non-zero balance.
+	AddressesWithBalanceCount int64 `json:"addressesWithBalanceCount"`
+	// The amount of unspent outputs.
+	UnspentOutputCount int64 `json:"unspentOutputCount"`
+	// The amount of unspent SigLockedSingleOutputs with an amount below the dust threshold.
+	DustOutputCount int64 `json:"dustOutputCount"`
+	// The amount of unspent SigLockedDustAllowanceOutputs.
+	DustAllowanceOutputCount int64 `json:"dustAllowanceOutputCount"`
+	// The distribution of the balances of all addresses with a non-zero balance.
+	BalanceHistogram []*balanceHistogramBucket `json:"balanceHistogram"`
+	// The ledger index at which the statistics were computed.
+	LedgerIndex milestone.Index `json:"ledgerIndex"`
+}
+
+// dormancyBucket is an item of the dormancyResponse.
+type dormancyBucket struct {
+	// The inclusive lower bound of the age of the outputs in this bucket in days.
+	MinAgeDays int `json:"minAgeDays"`
+	// The exclusive upper bound of the age of the outputs in this bucket in days. Omitted for the last bucket.
+	MaxAgeDays *int `json:"maxAgeDays,omitempty"`
+	// The amount of unspent outputs in this bucket.
+	OutputCount int64 `json:"outputCount"`
+	// The sum of the amounts of the unspent outputs in this bucket.
+	Amount uint64 `json:"amount"`
+	// The share of the amount in the total supply.
+	ShareOfTotalSupply float64 `json:"shareOfTotalSupply"`
+	// The amount of unspent outputs in this bucket that were created by the migration.
+	MigratedOutputCount int64 `json:"migratedOutputCount"`
+	// The sum of the amounts of the unspent outputs in this bucket that were created by the migration.
+	MigratedAmount uint64 `json:"migratedAmount"`
+}
+
+// dormancyResponse defines the response of a GET ledger dormancy REST API call.
+type dormancyResponse struct {
+	// The timestamp of the ledger milestone, the ages of the outputs are relative to this timestamp.
+	ReferenceTimestamp int64 `json:"referenceTimestamp"`
+	// The pruning index of the database. The age of outputs created at or before the pruning index is a lower bound.
+	PruningIndex milestone.Index `json:"pruningIndex"`
+	// The amount of unspent outputs.
+	OutputCount int64 `json:"outputCount"`
+	// The sum of the amounts of all unspent outputs.
+	Amount uint64 `json:"amount"`
+	// The sum of the amounts of all unspent outputs that were created by the migration and never moved.
+	MigratedAmount uint64 `json:"migratedAmount"`
+	// The unspent outputs bucketed by age.
+	Buckets []*dormancyBucket `json:"buckets"`
+	// The ledger index at which the summary was computed.
+	LedgerIndex milestone.Index `json:"ledgerIndex"`
+}
+
+// dormantOutput is an item of the dormantOutputsResponse.
+type dormantOutput struct {
+	// The hex encoded output ID.
+	OutputID string `json:"outputId"`
+	// The type of the address (0=Ed25519).
+	AddressType byte `json:"addressType"`
+	// The hex encoded address.
+	Address string `json:"address"`
+	// The amount of the output.
+	Amount uint64 `json:"amount"`
+	// The index of the milestone that created the output.
+	// Outputs created at or before the pruning index are assigned to the pruning index.
+	CreatedAtMilestoneIndex milestone.Index `json:"createdAtMilestoneIndex"`
+	// The timestamp of the milestone that created the output. Omitted for outputs created at or before the pruning index.
+	CreatedAtMilestoneTimestamp *int64 `json:"createdAtMilestoneTimestamp,omitempty"`
+	// Whether the output was created by the migration.
+	Migrated bool `json:"migrated"`
+	// The label of the address.
+	Label *addressLabel `json:"label,omitempty"`
+}
+
+// dormantOutputsResponse defines the response of a GET ledger dormant outputs REST API call.
+type dormantOutputsResponse struct {
+	// The minimum age of the outputs in days.
+	MinAgeDays uint32 `json:"minAgeDays"`
+	// The minimum amount of the outputs.
+	MinAmount uint64 `json:"minAmount"`
+	// The amount of outputs that were skipped.
+	Offset uint32 `json:"offset"`
+	// The maximum count of results that are returned by the node.
+	Limit uint32 `json:"limit"`
+	// The actual count of results that are returned.
+	Count uint32 `json:"count"`
+	// The unspent outputs sorted by age (oldest first).
+	Outputs []*dormantOutput `json:"outputs"`
+	// The ledger index at which the outputs were queried at.
+	LedgerIndex milestone.Index `json:"ledgerIndex"`
+}
+
+// dailyStatsItem is an item of the dailyStatsResponse.
+type dailyStatsItem struct {
+	// The day (UTC, YYYY-MM-DD).
+	Date string `json:"date"`
+	// The amount of milestones issued on this day.
+	MilestoneCount int64 `json:"milestoneCount"`
+	// The amount of referenced transactions that were included in the ledger.
+	TransactionCount int64 `json:"transactionCount"`
+	// The amount of referenced transactions that were conflicting.
+	ConflictingTransactionCount int64 `json:"conflictingTransactionCount"`
+	// The amount of referenced messages with an indexation payload.
+	IndexationMessageCount int64 `json:"indexationMessageCount"`
+	// The sum of the amounts of all outputs consumed by the milestones of this day.
+	ValueMoved uint64 `json:"valueMoved"`
+	// The amount of distinct addresses of all outputs created or consumed by the milestones of this day.
+	ActiveAddressCount int64 `json:"activeAddressCount"`
+	// The amount of addresses that received their first output on this day.
+	NewAddressCount int64 `json:"newAddressCount"`
+}
+
+// dailyStatsResponse defines the response of a GET daily stats REST API call.
+type dailyStatsResponse struct {
+	// The first day of the range (UTC, YYYY-MM-DD).
+	From string `json:"from"`
+	// The last day of the range (UTC, YYYY-MM-DD).
+	To string `json:"to"`
+	// The statistics of all days in the range with network activity.
+	Days []*dailyStatsItem `json:"days"`
+}
+
 // treasuryResponse defines the response of a GET treasury REST API call.
 type treasuryResponse struct {
 	MilestoneID string `json:"milestoneId"`
 	Amount      uint64 `json:"amount"`
 }
 
+// addressLabel is the label of an address maintained in the label files.
+type addressLabel struct {
+	// The name of the owner of the address.
+	Name string `json:"name"`
+	// The category of the address.
+	Category string `json:"category"`
+	// An optional note about the address.
+	Note string `json:"note,omitempty"`
+}
+
+// counterparty is another address that is part of a transaction in the transactionHistoryItem.
+type counterparty struct {
+	// The type of the address (0=Ed25519).
+	AddressType byte `json:"addressType"`
+	// The hex encoded address.
+	Address string `json:"address"`
+	// The label of the address.
+	Label *addressLabel `json:"label,omitempty"`
+}
+
+// labeledAddress is an item of the labelsResponse.
+type labeledAddress struct {
+	// The type of the address (0=Ed25519).
+	AddressType byte `json:"addressType"`
+	// The hex encoded address.
+	Address string `json:"address"`
+	// The bech32 encoded address.
+	Bech32Address string `json:"bech32Address"`
+	// The name of the owner of the address.
+	Name string `json:"name"`
+	// The category of the address.
+	Category string `json:"category"`
+	// An optional note about the address.
+	Note string `json:"note,omitempty"`
+	// The label file the label was loaded from.
+	Source string `json:"source"`
+}
+
+// labelsResponse defines the response of a GET labels REST API call.
+type labelsResponse struct {
+	// The query the labels were searched with.
+	Query string `json:"query"`
+	// The maximum count of results that are returned by the node.
+	MaxResults uint32 `json:"maxResults"`
+	// The actual count of results that are returned.
+	Count uint32 `json:"count"`
+	// The labels that match the query.
+	Labels []*labeledAddress `json:"labels"`
+}
+
+// treasuryReceipt is the receipt that created a treasuryHistoryItem.
+type treasuryReceipt struct {
+	// The index of the milestone which included the receipt.
+	MilestoneIndex milestone.Index `json:"milestoneIndex"`
+	// The milestone index at which the funds were migrated in the legacy network.
+	MigratedAt uint32 `json:"migratedAt"`
+	// Whether this receipt is the final one for the migrated at index.
+	Final bool `json:"final"`
+	// The amount of migrated funds entries in the receipt.
+	FundsCount int `json:"fundsCount"`
+	// The sum of the deposits of all migrated funds entries in the receipt.
+	MigratedAmount uint64 `json:"migratedAmount"`
+}
+
+// treasuryHistoryItem is an item of the treasuryHistoryResponse.
+type treasuryHistoryItem struct {
+	// The hex encoded ID of the milestone which generated the treasury output.
+	MilestoneID string `json:"milestoneId"`
+	// The amount residing on the treasury output.
+	Amount uint64 `json:"amount"`
+	// Whether the treasury output is spent.
+	Spent bool `json:"isSpent"`
+	// The index of the milestone that created the treasury output.
+	// Omitted if the output was part of the genesis snapshot or the milestone diff is not available.
+	CreatedByMilestoneIndex *milestone.Index `json:"createdByMilestoneIndex,omitempty"`
+	// The index of the milestone that consumed the treasury output.
+	ConsumedByMilestoneIndex *milestone.Index `json:"consumedByMilestoneIndex,omitempty"`
+	// The receipt that created the treasury output.
+	Receipt *treasuryReceipt `json:"receipt,omitempty"`
+}
+
+// treasuryHistoryResponse defines the response of a GET treasury history REST API call.
+type treasuryHistoryResponse struct {
+	// The actual count of treasury outputs that are returned.
+	Count uint32 `json:"count"`
+	// All spent and unspent treasury outputs sorted by the milestone that created them.
+	History []*treasuryHistoryItem `json:"history"`
+	// The ledger index at which the history was queried at.
+	LedgerIndex milestone.Index `json:"ledgerIndex"`
+}
+
 // transactionHistoryItem is an item of the transactionHistoryResponse.
 type transactionHistoryItem struct {
 	// The hex encoded message ID of the message in which the transaction payload was included.
@@ -188,6 +848,8 @@ type transactionHistoryItem struct {
 	OutputsCount int `json:"outputsCount"`
 	// The balance change of the address the history was queried for.
 	AddressBalanceChange int64 `json:"addressBalanceChange"`
+	// The other addresses that are part of the inputs and outputs of the transaction.
+	Counterparties []*counterparty `json:"counterparties"`
 }
 
 // transactionHistoryResponse defines the response of a GET address transaction history REST API call.
@@ -200,8 +862,147 @@ type transactionHistoryResponse struct {
 	MaxResults uint32 `json:"maxResults"`
 	// The actual count of results that are returned.
 	Count uint32 `json:"count"`
+	// The label of the address.
+	Label *addressLabel `json:"label,omitempty"`
 	// The transaction history of this address.
 	History []*transactionHistoryItem `json:"history"`
 	// The ledger index at which the history was queried at.
 	LedgerIndex milestone.Index `json:"ledgerIndex"`
 }
+
+// transferAmount is the sum of the amounts of the inputs or outputs of an address in a largestTransaction.
+type transferAmount struct {
+	// The type of the address (0=Ed25519).
+	AddressType byte `json:"addressType"`
+	// The hex encoded address.
+	Address string `json:"address"`
+	// The sum of the amounts.
+	Amount uint64 `json:"amount"`
+	// The label of the address.
+	Label *addressLabel `json:"label,omitempty"`
+}
+
+// largestTransaction is an item of the largestTransactionsResponse.
+type largestTransaction struct {
+	// The hex encoded transaction id.
+	TransactionID string `json:"transactionId"`
+	// The index of the milestone that included the transaction.
+	MilestoneIndex milestone.Index `json:"milestoneIndex"`
+	// The timestamp of the milestone that included the transaction.
+	MilestoneTimestamp int64 `json:"milestoneTimestamp"`
+	// The sum of the amounts of all consumed outputs.
+	TotalValue uint64 `json:"totalValue"`
+	// The sum of the amounts sent to addresses that are not part of the inputs (without the remainder).
+	NetValueMoved uint64 `json:"netValueMoved"`
+	// The consumed amounts per input address.
+	Inputs []*transferAmount `json:"inputs"`
+	// The created amounts per output address.
+	Outputs []*transferAmount `json:"outputs"`
+}
+
+// largestTransactionsResponse defines the response of a GET largest transactions REST API call.
+type largestTransactionsResponse struct {
+	// The first milestone index of the range.
+	From milestone.Index `json:"from"`
+	// The last milestone index of the range.
+	To milestone.Index `json:"to"`
+	// The amount of transactions that were skipped.
+	Offset uint32 `json:"offset"`
+	// The maximum count of results that are returned by the node.
+	Limit uint32 `json:"limit"`
+	// The actual count of results that are returned.
+	Count uint32 `json:"count"`
+	// The transactions sorted by their net value moved (descending).
+	Transactions []*largestTransaction `json:"transactions"`
+}
+
+const (
+	// ledgerDiffStreamItemSummary is the type of the ledgerDiffStreamItem that contains the ledgerDiffResponse without lists.
+	ledgerDiffStreamItemSummary = "summary"
+	// ledgerDiffStreamItemCreated is the type of the ledgerDiffStreamItem that contains a created ledgerDiffOutput.
+	ledgerDiffStreamItemCreated = "created"
+	// ledgerDiffStreamItemConsumed is the type of the ledgerDiffStreamItem that contains a consumed ledgerDiffOutput.
+	ledgerDiffStreamItemConsumed = "consumed"
+	// ledgerDiffStreamItemBalanceChange is the type of the ledgerDiffStreamItem that contains a ledgerDiffBalanceChange.
+	ledgerDiffStreamItemBalanceChange = "balanceChange"
+)
+
+// ledgerDiffOutput is a created or consumed output of the ledgerDiffResponse.
+type ledgerDiffOutput struct {
+	// The hex encoded output ID.
+	OutputID string `json:"outputId"`
+	// The type of the address (0=Ed25519).
+	AddressType byte `json:"addressType"`
+	// The hex encoded address.
+	Address string `json:"address"`
+	// The amount of the output.
+	Amount uint64 `json:"amount"`
+	// The hex encoded ID of the transaction that consumed the output. Omitted for created outputs.
+	TransactionIDSpent string `json:"transactionIdSpent,omitempty"`
+	// The index of the milestone that consumed the output. Omitted for created outputs.
+	MilestoneIndexSpent *milestone.Index `json:"milestoneIndexSpent,omitempty"`
+}
+
+// ledgerDiffBalanceChange is the net balance change of an address in the ledgerDiffResponse.
+type ledgerDiffBalanceChange struct {
+	// The type of the address (0=Ed25519).
+	AddressType byte `json:"addressType"`
+	// The hex encoded address.
+	Address string `json:"address"`
+	// The net balance change of the address.
+	Change int64 `json:"change"`
+	// The label of the address.
+	Label *addressLabel `json:"label,omitempty"`
+}
+
+// ledgerDiffTreasury is the movement of the treasury in the ledgerDiffResponse and the milestoneUTXOChangesExpandedResponse.
+type ledgerDiffTreasury struct {
+	// The hex encoded ID of the milestone that created the treasury output that was unspent at "from".
+	FromMilestoneID string `json:"fromMilestoneId"`
+	// The amount of the treasury at "from".
+	FromAmount uint64 `json:"fromAmount"`
+	// The hex encoded ID of the milestone that created the treasury output that is unspent at "to".
+	ToMilestoneID string `json:"toMilestoneId"`
+	// The amount of the treasury at "to".
+	ToAmount uint64 `json:"toAmount"`
+	// The change of the treasury amount.
+	Change int64 `json:"change"`
+}
+
+// ledgerDiffResponse defines the response of a GET ledger diff REST API call.
+type ledgerDiffResponse struct {
+	// The milestone index of the ledger state the diff starts at.
+	From milestone.Index `json:"from"`
+	// The milestone index of the ledger state the diff ends at.
+	To milestone.Index `json:"to"`
+	// The amount of outputs created after "from" that are still unspent at "to".
+	CreatedCount uint32 `json:"createdCount"`
+	// The sum of the amounts of the created outputs.
+	CreatedAmount uint64 `json:"createdAmount"`
+	// The amount of outputs that existed at "from" and were consumed until "to".
+	ConsumedCount uint32 `json:"consumedCount"`
+	// The sum of the amounts of the consumed outputs.
+	ConsumedAmount uint64 `json:"consumedAmount"`
+	// The amount of addresses with a non-zero net balance change.
+	BalanceChangesCount uint32 `json:"balanceChangesCount"`
+	// The movement of the treasury. Omitted if the treasury didn't change in the range.
+	Treasury *ledgerDiffTreasury `json:"treasury,omitempty"`
+	// The amount of items that were skipped in each list.
+	Offset uint32 `json:"offset"`
+	// The maximum count of items that are returned in each list.
+	Limit uint32 `json:"limit"`
+	// The created outputs sorted by output ID.
+	Created []*ledgerDiffOutput `json:"created,omitempty"`
+	// The consumed outputs sorted by output ID.
+	Consumed []*ledgerDiffOutput `json:"consumed,omitempty"`
+	// The net balance changes sorted by address.
+	BalanceChanges []*ledgerDiffBalanceChange `json:"balanceChanges,omitempty"`
+}
+
+// ledgerDiffStreamItem is a line of a streamed ledger diff.
+type ledgerDiffStreamItem struct {
+	// The type of the item ("summary", "created", "consumed" or "balanceChange").
+	Type string `json:"type"`
+	// The item.
+	Data interface{} `json:"data"`
+}
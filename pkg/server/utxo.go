@@ -12,6 +12,7 @@ import (
 
 	"github.com/iotaledger/hive.go/kvstore"
 	"github.com/iotaledger/hive.go/serializer"
+	"github.com/iotaledger/inx-api-core-v1/pkg/database"
 	"github.com/iotaledger/inx-api-core-v1/pkg/milestone"
 	"github.com/iotaledger/inx-api-core-v1/pkg/restapi"
 	"github.com/iotaledger/inx-api-core-v1/pkg/utxo"
@@ -70,6 +71,11 @@ func (s *DatabaseServer) outputByID(c echo.Context) (*OutputResponse, error) {
 		return nil, err
 	}
 
+	return s.outputResponseByOutputID(outputID)
+}
+
+// outputResponseByOutputID returns the output with the given ID and its spent status.
+func (s *DatabaseServer) outputResponseByOutputID(outputID *iotago.UTXOInputID) (*OutputResponse, error) {
 	ledgerIndex := s.UTXOManager.ReadLedgerIndex()
 
 	output, err := s.UTXOManager.ReadOutputByOutputID(outputID)
@@ -87,7 +93,13 @@ func (s *DatabaseServer) outputByID(c echo.Context) (*OutputResponse, error) {
 	}
 
 	if isUnspent {
-		return newOutputResponse(output, ledgerIndex)
+		response, err := newOutputResponse(output, ledgerIndex)
+		if err != nil {
+			return nil, err
+		}
+		response.Label = s.addressLabel(output.Address())
+
+		return response, nil
 	}
 
 	spent, err := s.UTXOManager.ReadSpentForOutput(output)
@@ -99,7 +111,13 @@ func (s *DatabaseServer) outputByID(c echo.Context) (*OutputResponse, error) {
 		return nil, errors.WithMessagef(echo.ErrInternalServerError, "reading output failed: %s, error: %s", outputID.ToHex(), err)
 	}
 
-	return newSpentResponse(spent, ledgerIndex)
+	response, err := newSpentResponse(spent, ledgerIndex)
+	if err != nil {
+		return nil, err
+	}
+	response.Label = s.addressLabel(output.Address())
+
+	return response, nil
 }
 
 //nolint:interfacer // false positive
@@ -114,6 +132,7 @@ func (s *DatabaseServer) ed25519Balance(address *iotago.Ed25519Address) (*addres
 		Address:     address.String(),
 		Balance:     balance,
 		DustAllowed: dustAllowed,
+		Label:       s.addressLabel(address),
 		LedgerIndex: ledgerIndex,
 	}, nil
 }
@@ -184,6 +203,7 @@ func (s *DatabaseServer) outputsResponse(address iotago.Address, includeSpent bo
 		MaxResults:  uint32(maxResults),
 		Count:       uint32(len(outputIDs)),
 		OutputIDs:   outputIDs,
+		Label:       s.addressLabel(address),
 		LedgerIndex: ledgerIndex,
 	}, nil
 }
@@ -212,6 +232,87 @@ func (s *DatabaseServer) outputIDsByAddress(c echo.Context, address iotago.Addre
 	return s.outputsResponse(address, includeSpent, filteredType, maxResults)
 }
 
+func (s *DatabaseServer) dustAllowanceByAddress(c echo.Context, address iotago.Address) (*addressDustAllowanceResponse, error) {
+	maxResults := s.maxResultsFromContext(c)
+
+	dustAllowanceBalance, dustOutputCount, ledgerIndex, err := s.UTXOManager.AddressDustBalance(address)
+	if err != nil {
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "reading address dust allowance failed: %s, error: %s", address, err)
+	}
+
+	unspentOutputs, err := s.UTXOManager.UnspentOutputs(utxo.FilterAddress(address), utxo.FilterOutputType(iotago.OutputSigLockedDustAllowanceOutput), utxo.MaxResultCount(maxResults))
+	if err != nil {
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "reading dust allowance outputs failed: %s, error: %s", address, err)
+	}
+
+	dustAllowanceOutputs := make([]*dustAllowanceOutput, len(unspentOutputs))
+	for i, unspentOutput := range unspentOutputs {
+		dustAllowanceOutputs[i] = &dustAllowanceOutput{
+			OutputID: unspentOutput.OutputID().ToHex(),
+			Amount:   unspentOutput.Amount(),
+		}
+	}
+
+	maxDustOutputCount := utxo.MaxDustOutputs(dustAllowanceBalance)
+
+	remainingDustOutputCount := maxDustOutputCount - dustOutputCount
+	if remainingDustOutputCount < 0 {
+		remainingDustOutputCount = 0
+	}
+
+	return &addressDustAllowanceResponse{
+		AddressType:              address.Type(),
+		Address:                  address.String(),
+		DustAllowanceBalance:     dustAllowanceBalance,
+		DustOutputCount:          dustOutputCount,
+		MaxDustOutputCount:       maxDustOutputCount,
+		RemainingDustOutputCount: remainingDustOutputCount,
+		DustAllowed:              maxDustOutputCount > dustOutputCount,
+		MaxResults:               uint32(maxResults),
+		Count:                    uint32(len(dustAllowanceOutputs)),
+		DustAllowanceOutputs:     dustAllowanceOutputs,
+		LedgerIndex:              ledgerIndex,
+	}, nil
+}
+
+func (s *DatabaseServer) publicKeyByAddress(_ echo.Context, address iotago.Address) (*addressPublicKeyResponse, error) {
+	if err := s.checkIndexReady(database.IndexStorePrefixPublicKeys); err != nil {
+		return nil, err
+	}
+
+	ed25519Address, ok := address.(*iotago.Ed25519Address)
+	if !ok {
+		return nil, errors.WithMessagef(restapi.ErrInvalidParameter, "invalid address type: %d", address.Type())
+	}
+
+	reveals, err := s.Database.AddressPublicKeys(ed25519Address)
+	if err != nil {
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "reading public keys failed: %s, error: %s", address, err)
+	}
+
+	if len(reveals) == 0 {
+		return nil, errors.WithMessagef(echo.ErrNotFound, "public key not revealed for address: %s", address)
+	}
+
+	publicKeys := make([]*publicKeyReveal, len(reveals))
+	for i, reveal := range reveals {
+		publicKeys[i] = &publicKeyReveal{
+			PublicKey:                    hex.EncodeToString(reveal.PublicKey),
+			TransactionID:                hex.EncodeToString(reveal.TransactionID[:]),
+			MessageID:                    reveal.MessageID.ToHex(),
+			ReferencedByMilestoneIndex:   reveal.MilestoneIndex,
+			MilestoneTimestampReferenced: reveal.MilestoneTimestamp,
+		}
+	}
+
+	return &addressPublicKeyResponse{
+		AddressType: address.Type(),
+		Address:     address.String(),
+		PublicKeys:  publicKeys,
+		LedgerIndex: s.UTXOManager.ReadLedgerIndex(),
+	}, nil
+}
+
 func (s *DatabaseServer) treasury(_ echo.Context) (*treasuryResponse, error) {
 
 	treasuryOutput, err := s.UTXOManager.UnspentTreasuryOutput()
@@ -224,3 +325,58 @@ func (s *DatabaseServer) treasury(_ echo.Context) (*treasuryResponse, error) {
 		Amount:      treasuryOutput.Amount,
 	}, nil
 }
+
+func newTreasuryReceipt(rt *utxo.ReceiptTuple) *treasuryReceipt {
+	if rt == nil {
+		return nil
+	}
+
+	var migratedAmount uint64
+	for _, entry := range rt.Receipt.Funds {
+		//nolint:forcetypeassert
+		migratedAmount += entry.(*iotago.MigratedFundsEntry).Deposit
+	}
+
+	return &treasuryReceipt{
+		MilestoneIndex: rt.MilestoneIndex,
+		MigratedAt:     rt.Receipt.MigratedAt,
+		Final:          rt.Receipt.Final,
+		FundsCount:     len(rt.Receipt.Funds),
+		MigratedAmount: migratedAmount,
+	}
+}
+
+func (s *DatabaseServer) treasuryHistory(_ echo.Context) (*treasuryHistoryResponse, error) {
+	entries, err := s.UTXOManager.TreasuryHistory()
+	if err != nil {
+		return nil, errors.WithMessagef(echo.ErrInternalServerError, "reading treasury history failed, error: %s", err)
+	}
+
+	history := make([]*treasuryHistoryItem, len(entries))
+	for i, entry := range entries {
+		item := &treasuryHistoryItem{
+			MilestoneID: hex.EncodeToString(entry.TreasuryOutput.MilestoneID[:]),
+			Amount:      entry.TreasuryOutput.Amount,
+			Spent:       entry.TreasuryOutput.Spent,
+			Receipt:     newTreasuryReceipt(entry.Receipt),
+		}
+
+		if entry.CreatedByMilestoneIndex != 0 {
+			createdByMilestoneIndex := entry.CreatedByMilestoneIndex
+			item.CreatedByMilestoneIndex = &createdByMilestoneIndex
+		}
+
+		if entry.ConsumedByMilestoneIndex != 0 {
+			consumedByMilestoneIndex := entry.ConsumedByMilestoneIndex
+			item.ConsumedByMilestoneIndex = &consumedByMilestoneIndex
+		}
+
+		history[i] = item
+	}
+
+	return &treasuryHistoryResponse{
+		Count:       uint32(len(history)),
+		History:     history,
+		LedgerIndex: s.UTXOManager.ReadLedgerIndex(),
+	}, nil
+}
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pkg/errors"
+)
+
+// boundedWorkerPool limits the amount of heavy computations that run at the same time.
+// Requests wait in a queue for a free worker, and are rejected if the queue is full.
+type boundedWorkerPool struct {
+	workers   chan struct{}
+	queued    atomic.Int32
+	queueSize int32
+}
+
+func newBoundedWorkerPool(workerCount int, queueSize int) *boundedWorkerPool {
+	if workerCount < 1 {
+		workerCount = 1
+	}
+
+	return &boundedWorkerPool{
+		workers:   make(chan struct{}, workerCount),
+		queueSize: int32(queueSize),
+	}
+}
+
+// Run executes the given function as soon as a worker is free.
+// It returns an echo.ErrServiceUnavailable error if the queue is full or the context is done while waiting.
+func (p *boundedWorkerPool) Run(ctx context.Context, f func()) error {
+	select {
+	case p.workers <- struct{}{}:
+		// a worker was free, no need to queue the request
+	default:
+		if p.queued.Add(1) > p.queueSize {
+			p.queued.Add(-1)
+
+			return errors.WithMessage(echo.ErrServiceUnavailable, "too many requests in the queue, try again later")
+		}
+
+		select {
+		case p.workers <- struct{}{}:
+			p.queued.Add(-1)
+		case <-ctx.Done():
+			p.queued.Add(-1)
+
+			return errors.WithMessagef(echo.ErrServiceUnavailable, "request aborted while waiting in the queue: %s", ctx.Err())
+		}
+	}
+	defer func() { <-p.workers }()
+
+	f()
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package toolset
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/iotaledger/inx-api-core-v1/pkg/attestation"
+)
+
+func readJSONFile(path string, v interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+func attestationVerify(args []string) error {
+
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	bundlePathFlag := fs.String(FlagToolBundlePath, "", "the path to the attestation bundle file")
+	coordinatorKeysPathFlag := fs.String(FlagToolCoordinatorKeysPath, "", "the path to the JSON file with the coordinator public keys (\"milestonePublicKeyCount\" and \"publicKeyRanges\")")
+	outputPathFlag := fs.String(FlagToolOutputPath, "", "the path to the JSON report file (the report is printed to stdout if empty)")
+
+	fs.Usage = func() {
+		_, _ = fmt.Fprintf(os.Stderr, "Usage of %s:\n", ToolAttestationVerify)
+		fs.PrintDefaults()
+		_, _ = fmt.Fprintf(os.Stderr, "\nexample: %s --%s %s --%s %s\n",
+			ToolAttestationVerify,
+			FlagToolBundlePath,
+			"attestation.json",
+			FlagToolCoordinatorKeysPath,
+			"coordinator_keys.json")
+	}
+
+	if err := parseFlagSet(fs, args); err != nil {
+		return err
+	}
+
+	if *bundlePathFlag == "" {
+		return fmt.Errorf("'%s' not specified", FlagToolBundlePath)
+	}
+
+	if *coordinatorKeysPathFlag == "" {
+		return fmt.Errorf("'%s' not specified", FlagToolCoordinatorKeysPath)
+	}
+
+	bundle := &attestation.Bundle{}
+	if err := readJSONFile(*bundlePathFlag, bundle); err != nil {
+		return fmt.Errorf("reading the attestation bundle failed: %w", err)
+	}
+
+	keys := &attestation.CoordinatorKeys{}
+	if err := readJSONFile(*coordinatorKeysPathFlag, keys); err != nil {
+		return fmt.Errorf("reading the coordinator public keys failed: %w", err)
+	}
+
+	report := attestation.Verify(bundle, keys)
+
+	reportJSON, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling the report failed: %w", err)
+	}
+
+	if *outputPathFlag == "" {
+		fmt.Println(string(reportJSON))
+	} else {
+		//nolint:gosec // the report is public
+		if err := os.WriteFile(*outputPathFlag, reportJSON, 0o644); err != nil {
+			return fmt.Errorf("writing the report failed: %w", err)
+		}
+
+		_, _ = fmt.Fprintf(os.Stderr, "verified the creation of %d/%d outputs of %d addresses (%d tokens proven, unspent state not verified), %d issues found, report written to %s\n", report.ProvenOutputCount, report.OutputCount, report.AddressCount, report.ProvenAmount, len(report.Issues), *outputPathFlag)
+	}
+
+	if !report.Valid {
+		return fmt.Errorf("the attestation verification found %d issues", len(report.Issues))
+	}
+
+	return nil
+}
@@ -0,0 +1,351 @@
+package toolset
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math/bits"
+	"os"
+	"sort"
+	"strings"
+
+	flag "github.com/spf13/pflag"
+
+	hivedb "github.com/iotaledger/hive.go/kvstore/database"
+	"github.com/iotaledger/inx-api-core-v1/pkg/database/engine"
+	"github.com/iotaledger/inx-api-core-v1/pkg/milestone"
+	"github.com/iotaledger/inx-api-core-v1/pkg/utxo"
+)
+
+const (
+	GraphFormatCSV     = "csv"
+	GraphFormatGraphML = "graphml"
+)
+
+// graphNode is an address in the transfer graph.
+type graphNode struct {
+	address string
+	// the sum of the amounts sent to other addresses
+	sent uint64
+	// the sum of the amounts received from other addresses
+	received uint64
+}
+
+// graphEdgeKey identifies the edge between two addresses.
+type graphEdgeKey struct {
+	source string
+	target string
+}
+
+// graphEdge is the aggregate of all transfers from the source to the target address.
+type graphEdge struct {
+	Source string
+	Target string
+	// the amount of transfers from the source to the target address
+	Count uint64
+	// the sum of the amounts attributed to the source address
+	Amount              uint64
+	FirstMilestoneIndex uint32
+	LastMilestoneIndex  uint32
+}
+
+// transferGraph is the aggregated value-transfer graph between addresses.
+type transferGraph struct {
+	nodes map[string]*graphNode
+	edges map[graphEdgeKey]*graphEdge
+}
+
+func newTransferGraph() *transferGraph {
+	return &transferGraph{
+		nodes: make(map[string]*graphNode),
+		edges: make(map[graphEdgeKey]*graphEdge),
+	}
+}
+
+func (g *transferGraph) node(address string) *graphNode {
+	node, exists := g.nodes[address]
+	if !exists {
+		node = &graphNode{address: address}
+		g.nodes[address] = node
+	}
+
+	return node
+}
+
+// attributeAmount splits the amount across the inputs in proportion to their share of the total input value.
+// The shares are rounded down and the remaining units are assigned to the inputs with the largest remainders,
+// so the shares always sum up to the amount.
+func attributeAmount(amount uint64, inputs []*utxo.TransferAmount, totalValue uint64) []uint64 {
+	shares := make([]uint64, len(inputs))
+	remainders := make([]uint64, len(inputs))
+
+	distributed := uint64(0)
+	for i, input := range inputs {
+		// amount * input.Amount / totalValue without overflow, the result is never bigger than amount
+		hi, lo := bits.Mul64(amount, input.Amount)
+		shares[i], remainders[i] = bits.Div64(hi, lo, totalValue)
+		distributed += shares[i]
+	}
+
+	order := make([]int, len(inputs))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return remainders[order[i]] > remainders[order[j]]
+	})
+
+	// the sum of the rounded down shares is less than the amount by at most the amount of inputs
+	for i := 0; distributed < amount; i++ {
+		shares[order[i]]++
+		distributed++
+	}
+
+	return shares
+}
+
+// addTransfer adds the value flows of the transfer to the graph.
+// The amount sent to each output address that is not an input address (the remainder) is attributed
+// to the input addresses in proportion to their share of the total input value, see attributeAmount.
+// Every edge between an input and an output address of the transfer counts the transfer once.
+func (g *transferGraph) addTransfer(transfer *utxo.Transfer) {
+	if transfer.TotalValue == 0 {
+		return
+	}
+
+	inputAddresses := make(map[string]struct{}, len(transfer.Inputs))
+	for _, input := range transfer.Inputs {
+		inputAddresses[input.Address.String()] = struct{}{}
+	}
+
+	// the amounts of the edges of this transfer
+	transferEdges := make(map[graphEdgeKey]uint64)
+	transferEdgeKeys := make([]graphEdgeKey, 0)
+
+	for _, output := range transfer.Outputs {
+		target := output.Address.String()
+		if _, isInput := inputAddresses[target]; isInput {
+			continue
+		}
+
+		for i, amount := range attributeAmount(output.Amount, transfer.Inputs, transfer.TotalValue) {
+			key := graphEdgeKey{source: transfer.Inputs[i].Address.String(), target: target}
+			if _, exists := transferEdges[key]; !exists {
+				transferEdgeKeys = append(transferEdgeKeys, key)
+			}
+			transferEdges[key] += amount
+		}
+	}
+
+	for _, key := range transferEdgeKeys {
+		amount := transferEdges[key]
+
+		edge, exists := g.edges[key]
+		if !exists {
+			edge = &graphEdge{
+				Source:              key.source,
+				Target:              key.target,
+				FirstMilestoneIndex: uint32(transfer.MilestoneIndex),
+			}
+			g.edges[key] = edge
+		}
+		edge.Count++
+		edge.Amount += amount
+		edge.LastMilestoneIndex = uint32(transfer.MilestoneIndex)
+
+		g.node(key.source).sent += amount
+		g.node(key.target).received += amount
+	}
+}
+
+// sortedNodes returns the nodes sorted by address.
+func (g *transferGraph) sortedNodes() []*graphNode {
+	nodes := make([]*graphNode, 0, len(g.nodes))
+	for _, node := range g.nodes {
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].address < nodes[j].address
+	})
+
+	return nodes
+}
+
+// sortedEdges returns the edges sorted by source and target address.
+func (g *transferGraph) sortedEdges() []*graphEdge {
+	edges := make([]*graphEdge, 0, len(g.edges))
+	for _, edge := range g.edges {
+		edges = append(edges, edge)
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].Source != edges[j].Source {
+			return edges[i].Source < edges[j].Source
+		}
+
+		return edges[i].Target < edges[j].Target
+	})
+
+	return edges
+}
+
+func (g *transferGraph) writeCSV(w io.Writer) error {
+	bufferedWriter := bufio.NewWriter(w)
+
+	if _, err := bufferedWriter.WriteString("source,target,count,amount,firstMilestoneIndex,lastMilestoneIndex\n"); err != nil {
+		return err
+	}
+
+	for _, edge := range g.sortedEdges() {
+		if _, err := fmt.Fprintf(bufferedWriter, "%s,%s,%d,%d,%d,%d\n", edge.Source, edge.Target, edge.Count, edge.Amount, edge.FirstMilestoneIndex, edge.LastMilestoneIndex); err != nil {
+			return err
+		}
+	}
+
+	return bufferedWriter.Flush()
+}
+
+func (g *transferGraph) writeGraphML(w io.Writer) error {
+	bufferedWriter := bufio.NewWriter(w)
+
+	// the addresses are hex encoded, so they don't need to be escaped
+	header := `<?xml version="1.0" encoding="UTF-8"?>
+<graphml xmlns="http://graphml.graphdrawing.org/xmlns" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:schemaLocation="http://graphml.graphdrawing.org/xmlns http://graphml.graphdrawing.org/xmlns/1.0/graphml.xsd">
+  <key id="sent" for="node" attr.name="sent" attr.type="long"/>
+  <key id="received" for="node" attr.name="received" attr.type="long"/>
+  <key id="count" for="edge" attr.name="count" attr.type="long"/>
+  <key id="amount" for="edge" attr.name="amount" attr.type="long"/>
+  <key id="firstMilestoneIndex" for="edge" attr.name="firstMilestoneIndex" attr.type="long"/>
+  <key id="lastMilestoneIndex" for="edge" attr.name="lastMilestoneIndex" attr.type="long"/>
+  <graph id="transfers" edgedefault="directed">
+`
+	if _, err := bufferedWriter.WriteString(header); err != nil {
+		return err
+	}
+
+	for _, node := range g.sortedNodes() {
+		if _, err := fmt.Fprintf(bufferedWriter, "    <node id=\"%s\"><data key=\"sent\">%d</data><data key=\"received\">%d</data></node>\n", node.address, node.sent, node.received); err != nil {
+			return err
+		}
+	}
+
+	for _, edge := range g.sortedEdges() {
+		if _, err := fmt.Fprintf(bufferedWriter, "    <edge source=\"%s\" target=\"%s\"><data key=\"count\">%d</data><data key=\"amount\">%d</data><data key=\"firstMilestoneIndex\">%d</data><data key=\"lastMilestoneIndex\">%d</data></edge>\n", edge.Source, edge.Target, edge.Count, edge.Amount, edge.FirstMilestoneIndex, edge.LastMilestoneIndex); err != nil {
+			return err
+		}
+	}
+
+	if _, err := bufferedWriter.WriteString("  </graph>\n</graphml>\n"); err != nil {
+		return err
+	}
+
+	return bufferedWriter.Flush()
+}
+
+func graphExport(args []string) error {
+
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	utxoDatabasePathFlag := fs.String(FlagToolUTXODatabasePath, DefaultValueUTXODatabasePath, "the path to the UTXO database folder")
+	outputPathFlag := fs.String(FlagToolOutputPath, "", "the path to the export file")
+	formatFlag := fs.String(FlagToolFormat, GraphFormatCSV, fmt.Sprintf("the format of the export (%s, %s)", GraphFormatCSV, GraphFormatGraphML))
+	fromFlag := fs.Uint32(FlagToolFromMilestoneIndex, 0, "the first milestone index of the range (the first available milestone if 0)")
+	toFlag := fs.Uint32(FlagToolToMilestoneIndex, 0, "the last milestone index of the range (the ledger index if 0)")
+
+	fs.Usage = func() {
+		_, _ = fmt.Fprintf(os.Stderr, "Usage of %s:\n", ToolGraphExport)
+		fs.PrintDefaults()
+		_, _ = fmt.Fprintf(os.Stderr, "\nThe transactions don't say which input paid which output. The amount sent to an output address\n"+
+			"that is not an input address is attributed to the input addresses in proportion to their share of the input value,\n"+
+			"rounded so that the attributed amounts sum up to the output amount. The remainder sent back to an input address is not exported.\n"+
+			"The count of an edge is the amount of transfers in which the source sent to the target.\n")
+		_, _ = fmt.Fprintf(os.Stderr, "\nexample: %s --%s %s --%s %s --%s %s\n",
+			ToolGraphExport,
+			FlagToolUTXODatabasePath,
+			DefaultValueUTXODatabasePath,
+			FlagToolFormat,
+			GraphFormatGraphML,
+			FlagToolOutputPath,
+			"transfers.graphml")
+	}
+
+	if err := parseFlagSet(fs, args); err != nil {
+		return err
+	}
+
+	if *outputPathFlag == "" {
+		return fmt.Errorf("'%s' not specified", FlagToolOutputPath)
+	}
+
+	var writeGraph func(g *transferGraph, w io.Writer) error
+	switch strings.ToLower(*formatFlag) {
+	case GraphFormatCSV:
+		writeGraph = (*transferGraph).writeCSV
+	case GraphFormatGraphML:
+		writeGraph = (*transferGraph).writeGraphML
+	default:
+		return fmt.Errorf("unknown format: %s", *formatFlag)
+	}
+
+	utxoDatabase, err := engine.StoreWithDefaultSettings(*utxoDatabasePathFlag, false, hivedb.EngineAuto, true, engine.AllowedEnginesStorageAuto...)
+	if err != nil {
+		return fmt.Errorf("opening utxo database failed: %w", err)
+	}
+	defer func() { _ = utxoDatabase.Close() }()
+
+	utxoManager := utxo.New(utxoDatabase)
+
+	firstIndex, _, found, err := utxoManager.MilestoneDiffIndexRange()
+	if err != nil {
+		return fmt.Errorf("reading milestone diffs failed: %w", err)
+	}
+	if !found {
+		return fmt.Errorf("no milestone diffs found in the utxo database")
+	}
+
+	from := milestone.Index(*fromFlag)
+	if from < firstIndex {
+		from = firstIndex
+	}
+
+	to := milestone.Index(*toFlag)
+	if ledgerIndex := utxoManager.ReadLedgerIndex(); to == 0 || to > ledgerIndex {
+		to = ledgerIndex
+	}
+
+	if from > to {
+		return fmt.Errorf("invalid milestone range: %d-%d", from, to)
+	}
+
+	graph := newTransferGraph()
+
+	var transferCount int
+	for msIndex := from; msIndex <= to; msIndex++ {
+		diff, err := utxoManager.MilestoneDiff(msIndex)
+		if err != nil {
+			return fmt.Errorf("loading milestone diff failed, msIndex: %d, error: %w", msIndex, err)
+		}
+
+		for _, transfer := range utxo.TransfersFromMilestoneDiff(diff) {
+			graph.addTransfer(transfer)
+			transferCount++
+		}
+	}
+
+	outputFile, err := os.Create(*outputPathFlag)
+	if err != nil {
+		return fmt.Errorf("creating the export file failed: %w", err)
+	}
+
+	if err := writeGraph(graph, outputFile); err != nil {
+		_ = outputFile.Close()
+
+		return fmt.Errorf("writing the export file failed: %w", err)
+	}
+
+	if err := outputFile.Close(); err != nil {
+		return fmt.Errorf("writing the export file failed: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(os.Stderr, "exported %d transfers of milestones %d-%d as %d addresses and %d edges to %s\n", transferCount, from, to, len(graph.nodes), len(graph.edges), *outputPathFlag)
+
+	return nil
+}
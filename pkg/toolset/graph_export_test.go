@@ -0,0 +1,156 @@
+package toolset
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/inx-api-core-v1/pkg/milestone"
+	"github.com/iotaledger/inx-api-core-v1/pkg/utxo"
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+func testAddress(b byte) iotago.Address {
+	return &iotago.Ed25519Address{b}
+}
+
+func testTransfer(msIndex milestone.Index, inputs []*utxo.TransferAmount, outputs []*utxo.TransferAmount) *utxo.Transfer {
+	transfer := &utxo.Transfer{
+		MilestoneIndex: msIndex,
+		Inputs:         inputs,
+		Outputs:        outputs,
+	}
+
+	for _, input := range inputs {
+		transfer.TotalValue += input.Amount
+	}
+
+	return transfer
+}
+
+// requireEdge checks the edge between the given addresses.
+func requireEdge(t *testing.T, g *transferGraph, source iotago.Address, target iotago.Address, count uint64, amount uint64, first uint32, last uint32) {
+	t.Helper()
+
+	edge, exists := g.edges[graphEdgeKey{source: source.String(), target: target.String()}]
+	require.True(t, exists)
+	require.Equal(t, &graphEdge{
+		Source:              source.String(),
+		Target:              target.String(),
+		Count:               count,
+		Amount:              amount,
+		FirstMilestoneIndex: first,
+		LastMilestoneIndex:  last,
+	}, edge)
+}
+
+func TestAttributeAmount(t *testing.T) {
+	inputs := func(amounts ...uint64) []*utxo.TransferAmount {
+		transferAmounts := make([]*utxo.TransferAmount, len(amounts))
+		for i, amount := range amounts {
+			transferAmounts[i] = &utxo.TransferAmount{Address: testAddress(byte(i)), Amount: amount}
+		}
+
+		return transferAmounts
+	}
+
+	tests := []struct {
+		name       string
+		amount     uint64
+		inputs     []*utxo.TransferAmount
+		totalValue uint64
+		shares     []uint64
+	}{
+		{
+			name:       "single input",
+			amount:     7,
+			inputs:     inputs(10),
+			totalValue: 10,
+			shares:     []uint64{7},
+		},
+		{
+			name:       "largest remainder gets the remaining unit",
+			amount:     33,
+			inputs:     inputs(60, 40),
+			totalValue: 100,
+			shares:     []uint64{20, 13},
+		},
+		{
+			name:       "equal remainders are assigned in input order",
+			amount:     5,
+			inputs:     inputs(5, 5, 5),
+			totalValue: 15,
+			shares:     []uint64{2, 2, 1},
+		},
+		{
+			name:       "total supply without overflow",
+			amount:     iotago.TokenSupply - 1,
+			inputs:     inputs(iotago.TokenSupply-3, 1, 2),
+			totalValue: iotago.TokenSupply,
+			shares:     []uint64{iotago.TokenSupply - 4, 1, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shares := attributeAmount(tt.amount, tt.inputs, tt.totalValue)
+			require.Equal(t, tt.shares, shares)
+
+			var sum uint64
+			for _, share := range shares {
+				sum += share
+			}
+			require.Equal(t, tt.amount, sum)
+		})
+	}
+}
+
+func TestTransferGraphAddTransfer(t *testing.T) {
+	addrA, addrB, addrC, addrD, addrE := testAddress(1), testAddress(2), testAddress(3), testAddress(4), testAddress(5)
+
+	g := newTransferGraph()
+
+	// A and B send 33 to C, the remainder of 67 goes back to A
+	transfer := testTransfer(1,
+		[]*utxo.TransferAmount{{Address: addrA, Amount: 60}, {Address: addrB, Amount: 40}},
+		[]*utxo.TransferAmount{{Address: addrC, Amount: 33}, {Address: addrA, Amount: 67}},
+	)
+	g.addTransfer(transfer)
+
+	require.Len(t, g.edges, 2)
+	requireEdge(t, g, addrA, addrC, 1, 20, 1, 1)
+	requireEdge(t, g, addrB, addrC, 1, 13, 1, 1)
+
+	// the same transfer in a later milestone counts once more per edge
+	transfer.MilestoneIndex = 2
+	g.addTransfer(transfer)
+
+	require.Len(t, g.edges, 2)
+	requireEdge(t, g, addrA, addrC, 2, 40, 1, 2)
+	requireEdge(t, g, addrB, addrC, 2, 26, 1, 2)
+
+	// a transfer to several outputs
+	g.addTransfer(testTransfer(3,
+		[]*utxo.TransferAmount{{Address: addrA, Amount: 5}, {Address: addrB, Amount: 5}, {Address: addrE, Amount: 5}},
+		[]*utxo.TransferAmount{{Address: addrC, Amount: 10}, {Address: addrD, Amount: 5}},
+	))
+
+	require.Len(t, g.edges, 6)
+	requireEdge(t, g, addrA, addrC, 3, 44, 1, 3)
+	requireEdge(t, g, addrB, addrC, 3, 29, 1, 3)
+	requireEdge(t, g, addrE, addrC, 1, 3, 3, 3)
+	requireEdge(t, g, addrA, addrD, 1, 2, 3, 3)
+	requireEdge(t, g, addrB, addrD, 1, 2, 3, 3)
+	requireEdge(t, g, addrE, addrD, 1, 1, 3, 3)
+
+	// the attributed amounts sum up to the transferred amounts
+	require.Equal(t, uint64(46), g.nodes[addrA.String()].sent)
+	require.Equal(t, uint64(31), g.nodes[addrB.String()].sent)
+	require.Equal(t, uint64(4), g.nodes[addrE.String()].sent)
+	require.Equal(t, uint64(76), g.nodes[addrC.String()].received)
+	require.Equal(t, uint64(5), g.nodes[addrD.String()].received)
+
+	// transfers without value are ignored
+	g.addTransfer(testTransfer(4, nil, nil))
+	require.Len(t, g.edges, 6)
+}
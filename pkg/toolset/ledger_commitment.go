@@ -0,0 +1,162 @@
+package toolset
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	flag "github.com/spf13/pflag"
+
+	hivedb "github.com/iotaledger/hive.go/kvstore/database"
+	"github.com/iotaledger/inx-api-core-v1/pkg/database/engine"
+	"github.com/iotaledger/inx-api-core-v1/pkg/milestone"
+	"github.com/iotaledger/inx-api-core-v1/pkg/utxo"
+)
+
+// ledgerCommitmentMilestone is the commitment of a milestone in the ledgerCommitmentReport.
+type ledgerCommitmentMilestone struct {
+	// The index of the milestone.
+	MilestoneIndex milestone.Index `json:"milestoneIndex"`
+	// The hex encoded hash over the created and consumed outputs and the treasury change of the milestone.
+	DiffHash string `json:"diffHash"`
+	// The hex encoded commitment chained over the diff hashes of all milestones since the anchor milestone.
+	Commitment string `json:"commitment"`
+}
+
+// ledgerCommitmentReport is the result of the ledger commitment tool.
+type ledgerCommitmentReport struct {
+	// The ledger index at which the commitment was computed.
+	LedgerIndex milestone.Index `json:"ledgerIndex"`
+	// The hex encoded commitment over the sorted unspent outputs and the treasury.
+	Commitment string `json:"commitment"`
+	// The amount of unspent outputs.
+	UnspentOutputCount int64 `json:"unspentOutputCount"`
+	// The hex encoded milestone ID of the unspent treasury output.
+	TreasuryMilestoneID string `json:"treasuryMilestoneId"`
+	// The amount of the unspent treasury output.
+	TreasuryAmount uint64 `json:"treasuryAmount"`
+	// The index of the anchor milestone of the milestone commitment chain.
+	AnchorIndex milestone.Index `json:"anchorIndex"`
+	// The hex encoded ledger commitment at the anchor milestone, which seeds the milestone commitment chain.
+	AnchorCommitment string `json:"anchorCommitment"`
+	// The milestone commitments of the requested range.
+	MilestoneCommitments []*ledgerCommitmentMilestone `json:"milestoneCommitments"`
+}
+
+func ledgerCommitment(args []string) error {
+
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	utxoDatabasePathFlag := fs.String(FlagToolUTXODatabasePath, DefaultValueUTXODatabasePath, "the path to the UTXO database folder")
+	outputPathFlag := fs.String(FlagToolOutputPath, "", "the path to the JSON report file (the report is printed to stdout if empty)")
+	fromFlag := fs.Uint32(FlagToolFromMilestoneIndex, 0, "the first milestone index of the listed milestone commitments (only the last milestone if 0)")
+	toFlag := fs.Uint32(FlagToolToMilestoneIndex, 0, "the last milestone index of the listed milestone commitments (the ledger index if 0)")
+	anchorFlag := fs.Uint32(FlagToolAnchorIndex, 0, "the well-known milestone index at which the milestone commitment chain is anchored")
+
+	fs.Usage = func() {
+		_, _ = fmt.Fprintf(os.Stderr, "Usage of %s:\n", ToolLedgerCommitment)
+		fs.PrintDefaults()
+		_, _ = fmt.Fprintf(os.Stderr, "\nexample: %s --%s %s --%s %s\n",
+			ToolLedgerCommitment,
+			FlagToolUTXODatabasePath,
+			DefaultValueUTXODatabasePath,
+			FlagToolOutputPath,
+			"ledger_commitment.json")
+	}
+
+	if err := parseFlagSet(fs, args); err != nil {
+		return err
+	}
+
+	utxoDatabase, err := engine.StoreWithDefaultSettings(*utxoDatabasePathFlag, false, hivedb.EngineAuto, true, engine.AllowedEnginesStorageAuto...)
+	if err != nil {
+		return fmt.Errorf("opening utxo database failed: %w", err)
+	}
+	defer func() { _ = utxoDatabase.Close() }()
+
+	utxoManager := utxo.New(utxoDatabase)
+
+	to := milestone.Index(*toFlag)
+	if ledgerIndex := utxoManager.ReadLedgerIndex(); to == 0 || to > ledgerIndex {
+		to = ledgerIndex
+	}
+
+	from := milestone.Index(*fromFlag)
+	if from == 0 {
+		from = to
+	}
+
+	if from > to {
+		return fmt.Errorf("invalid milestone range: %d-%d", from, to)
+	}
+
+	anchorIndex := milestone.Index(*anchorFlag)
+	if from < anchorIndex {
+		return fmt.Errorf("%w: %d < %d", utxo.ErrCommitmentBelowAnchor, from, anchorIndex)
+	}
+
+	commitment, err := utxoManager.LedgerCommitment()
+	if err != nil {
+		return fmt.Errorf("computing the ledger commitment failed: %w", err)
+	}
+
+	report := &ledgerCommitmentReport{
+		LedgerIndex:          commitment.LedgerIndex,
+		Commitment:           hex.EncodeToString(commitment.Commitment[:]),
+		UnspentOutputCount:   commitment.UnspentOutputCount,
+		TreasuryMilestoneID:  hex.EncodeToString(commitment.Treasury.MilestoneID[:]),
+		TreasuryAmount:       commitment.Treasury.Amount,
+		MilestoneCommitments: make([]*ledgerCommitmentMilestone, 0),
+	}
+
+	anchorCommitment, err := utxoManager.LedgerCommitmentAtIndex(anchorIndex)
+	if err != nil {
+		return fmt.Errorf("computing the ledger commitment at the anchor milestone failed: %w", err)
+	}
+	report.AnchorIndex = anchorIndex
+	report.AnchorCommitment = hex.EncodeToString(anchorCommitment.Commitment[:])
+
+	if from == anchorIndex {
+		// the commitment of the anchor milestone is the ledger commitment at the anchor milestone
+		var zeroDiffHash utxo.Commitment
+		report.MilestoneCommitments = append(report.MilestoneCommitments, &ledgerCommitmentMilestone{
+			MilestoneIndex: anchorIndex,
+			DiffHash:       hex.EncodeToString(zeroDiffHash[:]),
+			Commitment:     report.AnchorCommitment,
+		})
+	}
+
+	if err := utxoManager.ForEachMilestoneCommitment(anchorCommitment, to, func(msIndex milestone.Index, diffHash utxo.Commitment, commitment utxo.Commitment) bool {
+		if msIndex >= from {
+			report.MilestoneCommitments = append(report.MilestoneCommitments, &ledgerCommitmentMilestone{
+				MilestoneIndex: msIndex,
+				DiffHash:       hex.EncodeToString(diffHash[:]),
+				Commitment:     hex.EncodeToString(commitment[:]),
+			})
+		}
+
+		return true
+	}); err != nil {
+		return fmt.Errorf("computing the milestone commitments failed: %w", err)
+	}
+
+	reportJSON, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling the report failed: %w", err)
+	}
+
+	if *outputPathFlag == "" {
+		fmt.Println(string(reportJSON))
+
+		return nil
+	}
+
+	//nolint:gosec // the report is public
+	if err := os.WriteFile(*outputPathFlag, reportJSON, 0o644); err != nil {
+		return fmt.Errorf("writing the report failed: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(os.Stderr, "ledger commitment at %d: %s, %d milestone commitments written to %s\n", report.LedgerIndex, report.Commitment, len(report.MilestoneCommitments), *outputPathFlag)
+
+	return nil
+}
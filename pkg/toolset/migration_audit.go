@@ -0,0 +1,68 @@
+package toolset
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	flag "github.com/spf13/pflag"
+
+	hivedb "github.com/iotaledger/hive.go/kvstore/database"
+	"github.com/iotaledger/inx-api-core-v1/pkg/database/engine"
+	"github.com/iotaledger/inx-api-core-v1/pkg/utxo"
+)
+
+func migrationAudit(args []string) error {
+
+	fs := flag.NewFlagSet("", flag.ContinueOnError)
+	utxoDatabasePathFlag := fs.String(FlagToolUTXODatabasePath, DefaultValueUTXODatabasePath, "the path to the UTXO database folder")
+	outputPathFlag := fs.String(FlagToolOutputPath, "", "the path to the JSON report file (the report is printed to stdout if empty)")
+
+	fs.Usage = func() {
+		_, _ = fmt.Fprintf(os.Stderr, "Usage of %s:\n", ToolMigrationAudit)
+		fs.PrintDefaults()
+		_, _ = fmt.Fprintf(os.Stderr, "\nexample: %s --%s %s --%s %s\n",
+			ToolMigrationAudit,
+			FlagToolUTXODatabasePath,
+			DefaultValueUTXODatabasePath,
+			FlagToolOutputPath,
+			"migration_audit.json")
+	}
+
+	if err := parseFlagSet(fs, args); err != nil {
+		return err
+	}
+
+	utxoDatabase, err := engine.StoreWithDefaultSettings(*utxoDatabasePathFlag, false, hivedb.EngineAuto, true, engine.AllowedEnginesStorageAuto...)
+	if err != nil {
+		return fmt.Errorf("opening utxo database failed: %w", err)
+	}
+	defer func() { _ = utxoDatabase.Close() }()
+
+	report, err := utxo.New(utxoDatabase).AuditMigration()
+	if err != nil {
+		return fmt.Errorf("auditing the migration failed: %w", err)
+	}
+
+	reportJSON, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling the report failed: %w", err)
+	}
+
+	if *outputPathFlag == "" {
+		fmt.Println(string(reportJSON))
+	} else {
+		//nolint:gosec // the report is public
+		if err := os.WriteFile(*outputPathFlag, reportJSON, 0o644); err != nil {
+			return fmt.Errorf("writing the report failed: %w", err)
+		}
+
+		_, _ = fmt.Fprintf(os.Stderr, "audited %d receipts with %d migrated funds entries (%d tokens), %d issues found, report written to %s\n", report.ReceiptCount, report.EntryCount, report.TotalMigrated, len(report.Issues), *outputPathFlag)
+	}
+
+	if !report.Valid {
+		return fmt.Errorf("the migration audit found %d issues", len(report.Issues))
+	}
+
+	return nil
+}
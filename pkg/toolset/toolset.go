@@ -0,0 +1,102 @@
+package toolset
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	flag "github.com/spf13/pflag"
+)
+
+const (
+	FlagToolUTXODatabasePath    = "utxoDatabasePath"
+	FlagToolOutputPath          = "outputPath"
+	FlagToolFormat              = "format"
+	FlagToolFromMilestoneIndex  = "fromMilestoneIndex"
+	FlagToolToMilestoneIndex    = "toMilestoneIndex"
+	FlagToolBundlePath          = "bundlePath"
+	FlagToolCoordinatorKeysPath = "coordinatorKeysPath"
+	FlagToolAnchorIndex         = "anchorIndex"
+)
+
+const (
+	ToolMigrationAudit    = "migration-audit"
+	ToolGraphExport       = "graph-export"
+	ToolLedgerCommitment  = "ledger-commitment"
+	ToolAttestationVerify = "attestation-verify"
+)
+
+const (
+	DefaultValueUTXODatabasePath = "database/utxo"
+)
+
+// ShouldHandleTools checks if tools were requested.
+func ShouldHandleTools() bool {
+	args := os.Args[1:]
+
+	for _, arg := range args {
+		if strings.ToLower(arg) == "tool" || strings.ToLower(arg) == "tools" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HandleTools handles available tools.
+func HandleTools() {
+
+	args := os.Args[1:]
+	if len(args) == 1 {
+		listTools()
+		os.Exit(1)
+	}
+
+	tools := map[string]func([]string) error{
+		ToolMigrationAudit:    migrationAudit,
+		ToolGraphExport:       graphExport,
+		ToolLedgerCommitment:  ledgerCommitment,
+		ToolAttestationVerify: attestationVerify,
+	}
+
+	tool, exists := tools[strings.ToLower(args[1])]
+	if !exists {
+		fmt.Print("tool not found.\n\n")
+		listTools()
+		os.Exit(1)
+	}
+
+	if err := tool(args[2:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			// help text was requested
+			os.Exit(0)
+		}
+
+		fmt.Printf("\nerror: %s\n", err)
+		os.Exit(1)
+	}
+
+	os.Exit(0)
+}
+
+func listTools() {
+	fmt.Printf("%-20s verifies the receipts and treasury outputs of the legacy migration and writes a JSON report\n", fmt.Sprintf("%s:", ToolMigrationAudit))
+	fmt.Printf("%-20s exports the value-transfer graph between addresses of a milestone range as CSV edge list or GraphML\n", fmt.Sprintf("%s:", ToolGraphExport))
+	fmt.Printf("%-20s computes the commitment over the unspent outputs and the treasury and the milestone commitments and writes a JSON report\n", fmt.Sprintf("%s:", ToolLedgerCommitment))
+	fmt.Printf("%-20s verifies a balance attestation bundle offline against the coordinator public keys and writes a JSON report\n", fmt.Sprintf("%s:", ToolAttestationVerify))
+}
+
+func parseFlagSet(fs *flag.FlagSet, args []string) error {
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	// Check if all parameters were parsed
+	if fs.NArg() != 0 {
+		return errors.New("too much arguments")
+	}
+
+	return nil
+}
@@ -54,6 +54,31 @@ func (u *Manager) AddressBalanceWithoutLocking(address iotago.Address) (balance
 		return 0, false, err
 	}
 
+	dustAllowed = MaxDustOutputs(dustAllowance) > dustOutputCount
+
+	return b, dustAllowed, nil
+}
+
+// AddressDustBalance returns the dust allowance balance and the amount of dust outputs of the address.
+func (u *Manager) AddressDustBalance(address iotago.Address) (dustAllowanceBalance uint64, dustOutputCount int64, ledgerIndex milestone.Index, err error) {
+
+	ledgerIndex = u.ReadLedgerIndex()
+
+	addressKey, err := address.Serialize(serializer.DeSeriModeNoValidation)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	_, dustAllowanceBalance, dustOutputCount, err = u.readBalanceForAddress(addressKey)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+
+	return dustAllowanceBalance, dustOutputCount, ledgerIndex, nil
+}
+
+// MaxDustOutputs returns the maximum amount of dust outputs that are allowed on an address with the given dust allowance balance.
+func MaxDustOutputs(dustAllowanceBalance uint64) int64 {
 	// There is no built-in min function for int64, so inline one here
 	min := func(x, y int64) int64 {
 		if x > y {
@@ -63,9 +88,7 @@ func (u *Manager) AddressBalanceWithoutLocking(address iotago.Address) (balance
 		return x
 	}
 
-	dustAllowed = min(int64(dustAllowance)/iotago.DustAllowanceDivisor, iotago.MaxDustOutputsOnAddress) > dustOutputCount
-
-	return b, dustAllowed, nil
+	return min(int64(dustAllowanceBalance)/iotago.DustAllowanceDivisor, iotago.MaxDustOutputsOnAddress)
 }
 
 func (u *Manager) readBalanceForAddress(addressKey []byte) (balance uint64, dustAllowanceBalance uint64, dustOutputCount int64, err error) {
@@ -84,3 +107,37 @@ func (u *Manager) readBalanceForAddress(addressKey []byte) (balance uint64, dust
 
 	return balanceFromBytes(value)
 }
+
+// BalanceConsumer is a function that consumes the balance of an address.
+// Returning false from this function indicates to abort the iteration.
+type BalanceConsumer func(address iotago.Address, balance uint64, dustAllowanceBalance uint64, dustOutputCount int64) bool
+
+// ForEachBalance iterates over the balances of all addresses.
+func (u *Manager) ForEachBalance(consumer BalanceConsumer) error {
+
+	var innerErr error
+
+	if err := u.utxoStorage.Iterate([]byte{UTXOStoreKeyPrefixBalances}, func(key kvstore.Key, value kvstore.Value) bool {
+
+		// skip the prefix
+		address, err := parseAddress(marshalutil.New(key[1:]))
+		if err != nil {
+			innerErr = err
+
+			return false
+		}
+
+		balance, dustAllowanceBalance, dustOutputCount, err := balanceFromBytes(value)
+		if err != nil {
+			innerErr = err
+
+			return false
+		}
+
+		return consumer(address, balance, dustAllowanceBalance, dustOutputCount)
+	}); err != nil {
+		return err
+	}
+
+	return innerErr
+}
@@ -0,0 +1,306 @@
+package utxo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"sort"
+
+	"golang.org/x/crypto/blake2b"
+
+	"github.com/iotaledger/inx-api-core-v1/pkg/milestone"
+)
+
+const (
+	// CommitmentLength is the length of a ledger or milestone commitment.
+	CommitmentLength = blake2b.Size256
+)
+
+var (
+	// ErrCommitmentBelowAnchor is returned if a milestone commitment below the anchor milestone is requested.
+	ErrCommitmentBelowAnchor = errors.New("milestone commitment below the anchor milestone")
+	// ErrMilestoneDiffsNotAvailable is returned if the milestone diffs needed to roll back the ledger are not available.
+	ErrMilestoneDiffsNotAvailable = errors.New("milestone diffs not available")
+)
+
+// Commitment is a BLAKE2b-256 hash over a ledger state or the changes of a milestone.
+type Commitment [CommitmentLength]byte
+
+/*
+
+   Ledger commitment:
+   ==================
+   BLAKE2b-256 over
+       LedgerIndex + UnspentOutputCount + UnspentOutputCount * Output entry                            + TreasuryMilestoneID + TreasuryAmount
+         4 bytes   +      8 bytes       +  (sorted by output ID)                                       +       32 bytes      +    8 bytes
+
+   Output entry:
+       iotago.UTXOInputID + iotago.Ed25519Address.Serialized() + iotago.OutputType + Amount
+       32 bytes + 2 bytes +       1 byte type + 32 bytes       +       1 byte      + 8 bytes
+
+   Milestone diff hash:
+   ====================
+   BLAKE2b-256 over
+       MilestoneIndex + CreatedCount + CreatedCount * Output entry + ConsumedCount + ConsumedCount * Output entry + HasTreasury + [SpentTreasuryMilestoneID + SpentTreasuryAmount + TreasuryMilestoneID + TreasuryAmount]
+          4 bytes     +    4 bytes   +   (sorted by output ID)     +    4 bytes    +    (sorted by output ID)     +    1 byte   + [        32 bytes          +       8 bytes       +       32 bytes      +     8 bytes     ]
+
+   Milestone commitment:
+   =====================
+   BLAKE2b-256 over
+       PreviousMilestoneCommitment + MilestoneDiffHash
+                32 bytes           +     32 bytes
+
+   If the milestone didn't consume a treasury output, the spent treasury fields are zero.
+   If no treasury output existed at the ledger index, the treasury fields of the ledger commitment are zero.
+   The milestone commitment chain is anchored at a well-known milestone that is configured independently of the available milestone diffs.
+   The commitment of the anchor milestone is the ledger commitment at the anchor milestone,
+   there are no milestone commitments below the anchor milestone.
+   All integers are encoded in big endian.
+
+*/
+
+// LedgerCommitment is the commitment over the unspent outputs and the treasury at the ledger index.
+type LedgerCommitment struct {
+	// The ledger index at which the commitment was computed.
+	LedgerIndex milestone.Index
+	// The amount of unspent outputs.
+	UnspentOutputCount int64
+	// The unspent treasury output.
+	Treasury *TreasuryOutput
+	// The commitment.
+	Commitment Commitment
+}
+
+func writeUint32(h hash.Hash, value uint32) {
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], value)
+	_, _ = h.Write(buf[:])
+}
+
+func writeUint64(h hash.Hash, value uint64) {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], value)
+	_, _ = h.Write(buf[:])
+}
+
+func writeOutputEntry(h hash.Hash, output *Output) {
+	_, _ = h.Write(output.OutputID()[:])
+	_, _ = h.Write(output.AddressBytes())
+	_, _ = h.Write([]byte{output.OutputType()})
+	writeUint64(h, output.Amount())
+}
+
+func writeTreasuryOutput(h hash.Hash, treasuryOutput *TreasuryOutput) {
+	_, _ = h.Write(treasuryOutput.MilestoneID[:])
+	writeUint64(h, treasuryOutput.Amount)
+}
+
+func sortOutputsByOutputID(outputs Outputs) {
+	sort.Slice(outputs, func(i, j int) bool {
+		return bytes.Compare(outputs[i].OutputID()[:], outputs[j].OutputID()[:]) < 0
+	})
+}
+
+func newCommitmentHash() hash.Hash {
+	// blake2b.New256 only fails for keys that are too long
+	h, _ := blake2b.New256(nil)
+
+	return h
+}
+
+// computeLedgerCommitment computes the commitment over the given unspent outputs and treasury output at the ledger index.
+// The treasury output may be nil if no treasury output existed at the ledger index.
+func computeLedgerCommitment(ledgerIndex milestone.Index, unspentOutputs Outputs, treasuryOutput *TreasuryOutput) *LedgerCommitment {
+	sorted := make(Outputs, len(unspentOutputs))
+	copy(sorted, unspentOutputs)
+	sortOutputsByOutputID(sorted)
+
+	committedTreasuryOutput := treasuryOutput
+	if committedTreasuryOutput == nil {
+		committedTreasuryOutput = &TreasuryOutput{}
+	}
+
+	h := newCommitmentHash()
+	writeUint32(h, uint32(ledgerIndex))
+	writeUint64(h, uint64(len(sorted)))
+	for _, output := range sorted {
+		writeOutputEntry(h, output)
+	}
+	writeTreasuryOutput(h, committedTreasuryOutput)
+
+	commitment := &LedgerCommitment{
+		LedgerIndex:        ledgerIndex,
+		UnspentOutputCount: int64(len(sorted)),
+		Treasury:           treasuryOutput,
+	}
+	copy(commitment.Commitment[:], h.Sum(nil))
+
+	return commitment
+}
+
+// LedgerCommitment computes the commitment over the unspent outputs and the treasury at the ledger index.
+func (u *Manager) LedgerCommitment() (*LedgerCommitment, error) {
+	unspentOutputs := make(Outputs, 0)
+	if err := u.ForEachUnspentOutput(func(output *Output) bool {
+		unspentOutputs = append(unspentOutputs, output)
+
+		return true
+	}); err != nil {
+		return nil, err
+	}
+
+	treasuryOutput, err := u.UnspentTreasuryOutput()
+	if err != nil {
+		return nil, err
+	}
+
+	return computeLedgerCommitment(u.ReadLedgerIndex(), unspentOutputs, treasuryOutput), nil
+}
+
+// LedgerCommitmentAtIndex computes the commitment over the unspent outputs and the treasury at the given milestone index.
+// The ledger state is rolled back from the ledger index with the milestone diffs, so all milestone diffs
+// after the given milestone index must be available.
+func (u *Manager) LedgerCommitmentAtIndex(msIndex milestone.Index) (*LedgerCommitment, error) {
+	ledgerIndex := u.ReadLedgerIndex()
+	if msIndex > ledgerIndex {
+		return nil, fmt.Errorf("milestone index %d is above the ledger index %d", msIndex, ledgerIndex)
+	}
+
+	if msIndex < ledgerIndex {
+		first, _, found, err := u.MilestoneDiffIndexRange()
+		if err != nil {
+			return nil, err
+		}
+		if !found || first > msIndex+1 {
+			return nil, fmt.Errorf("%w: rolling back the ledger to milestone %d needs the milestone diffs since milestone %d", ErrMilestoneDiffsNotAvailable, msIndex, msIndex+1)
+		}
+	}
+
+	unspentOutputs := make(map[string]*Output)
+	if err := u.ForEachUnspentOutput(func(output *Output) bool {
+		unspentOutputs[string(output.OutputID()[:])] = output
+
+		return true
+	}); err != nil {
+		return nil, err
+	}
+
+	treasuryOutput, err := u.UnspentTreasuryOutput()
+	if err != nil {
+		return nil, err
+	}
+
+	for index := ledgerIndex; index > msIndex; index-- {
+		diff, err := u.MilestoneDiff(index)
+		if err != nil {
+			return nil, fmt.Errorf("reading milestone diff %d failed: %w", index, err)
+		}
+
+		for _, output := range diff.Outputs {
+			delete(unspentOutputs, string(output.OutputID()[:]))
+		}
+		for _, spent := range diff.Spents {
+			unspentOutputs[string(spent.OutputID()[:])] = spent.Output()
+		}
+		if diff.TreasuryOutput != nil {
+			// the consumed treasury output is nil if no treasury output existed before the milestone
+			treasuryOutput = diff.SpentTreasuryOutput
+		}
+	}
+
+	outputs := make(Outputs, 0, len(unspentOutputs))
+	for _, output := range unspentOutputs {
+		outputs = append(outputs, output)
+	}
+
+	return computeLedgerCommitment(msIndex, outputs, treasuryOutput), nil
+}
+
+// MilestoneDiffHash computes the hash over the created and consumed outputs and the treasury change of the milestone diff.
+func MilestoneDiffHash(diff *MilestoneDiff) Commitment {
+	created := make(Outputs, len(diff.Outputs))
+	copy(created, diff.Outputs)
+	sortOutputsByOutputID(created)
+
+	consumed := make(Outputs, len(diff.Spents))
+	for i, spent := range diff.Spents {
+		consumed[i] = spent.Output()
+	}
+	sortOutputsByOutputID(consumed)
+
+	h := newCommitmentHash()
+	writeUint32(h, uint32(diff.Index))
+
+	writeUint32(h, uint32(len(created)))
+	for _, output := range created {
+		writeOutputEntry(h, output)
+	}
+
+	writeUint32(h, uint32(len(consumed)))
+	for _, output := range consumed {
+		writeOutputEntry(h, output)
+	}
+
+	if diff.TreasuryOutput != nil {
+		_, _ = h.Write([]byte{1})
+
+		spentTreasuryOutput := diff.SpentTreasuryOutput
+		if spentTreasuryOutput == nil {
+			// no treasury output was consumed by the milestone
+			spentTreasuryOutput = &TreasuryOutput{}
+		}
+		writeTreasuryOutput(h, spentTreasuryOutput)
+		writeTreasuryOutput(h, diff.TreasuryOutput)
+	} else {
+		_, _ = h.Write([]byte{0})
+	}
+
+	var diffHash Commitment
+	copy(diffHash[:], h.Sum(nil))
+
+	return diffHash
+}
+
+// MilestoneCommitment chains the commitment of the previous milestone with the diff hash of the milestone.
+func MilestoneCommitment(previous Commitment, diffHash Commitment) Commitment {
+	h := newCommitmentHash()
+	_, _ = h.Write(previous[:])
+	_, _ = h.Write(diffHash[:])
+
+	var commitment Commitment
+	copy(commitment[:], h.Sum(nil))
+
+	return commitment
+}
+
+// MilestoneCommitmentConsumer is a function that consumes the diff hash and the commitment of a milestone.
+// Returning false from this function indicates to abort the iteration.
+type MilestoneCommitmentConsumer func(msIndex milestone.Index, diffHash Commitment, commitment Commitment) bool
+
+// ForEachMilestoneCommitment computes the milestone commitment chain from the anchor milestone
+// up to the given milestone index and passes the commitment of every milestone after the anchor milestone to the consumer.
+// The chain is seeded with the ledger commitment at the anchor milestone.
+func (u *Manager) ForEachMilestoneCommitment(anchor *LedgerCommitment, to milestone.Index, consumer MilestoneCommitmentConsumer) error {
+	if to < anchor.LedgerIndex {
+		return fmt.Errorf("%w: %d < %d", ErrCommitmentBelowAnchor, to, anchor.LedgerIndex)
+	}
+
+	commitment := anchor.Commitment
+	for msIndex := anchor.LedgerIndex + 1; msIndex <= to; msIndex++ {
+		diff, err := u.MilestoneDiff(msIndex)
+		if err != nil {
+			return fmt.Errorf("reading milestone diff %d failed: %w", msIndex, err)
+		}
+
+		diffHash := MilestoneDiffHash(diff)
+		commitment = MilestoneCommitment(commitment, diffHash)
+
+		if !consumer(msIndex, diffHash, commitment) {
+			return nil
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,341 @@
+package utxo
+
+import (
+	"encoding/binary"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/iotaledger/hive.go/serializer/v2/byteutils"
+	"github.com/iotaledger/hive.go/serializer/v2/marshalutil"
+	"github.com/iotaledger/inx-api-core-v1/pkg/hornet"
+	"github.com/iotaledger/inx-api-core-v1/pkg/milestone"
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+// testOutput returns an output whose output ID, message ID and address are derived from b.
+func testOutput(b byte, amount uint64) *Output {
+	outputID := &iotago.UTXOInputID{}
+	outputID[0] = b
+	outputID[iotago.TransactionIDLength] = b
+
+	messageID := make(hornet.MessageID, iotago.MessageIDLength)
+	messageID[0] = b
+
+	address := &iotago.Ed25519Address{}
+	address[0] = b
+
+	return &Output{
+		outputID:   outputID,
+		messageID:  messageID,
+		outputType: iotago.OutputSigLockedSingleOutput,
+		address:    address,
+		amount:     amount,
+	}
+}
+
+func testTreasuryOutput(b byte, amount uint64) *TreasuryOutput {
+	treasuryOutput := &TreasuryOutput{Amount: amount}
+	treasuryOutput.MilestoneID[0] = b
+
+	return treasuryOutput
+}
+
+// shuffledOutputs returns a shuffled copy of the outputs.
+func shuffledOutputs(rng *rand.Rand, outputs Outputs) Outputs {
+	shuffled := make(Outputs, len(outputs))
+	copy(shuffled, outputs)
+	rng.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+
+	return shuffled
+}
+
+func TestLedgerCommitmentIsIndependentOfOutputOrder(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	outputs := make(Outputs, 0, 20)
+	for i := 1; i <= 20; i++ {
+		outputs = append(outputs, testOutput(byte(i), uint64(i)*1_000_000))
+	}
+	treasuryOutput := testTreasuryOutput(1, 500)
+
+	expected := computeLedgerCommitment(10, outputs, treasuryOutput)
+	require.EqualValues(t, 20, expected.UnspentOutputCount)
+
+	for i := 0; i < 10; i++ {
+		shuffled := shuffledOutputs(rng, outputs)
+		order := make(Outputs, len(shuffled))
+		copy(order, shuffled)
+
+		require.Equal(t, expected.Commitment, computeLedgerCommitment(10, shuffled, treasuryOutput).Commitment)
+		// the order of the given outputs is not modified
+		require.Equal(t, order, shuffled)
+	}
+
+	// every committed field changes the commitment
+	require.NotEqual(t, expected.Commitment, computeLedgerCommitment(11, outputs, treasuryOutput).Commitment)
+	require.NotEqual(t, expected.Commitment, computeLedgerCommitment(10, outputs[1:], treasuryOutput).Commitment)
+	require.NotEqual(t, expected.Commitment, computeLedgerCommitment(10, outputs, testTreasuryOutput(1, 501)).Commitment)
+	require.NotEqual(t, expected.Commitment, computeLedgerCommitment(10, outputs, testTreasuryOutput(2, 500)).Commitment)
+
+	changedOutputs := make(Outputs, len(outputs))
+	copy(changedOutputs, outputs)
+	changedOutputs[5] = testOutput(6, 1)
+	require.NotEqual(t, expected.Commitment, computeLedgerCommitment(10, changedOutputs, treasuryOutput).Commitment)
+
+	// a missing treasury output is committed as zero treasury fields
+	require.Equal(t, computeLedgerCommitment(10, outputs, &TreasuryOutput{}).Commitment, computeLedgerCommitment(10, outputs, nil).Commitment)
+}
+
+func TestMilestoneDiffHashIsIndependentOfOutputOrder(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	created := make(Outputs, 0, 10)
+	consumed := make(Spents, 0, 10)
+	for i := 1; i <= 10; i++ {
+		created = append(created, testOutput(byte(i), uint64(i)))
+		consumed = append(consumed, &Spent{output: testOutput(byte(100+i), uint64(i))})
+	}
+
+	diff := &MilestoneDiff{
+		Index:               5,
+		Outputs:             created,
+		Spents:              consumed,
+		TreasuryOutput:      testTreasuryOutput(2, 400),
+		SpentTreasuryOutput: testTreasuryOutput(1, 500),
+	}
+	expected := MilestoneDiffHash(diff)
+
+	for i := 0; i < 10; i++ {
+		shuffledSpents := make(Spents, len(consumed))
+		copy(shuffledSpents, consumed)
+		rng.Shuffle(len(shuffledSpents), func(i, j int) { shuffledSpents[i], shuffledSpents[j] = shuffledSpents[j], shuffledSpents[i] })
+
+		shuffledDiff := &MilestoneDiff{
+			Index:               diff.Index,
+			Outputs:             shuffledOutputs(rng, created),
+			Spents:              shuffledSpents,
+			TreasuryOutput:      diff.TreasuryOutput,
+			SpentTreasuryOutput: diff.SpentTreasuryOutput,
+		}
+		require.Equal(t, expected, MilestoneDiffHash(shuffledDiff))
+	}
+
+	// created and consumed outputs are committed separately
+	require.NotEqual(t, expected, MilestoneDiffHash(&MilestoneDiff{
+		Index:               diff.Index,
+		Outputs:             created[1:],
+		Spents:              append(Spents{{output: created[0]}}, consumed...),
+		TreasuryOutput:      diff.TreasuryOutput,
+		SpentTreasuryOutput: diff.SpentTreasuryOutput,
+	}))
+
+	// the treasury change is committed
+	require.NotEqual(t, expected, MilestoneDiffHash(&MilestoneDiff{
+		Index:   diff.Index,
+		Outputs: created,
+		Spents:  consumed,
+	}))
+
+	// the milestone index is committed
+	require.NotEqual(t, expected, MilestoneDiffHash(&MilestoneDiff{
+		Index:               diff.Index + 1,
+		Outputs:             created,
+		Spents:              consumed,
+		TreasuryOutput:      diff.TreasuryOutput,
+		SpentTreasuryOutput: diff.SpentTreasuryOutput,
+	}))
+}
+
+// testLedger stores the outputs, the spents, the treasury outputs and the milestone diffs of a ledger.
+type testLedger struct {
+	t     *testing.T
+	store kvstore.KVStore
+}
+
+func (l *testLedger) set(key []byte, value []byte) {
+	require.NoError(l.t, l.store.Set(key, value))
+}
+
+func (l *testLedger) storeOutput(output *Output, spent bool) {
+	value := marshalutil.New()
+	value.WriteBytes(output.messageID)
+	value.WriteByte(output.outputType)
+	value.WriteBytes(output.AddressBytes())
+	value.WriteUint64(output.amount)
+	l.set(byteutils.ConcatBytes([]byte{UTXOStoreKeyPrefixOutput}, output.outputID[:]), value.Bytes())
+
+	if !spent {
+		l.set(output.unspentDatabaseKey(), []byte{})
+
+		return
+	}
+
+	spentValue := marshalutil.New()
+	spentValue.WriteBytes(make([]byte, iotago.TransactionIDLength))
+	spentValue.WriteUint32(0)
+	l.set(output.spentDatabaseKey(), spentValue.Bytes())
+}
+
+func (l *testLedger) storeTreasuryOutput(treasuryOutput *TreasuryOutput, spent bool) {
+	prefix := byte(TreasuryOutputUnspentPrefix)
+	if spent {
+		prefix = TreasuryOutputSpentPrefix
+	}
+
+	value := marshalutil.New()
+	value.WriteUint64(treasuryOutput.Amount)
+	l.set(byteutils.ConcatBytes([]byte{UTXOStoreKeyPrefixTreasuryOutput, prefix}, treasuryOutput.MilestoneID[:]), value.Bytes())
+}
+
+func (l *testLedger) storeMilestoneDiff(msIndex milestone.Index, created Outputs, consumed Outputs, treasuryOutput *TreasuryOutput, spentTreasuryOutput *TreasuryOutput) {
+	value := marshalutil.New()
+	value.WriteUint32(uint32(len(created)))
+	for _, output := range created {
+		value.WriteBytes(output.outputID[:])
+	}
+	value.WriteUint32(uint32(len(consumed)))
+	for _, output := range consumed {
+		value.WriteBytes(output.outputID[:])
+	}
+	value.WriteBool(treasuryOutput != nil)
+	if treasuryOutput != nil {
+		value.WriteBytes(treasuryOutput.MilestoneID[:])
+		value.WriteBytes(spentTreasuryOutput.MilestoneID[:])
+	}
+
+	l.set(milestoneDiffKeyForIndex(msIndex), value.Bytes())
+}
+
+func (l *testLedger) storeLedgerIndex(msIndex milestone.Index) {
+	value := make([]byte, 4)
+	binary.LittleEndian.PutUint32(value, uint32(msIndex))
+	l.set([]byte{UTXOStoreKeyPrefixLedgerMilestoneIndex}, value)
+}
+
+// newTestLedger stores the following ledger with the ledger index 3:
+//
+//	milestone 1 creates o1 and o2
+//	milestone 2 creates o3 and consumes o1
+//	milestone 3 creates o4, consumes o2 and replaces the treasury output t0 with t3
+func newTestLedger(t *testing.T) (*Manager, map[string]*Output, map[string]*TreasuryOutput) {
+	t.Helper()
+
+	outputs := map[string]*Output{
+		"o1": testOutput(1, 100),
+		"o2": testOutput(2, 200),
+		"o3": testOutput(3, 100),
+		"o4": testOutput(4, 200),
+	}
+	treasuryOutputs := map[string]*TreasuryOutput{
+		"t0": testTreasuryOutput(10, 1000),
+		"t3": testTreasuryOutput(13, 900),
+	}
+
+	l := &testLedger{t: t, store: mapdb.NewMapDB()}
+	l.storeOutput(outputs["o1"], true)
+	l.storeOutput(outputs["o2"], true)
+	l.storeOutput(outputs["o3"], false)
+	l.storeOutput(outputs["o4"], false)
+	l.storeTreasuryOutput(treasuryOutputs["t0"], true)
+	l.storeTreasuryOutput(treasuryOutputs["t3"], false)
+	l.storeMilestoneDiff(1, Outputs{outputs["o1"], outputs["o2"]}, nil, nil, nil)
+	l.storeMilestoneDiff(2, Outputs{outputs["o3"]}, Outputs{outputs["o1"]}, nil, nil)
+	l.storeMilestoneDiff(3, Outputs{outputs["o4"]}, Outputs{outputs["o2"]}, treasuryOutputs["t3"], treasuryOutputs["t0"])
+	l.storeLedgerIndex(3)
+
+	return New(l.store), outputs, treasuryOutputs
+}
+
+func TestLedgerCommitmentAtIndex(t *testing.T) {
+	u, outputs, treasuryOutputs := newTestLedger(t)
+
+	tests := []struct {
+		msIndex        milestone.Index
+		unspent        []string
+		treasuryOutput *TreasuryOutput
+	}{
+		{msIndex: 3, unspent: []string{"o3", "o4"}, treasuryOutput: treasuryOutputs["t3"]},
+		{msIndex: 2, unspent: []string{"o2", "o3"}, treasuryOutput: treasuryOutputs["t0"]},
+		{msIndex: 1, unspent: []string{"o1", "o2"}, treasuryOutput: treasuryOutputs["t0"]},
+		{msIndex: 0, unspent: []string{}, treasuryOutput: treasuryOutputs["t0"]},
+	}
+
+	for _, tt := range tests {
+		unspentOutputs := make(Outputs, 0, len(tt.unspent))
+		for _, name := range tt.unspent {
+			unspentOutputs = append(unspentOutputs, outputs[name])
+		}
+
+		commitment, err := u.LedgerCommitmentAtIndex(tt.msIndex)
+		require.NoError(t, err)
+		require.Equal(t, computeLedgerCommitment(tt.msIndex, unspentOutputs, tt.treasuryOutput).Commitment, commitment.Commitment, "milestone %d", tt.msIndex)
+		require.Equal(t, tt.treasuryOutput.Amount, commitment.Treasury.Amount)
+		require.EqualValues(t, len(tt.unspent), commitment.UnspentOutputCount)
+	}
+
+	ledgerCommitment, err := u.LedgerCommitment()
+	require.NoError(t, err)
+	atLedgerIndex, err := u.LedgerCommitmentAtIndex(3)
+	require.NoError(t, err)
+	require.Equal(t, ledgerCommitment.Commitment, atLedgerIndex.Commitment)
+
+	_, err = u.LedgerCommitmentAtIndex(4)
+	require.Error(t, err)
+}
+
+func TestLedgerCommitmentAtIndexWithoutMilestoneDiffs(t *testing.T) {
+	u, _, _ := newTestLedger(t)
+	require.NoError(t, u.utxoStorage.Delete(milestoneDiffKeyForIndex(1)))
+
+	_, err := u.LedgerCommitmentAtIndex(0)
+	require.ErrorIs(t, err, ErrMilestoneDiffsNotAvailable)
+
+	_, err = u.LedgerCommitmentAtIndex(1)
+	require.NoError(t, err)
+}
+
+func TestForEachMilestoneCommitment(t *testing.T) {
+	u, _, _ := newTestLedger(t)
+
+	anchor, err := u.LedgerCommitmentAtIndex(1)
+	require.NoError(t, err)
+
+	commitments := make(map[milestone.Index]Commitment)
+	require.NoError(t, u.ForEachMilestoneCommitment(anchor, 3, func(msIndex milestone.Index, diffHash Commitment, commitment Commitment) bool {
+		diff, err := u.MilestoneDiff(msIndex)
+		require.NoError(t, err)
+		require.Equal(t, MilestoneDiffHash(diff), diffHash)
+
+		commitments[msIndex] = commitment
+
+		return true
+	}))
+
+	// the chain starts after the anchor milestone and is seeded with the ledger commitment at the anchor milestone
+	require.Len(t, commitments, 2)
+	diff2, err := u.MilestoneDiff(2)
+	require.NoError(t, err)
+	diff3, err := u.MilestoneDiff(3)
+	require.NoError(t, err)
+	require.Equal(t, MilestoneCommitment(anchor.Commitment, MilestoneDiffHash(diff2)), commitments[2])
+	require.Equal(t, MilestoneCommitment(commitments[2], MilestoneDiffHash(diff3)), commitments[3])
+
+	// the chain doesn't depend on the first available milestone diff
+	require.NoError(t, u.utxoStorage.Delete(milestoneDiffKeyForIndex(1)))
+	require.NoError(t, u.ForEachMilestoneCommitment(anchor, 3, func(msIndex milestone.Index, _ Commitment, commitment Commitment) bool {
+		require.Equal(t, commitments[msIndex], commitment)
+
+		return true
+	}))
+
+	// commitments below the anchor milestone are refused
+	require.ErrorIs(t, u.ForEachMilestoneCommitment(anchor, 0, func(milestone.Index, Commitment, Commitment) bool {
+		require.Fail(t, "no commitments below the anchor milestone")
+
+		return true
+	}), ErrCommitmentBelowAnchor)
+}
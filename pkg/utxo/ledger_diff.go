@@ -0,0 +1,137 @@
+package utxo
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/iotaledger/inx-api-core-v1/pkg/milestone"
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+// AddressBalanceChange is the net balance change of an address in a LedgerDiff.
+type AddressBalanceChange struct {
+	// The address.
+	Address iotago.Address
+	// The sum of the amounts of the created outputs minus the sum of the amounts of the consumed outputs.
+	Change int64
+}
+
+// LedgerDiff is the net result of all milestone diffs of a milestone range.
+type LedgerDiff struct {
+	// The milestone index of the ledger state the diff starts at.
+	From milestone.Index
+	// The milestone index of the ledger state the diff ends at.
+	To milestone.Index
+	// The outputs created after "from" that are still unspent at "to", sorted by output ID.
+	Created Outputs
+	// The outputs that existed at "from" and were consumed until "to", sorted by output ID.
+	Consumed Spents
+	// The net balance changes of all addresses with a non-zero change, sorted by address.
+	BalanceChanges []*AddressBalanceChange
+	// The treasury output that was unspent at "from", nil if the treasury didn't change in the range.
+	TreasuryFrom *TreasuryOutput
+	// The treasury output that is unspent at "to", nil if the treasury didn't change in the range.
+	TreasuryTo *TreasuryOutput
+}
+
+// LedgerDiff composes the milestone diffs after the ledger state of milestone "from"
+// up to and including milestone "to" into a net result.
+func (u *Manager) LedgerDiff(from milestone.Index, to milestone.Index) (*LedgerDiff, error) {
+	created := make(map[iotago.UTXOInputID]*Output)
+	consumed := make(map[iotago.UTXOInputID]*Spent)
+
+	ledgerDiff := &LedgerDiff{
+		From: from,
+		To:   to,
+	}
+
+	for msIndex := from + 1; msIndex <= to; msIndex++ {
+		diff, err := u.MilestoneDiff(msIndex)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, output := range diff.Outputs {
+			created[*output.OutputID()] = output
+		}
+
+		for _, spent := range diff.Spents {
+			outputID := *spent.OutputID()
+
+			if _, exists := created[outputID]; exists {
+				// the output was created and consumed in the range
+				delete(created, outputID)
+
+				continue
+			}
+			consumed[outputID] = spent
+		}
+
+		if diff.SpentTreasuryOutput != nil && ledgerDiff.TreasuryFrom == nil {
+			ledgerDiff.TreasuryFrom = diff.SpentTreasuryOutput
+		}
+		if diff.TreasuryOutput != nil {
+			ledgerDiff.TreasuryTo = diff.TreasuryOutput
+		}
+	}
+
+	balanceChanges := make(map[string]*AddressBalanceChange)
+	addBalanceChange := func(address iotago.Address, change int64) {
+		key := address.String()
+
+		balanceChange, exists := balanceChanges[key]
+		if !exists {
+			balanceChange = &AddressBalanceChange{Address: address}
+			balanceChanges[key] = balanceChange
+		}
+		balanceChange.Change += change
+	}
+
+	ledgerDiff.Created = make(Outputs, 0, len(created))
+	for _, output := range created {
+		ledgerDiff.Created = append(ledgerDiff.Created, output)
+		addBalanceChange(output.Address(), int64(output.Amount()))
+	}
+	sort.Slice(ledgerDiff.Created, func(i, j int) bool {
+		return bytes.Compare(ledgerDiff.Created[i].OutputID()[:], ledgerDiff.Created[j].OutputID()[:]) < 0
+	})
+
+	ledgerDiff.Consumed = make(Spents, 0, len(consumed))
+	for _, spent := range consumed {
+		ledgerDiff.Consumed = append(ledgerDiff.Consumed, spent)
+		addBalanceChange(spent.Address(), -int64(spent.Amount()))
+	}
+	sort.Slice(ledgerDiff.Consumed, func(i, j int) bool {
+		return bytes.Compare(ledgerDiff.Consumed[i].OutputID()[:], ledgerDiff.Consumed[j].OutputID()[:]) < 0
+	})
+
+	ledgerDiff.BalanceChanges = make([]*AddressBalanceChange, 0, len(balanceChanges))
+	for _, balanceChange := range balanceChanges {
+		if balanceChange.Change == 0 {
+			continue
+		}
+		ledgerDiff.BalanceChanges = append(ledgerDiff.BalanceChanges, balanceChange)
+	}
+	sort.Slice(ledgerDiff.BalanceChanges, func(i, j int) bool {
+		return ledgerDiff.BalanceChanges[i].Address.String() < ledgerDiff.BalanceChanges[j].Address.String()
+	})
+
+	return ledgerDiff, nil
+}
+
+// LedgerDiffWeightBound returns an upper bound of the sum of the created and consumed outputs and the balance changes
+// of the ledger diff of the milestone range, without loading the outputs.
+// Every created or consumed output changes the balance of at most one address, so the bound is twice the amount
+// of outputs in the milestone diffs. The counting stops as soon as the bound exceeds maxWeight.
+func (u *Manager) LedgerDiffWeightBound(from milestone.Index, to milestone.Index, maxWeight int) (int, error) {
+	var weight int
+	for msIndex := from + 1; msIndex <= to && weight <= maxWeight; msIndex++ {
+		outputCount, spentCount, err := u.MilestoneDiffSize(msIndex)
+		if err != nil {
+			return 0, err
+		}
+		weight += 2 * (outputCount + spentCount)
+	}
+
+	return weight, nil
+}
@@ -0,0 +1,300 @@
+package utxo
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/inx-api-core-v1/pkg/milestone"
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+const (
+	// MigrationAuditCheckTreasuryTransaction checks that the receipt contains a valid treasury transaction.
+	MigrationAuditCheckTreasuryTransaction = "treasuryTransaction"
+	// MigrationAuditCheckTreasuryChain checks that the receipt consumes the treasury output created by the previous receipt.
+	MigrationAuditCheckTreasuryChain = "treasuryChain"
+	// MigrationAuditCheckFundsSum checks that the sum of the migrated funds equals the treasury input minus output.
+	MigrationAuditCheckFundsSum = "fundsSum"
+	// MigrationAuditCheckMigratedOutput checks that every migrated funds entry produced the expected output.
+	MigrationAuditCheckMigratedOutput = "migratedOutput"
+	// MigrationAuditCheckFinalReceipt checks that no migrated at index has more than one final receipt.
+	MigrationAuditCheckFinalReceipt = "finalReceipt"
+)
+
+// MigrationAuditIssue is a failed check of the migration audit.
+type MigrationAuditIssue struct {
+	// The index of the milestone which included the receipt.
+	MilestoneIndex milestone.Index `json:"milestoneIndex"`
+	// The milestone index at which the funds were migrated in the legacy network.
+	MigratedAt milestone.Index `json:"migratedAt"`
+	// The name of the failed check.
+	Check string `json:"check"`
+	// The description of the issue.
+	Message string `json:"message"`
+}
+
+// MigrationAuditReceipt contains the audited amounts of a receipt.
+type MigrationAuditReceipt struct {
+	// The index of the milestone which included the receipt.
+	MilestoneIndex milestone.Index `json:"milestoneIndex"`
+	// The milestone index at which the funds were migrated in the legacy network.
+	MigratedAt milestone.Index `json:"migratedAt"`
+	// Whether the receipt is the final receipt for the migrated at index.
+	Final bool `json:"final"`
+	// The amount of migrated funds entries in the receipt.
+	EntryCount int `json:"entryCount"`
+	// The sum of the deposits of all migrated funds entries.
+	FundsSum uint64 `json:"fundsSum"`
+	// The hex encoded ID of the treasury output consumed by the receipt.
+	TreasuryInputID string `json:"treasuryInputId"`
+	// The amount of the treasury output consumed by the receipt.
+	TreasuryInputAmount uint64 `json:"treasuryInputAmount"`
+	// The amount of the treasury output created by the receipt.
+	TreasuryOutputAmount uint64 `json:"treasuryOutputAmount"`
+	// Whether all checks of the receipt passed.
+	Valid bool `json:"valid"`
+}
+
+// MigrationAuditReport is the result of the migration audit.
+type MigrationAuditReport struct {
+	// The ledger index of the audited UTXO database.
+	LedgerIndex milestone.Index `json:"ledgerIndex"`
+	// The amount of treasury outputs in the UTXO database.
+	TreasuryOutputCount int `json:"treasuryOutputCount"`
+	// The amount of audited receipts.
+	ReceiptCount int `json:"receiptCount"`
+	// The amount of audited migrated funds entries.
+	EntryCount int `json:"entryCount"`
+	// The sum of the deposits of all migrated funds entries.
+	TotalMigrated uint64 `json:"totalMigrated"`
+	// The amount of the unspent treasury output.
+	TreasuryAmount uint64 `json:"treasuryAmount"`
+	// The audited receipts sorted by the milestone which included them.
+	Receipts []*MigrationAuditReceipt `json:"receipts"`
+	// The failed checks.
+	Issues []*MigrationAuditIssue `json:"issues"`
+	// Whether all checks passed.
+	Valid bool `json:"valid"`
+}
+
+// AuditMigration walks all receipts and treasury outputs in the order of the milestones that included them
+// and verifies the consistency of the legacy migration.
+func (u *Manager) AuditMigration() (*MigrationAuditReport, error) {
+	report := &MigrationAuditReport{
+		LedgerIndex: u.ReadLedgerIndex(),
+		Receipts:    make([]*MigrationAuditReceipt, 0),
+		Issues:      make([]*MigrationAuditIssue, 0),
+	}
+
+	if err := u.ForEachTreasuryOutput(func(_ *TreasuryOutput) bool {
+		report.TreasuryOutputCount++
+
+		return true
+	}); err != nil {
+		return nil, fmt.Errorf("iterating over treasury outputs failed: %w", err)
+	}
+
+	treasuryOutput, err := u.UnspentTreasuryOutput()
+	if err != nil {
+		return nil, fmt.Errorf("reading unspent treasury output failed: %w", err)
+	}
+	report.TreasuryAmount = treasuryOutput.Amount
+
+	// the receipts are stored by migrated at index, but the treasury is consumed in the order of the milestones
+	receipts := make([]*ReceiptTuple, 0)
+	if err := u.ForEachReceiptTuple(func(rt *ReceiptTuple) bool {
+		receipts = append(receipts, rt)
+
+		return true
+	}); err != nil {
+		return nil, fmt.Errorf("iterating over receipts failed: %w", err)
+	}
+
+	sort.Slice(receipts, func(i, j int) bool {
+		return receipts[i].MilestoneIndex < receipts[j].MilestoneIndex
+	})
+
+	finalReceipts := make(map[milestone.Index][]milestone.Index)
+
+	// the ID of the treasury output created by the previous receipt
+	var previousTreasuryOutputID *iotago.MilestoneID
+
+	for _, rt := range receipts {
+		auditReceipt := &MigrationAuditReceipt{
+			MilestoneIndex: rt.MilestoneIndex,
+			MigratedAt:     milestone.Index(rt.Receipt.MigratedAt),
+			Final:          rt.Receipt.Final,
+			EntryCount:     len(rt.Receipt.Funds),
+			Valid:          true,
+		}
+		report.Receipts = append(report.Receipts, auditReceipt)
+		report.ReceiptCount++
+		report.EntryCount += auditReceipt.EntryCount
+
+		addIssue := func(check string, format string, args ...interface{}) {
+			auditReceipt.Valid = false
+			report.Issues = append(report.Issues, &MigrationAuditIssue{
+				MilestoneIndex: auditReceipt.MilestoneIndex,
+				MigratedAt:     auditReceipt.MigratedAt,
+				Check:          check,
+				Message:        fmt.Sprintf(format, args...),
+			})
+		}
+
+		if rt.Receipt.Final {
+			finalReceipts[auditReceipt.MigratedAt] = append(finalReceipts[auditReceipt.MigratedAt], rt.MilestoneIndex)
+		}
+
+		migratedFundsEntries := make([]*iotago.MigratedFundsEntry, 0, len(rt.Receipt.Funds))
+		for i, entry := range rt.Receipt.Funds {
+			migratedFundsEntry, ok := entry.(*iotago.MigratedFundsEntry)
+			if !ok {
+				addIssue(MigrationAuditCheckFundsSum, "funds entry %d is not a migrated funds entry", i)
+
+				continue
+			}
+			migratedFundsEntries = append(migratedFundsEntries, migratedFundsEntry)
+			auditReceipt.FundsSum += migratedFundsEntry.Deposit
+		}
+		report.TotalMigrated += auditReceipt.FundsSum
+
+		treasuryTransaction, ok := rt.Receipt.Transaction.(*iotago.TreasuryTransaction)
+		if !ok {
+			addIssue(MigrationAuditCheckTreasuryTransaction, "receipt contains no treasury transaction")
+
+			continue
+		}
+
+		treasuryInput, ok := treasuryTransaction.Input.(*iotago.TreasuryInput)
+		if !ok {
+			addIssue(MigrationAuditCheckTreasuryTransaction, "treasury transaction contains no treasury input")
+
+			continue
+		}
+		auditReceipt.TreasuryInputID = hex.EncodeToString(treasuryInput[:])
+
+		createdTreasuryOutput, ok := treasuryTransaction.Output.(*iotago.TreasuryOutput)
+		if !ok {
+			addIssue(MigrationAuditCheckTreasuryTransaction, "treasury transaction contains no treasury output")
+
+			continue
+		}
+		auditReceipt.TreasuryOutputAmount = createdTreasuryOutput.Amount
+
+		if previousTreasuryOutputID != nil && iotago.MilestoneID(*treasuryInput) != *previousTreasuryOutputID {
+			addIssue(MigrationAuditCheckTreasuryChain, "treasury input %s is not the treasury output %s created by the previous receipt", auditReceipt.TreasuryInputID, hex.EncodeToString(previousTreasuryOutputID[:]))
+		}
+
+		consumedTreasuryOutput, err := u.readSpentTreasuryOutput(treasuryInput[:])
+		if err != nil {
+			if !errors.Is(err, kvstore.ErrKeyNotFound) {
+				return nil, fmt.Errorf("reading treasury output %s failed: %w", auditReceipt.TreasuryInputID, err)
+			}
+			addIssue(MigrationAuditCheckTreasuryChain, "consumed treasury output %s not found", auditReceipt.TreasuryInputID)
+		} else {
+			auditReceipt.TreasuryInputAmount = consumedTreasuryOutput.Amount
+
+			if consumedTreasuryOutput.Amount < createdTreasuryOutput.Amount || consumedTreasuryOutput.Amount-createdTreasuryOutput.Amount != auditReceipt.FundsSum {
+				addIssue(MigrationAuditCheckFundsSum, "funds sum %d doesn't match treasury input %d minus output %d", auditReceipt.FundsSum, consumedTreasuryOutput.Amount, createdTreasuryOutput.Amount)
+			}
+		}
+
+		// the ID of the created treasury output is the ID of the milestone, which is only known by the milestone diff.
+		// the outputs of the migrated funds entries are derived from the same ID.
+		diff, err := u.MilestoneDiff(rt.MilestoneIndex)
+		if err != nil {
+			if !errors.Is(err, kvstore.ErrKeyNotFound) {
+				return nil, fmt.Errorf("reading milestone diff %d failed: %w", rt.MilestoneIndex, err)
+			}
+			addIssue(MigrationAuditCheckMigratedOutput, "milestone diff not found")
+			previousTreasuryOutputID = nil
+
+			continue
+		}
+
+		if diff.TreasuryOutput == nil {
+			addIssue(MigrationAuditCheckTreasuryChain, "milestone diff contains no treasury output")
+			previousTreasuryOutputID = nil
+
+			continue
+		}
+
+		if diff.TreasuryOutput.Amount != createdTreasuryOutput.Amount {
+			addIssue(MigrationAuditCheckTreasuryChain, "stored treasury output amount %d doesn't match treasury transaction output %d", diff.TreasuryOutput.Amount, createdTreasuryOutput.Amount)
+		}
+
+		milestoneID := diff.TreasuryOutput.MilestoneID
+		previousTreasuryOutputID = &milestoneID
+
+		for i, migratedFundsEntry := range migratedFundsEntries {
+			outputID := OutputIDForMigratedFunds(milestoneID, uint16(i))
+
+			output, err := u.ReadOutputByOutputID(outputID)
+			if err != nil {
+				if !errors.Is(err, kvstore.ErrKeyNotFound) {
+					return nil, fmt.Errorf("reading output %s failed: %w", outputID.ToHex(), err)
+				}
+				addIssue(MigrationAuditCheckMigratedOutput, "output %s of funds entry %d not found", outputID.ToHex(), i)
+
+				continue
+			}
+
+			//nolint:forcetypeassert // only addresses are allowed in migrated funds entries
+			if output.Address().String() != migratedFundsEntry.Address.(iotago.Address).String() {
+				addIssue(MigrationAuditCheckMigratedOutput, "output %s of funds entry %d has address %s instead of %s", outputID.ToHex(), i, output.Address(), migratedFundsEntry.Address)
+			}
+
+			if output.Amount() != migratedFundsEntry.Deposit {
+				addIssue(MigrationAuditCheckMigratedOutput, "output %s of funds entry %d has amount %d instead of %d", outputID.ToHex(), i, output.Amount(), migratedFundsEntry.Deposit)
+			}
+
+			if output.OutputType() != iotago.OutputSigLockedSingleOutput {
+				addIssue(MigrationAuditCheckMigratedOutput, "output %s of funds entry %d has output type %d", outputID.ToHex(), i, output.OutputType())
+			}
+		}
+	}
+
+	for migratedAt, milestoneIndexes := range finalReceipts {
+		if len(milestoneIndexes) <= 1 {
+			continue
+		}
+
+		for _, msIndex := range milestoneIndexes {
+			for _, auditReceipt := range report.Receipts {
+				if auditReceipt.MilestoneIndex == msIndex && auditReceipt.MigratedAt == migratedAt {
+					auditReceipt.Valid = false
+				}
+			}
+
+			report.Issues = append(report.Issues, &MigrationAuditIssue{
+				MilestoneIndex: msIndex,
+				MigratedAt:     migratedAt,
+				Check:          MigrationAuditCheckFinalReceipt,
+				Message:        fmt.Sprintf("%d final receipts exist for migrated at index %d", len(milestoneIndexes), migratedAt),
+			})
+		}
+	}
+
+	sort.SliceStable(report.Issues, func(i, j int) bool {
+		return report.Issues[i].MilestoneIndex < report.Issues[j].MilestoneIndex
+	})
+
+	report.Valid = len(report.Issues) == 0
+
+	return report, nil
+}
+
+// OutputIDForMigratedFunds returns the ID of the output that was created for the migrated funds entry
+// with the given index in the receipt of the milestone with the given ID.
+func OutputIDForMigratedFunds(milestoneID iotago.MilestoneID, outputIndex uint16) *iotago.UTXOInputID {
+	var outputID iotago.UTXOInputID
+	copy(outputID[:iotago.TransactionIDLength], milestoneID[:iotago.TransactionIDLength])
+	binary.LittleEndian.PutUint16(outputID[iotago.TransactionIDLength:], outputIndex)
+
+	return &outputID
+}
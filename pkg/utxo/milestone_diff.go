@@ -3,6 +3,7 @@ package utxo
 import (
 	"encoding/binary"
 
+	"github.com/iotaledger/hive.go/kvstore"
 	"github.com/iotaledger/hive.go/serializer/v2/marshalutil"
 	"github.com/iotaledger/inx-api-core-v1/pkg/milestone"
 	iotago "github.com/iotaledger/iota.go/v2"
@@ -132,3 +133,53 @@ func (u *Manager) MilestoneDiff(msIndex milestone.Index) (*MilestoneDiff, error)
 
 	return diff, nil
 }
+
+// MilestoneDiffSize returns the amount of created and consumed outputs of the milestone diff without loading the outputs.
+func (u *Manager) MilestoneDiffSize(msIndex milestone.Index) (int, int, error) {
+	value, err := u.utxoStorage.Get(milestoneDiffKeyForIndex(msIndex))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	marshalUtil := marshalutil.New(value)
+
+	outputCount, err := marshalUtil.ReadUint32()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if _, err := marshalUtil.ReadBytes(int(outputCount) * OutputIDLength); err != nil {
+		return 0, 0, err
+	}
+
+	spentCount, err := marshalUtil.ReadUint32()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return int(outputCount), int(spentCount), nil
+}
+
+// MilestoneDiffIndexRange returns the lowest and the highest milestone index of the stored milestone diffs.
+// It returns false if no milestone diffs are stored.
+func (u *Manager) MilestoneDiffIndexRange() (milestone.Index, milestone.Index, bool, error) {
+	var first, last milestone.Index
+	var found bool
+
+	if err := u.utxoStorage.IterateKeys([]byte{UTXOStoreKeyPrefixMilestoneDiffs}, func(key kvstore.Key) bool {
+		msIndex := milestone.Index(binary.LittleEndian.Uint32(key[1:]))
+		if !found || msIndex < first {
+			first = msIndex
+		}
+		if !found || msIndex > last {
+			last = msIndex
+		}
+		found = true
+
+		return true
+	}); err != nil {
+		return 0, 0, false, err
+	}
+
+	return first, last, found, nil
+}
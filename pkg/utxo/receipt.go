@@ -2,6 +2,7 @@ package utxo
 
 import (
 	"encoding/binary"
+	"fmt"
 
 	"github.com/iotaledger/hive.go/kvstore"
 	"github.com/iotaledger/hive.go/serializer"
@@ -112,3 +113,97 @@ func (u *Manager) ForEachReceiptTupleMigratedAt(migratedAtIndex milestone.Index,
 
 	return innerErr
 }
+
+// ReceiptsSummary summarizes all receipts of a migrated at index.
+type ReceiptsSummary struct {
+	// The milestone index at which the funds were migrated in the legacy network.
+	MigratedAt milestone.Index
+	// The amount of receipts for the migrated at index.
+	ReceiptCount int
+	// Whether a final receipt exists for the migrated at index.
+	Final bool
+	// The amount of migrated funds entries in all receipts.
+	EntryCount int
+	// The sum of the deposits of all migrated funds entries.
+	TotalMigrated uint64
+	// The sum of the amounts the treasury decreased by the treasury transactions of the receipts.
+	TreasuryDecrease uint64
+}
+
+// ReceiptsSummaryMigratedAt summarizes all stored receipts for a given migrated at index.
+// It returns nil if no receipt exists for the migrated at index.
+func (u *Manager) ReceiptsSummaryMigratedAt(migratedAtIndex milestone.Index) (*ReceiptsSummary, error) {
+	summary := &ReceiptsSummary{
+		MigratedAt: migratedAtIndex,
+	}
+
+	var innerErr error
+	if err := u.ForEachReceiptTupleMigratedAt(migratedAtIndex, func(rt *ReceiptTuple) bool {
+		summary.ReceiptCount++
+		summary.EntryCount += len(rt.Receipt.Funds)
+		if rt.Receipt.Final {
+			summary.Final = true
+		}
+
+		for _, entry := range rt.Receipt.Funds {
+			migratedFundsEntry, ok := entry.(*iotago.MigratedFundsEntry)
+			if !ok {
+				innerErr = fmt.Errorf("receipt in milestone %d contains an unsupported funds entry", rt.MilestoneIndex)
+
+				return false
+			}
+			summary.TotalMigrated += migratedFundsEntry.Deposit
+		}
+
+		treasuryTransaction, ok := rt.Receipt.Transaction.(*iotago.TreasuryTransaction)
+		if !ok {
+			innerErr = fmt.Errorf("%w: receipt in milestone %d contains no treasury transaction", ErrInvalidTreasuryState, rt.MilestoneIndex)
+
+			return false
+		}
+
+		treasuryInput, ok := treasuryTransaction.Input.(*iotago.TreasuryInput)
+		if !ok {
+			innerErr = fmt.Errorf("%w: treasury transaction in milestone %d contains no treasury input", ErrInvalidTreasuryState, rt.MilestoneIndex)
+
+			return false
+		}
+
+		treasuryOutput, ok := treasuryTransaction.Output.(*iotago.TreasuryOutput)
+		if !ok {
+			innerErr = fmt.Errorf("%w: treasury transaction in milestone %d contains no treasury output", ErrInvalidTreasuryState, rt.MilestoneIndex)
+
+			return false
+		}
+
+		// the treasury output consumed by the receipt is always spent
+		consumedTreasuryOutput, err := u.readSpentTreasuryOutput(treasuryInput[:])
+		if err != nil {
+			innerErr = fmt.Errorf("reading treasury output consumed in milestone %d failed: %w", rt.MilestoneIndex, err)
+
+			return false
+		}
+
+		if consumedTreasuryOutput.Amount < treasuryOutput.Amount {
+			innerErr = fmt.Errorf("%w: treasury transaction in milestone %d increases the treasury from %d to %d", ErrInvalidTreasuryState, rt.MilestoneIndex, consumedTreasuryOutput.Amount, treasuryOutput.Amount)
+
+			return false
+		}
+		summary.TreasuryDecrease += consumedTreasuryOutput.Amount - treasuryOutput.Amount
+
+		return true
+	}); err != nil {
+		return nil, err
+	}
+
+	if innerErr != nil {
+		return nil, innerErr
+	}
+
+	if summary.ReceiptCount == 0 {
+		//nolint:nilnil // nil is a valid return value if no receipt exists
+		return nil, nil
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,137 @@
+package utxo
+
+import (
+	"sort"
+
+	"github.com/iotaledger/inx-api-core-v1/pkg/milestone"
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+// TransferAmount is the sum of the amounts of the inputs or outputs of an address in a transfer.
+type TransferAmount struct {
+	// The address.
+	Address iotago.Address
+	// The sum of the amounts.
+	Amount uint64
+}
+
+// Transfer is a transaction that was included in the ledger by a milestone.
+type Transfer struct {
+	// The ID of the transaction.
+	TransactionID iotago.TransactionID
+	// The index of the milestone that included the transaction.
+	MilestoneIndex milestone.Index
+	// The amounts of the consumed outputs per address, in the order the addresses appear.
+	Inputs []*TransferAmount
+	// The amounts of the created outputs per address, in the order the addresses appear.
+	Outputs []*TransferAmount
+	// The sum of the amounts of all consumed outputs.
+	TotalValue uint64
+	// The sum of the amounts of the created outputs on addresses that are not part of the inputs (without the remainder).
+	NetValue uint64
+}
+
+// addTransferAmount adds the amount to the entry of the address or appends a new entry.
+func addTransferAmount(amounts []*TransferAmount, address iotago.Address, amount uint64) []*TransferAmount {
+	for _, entry := range amounts {
+		if entry.Address.String() == address.String() {
+			entry.Amount += amount
+
+			return amounts
+		}
+	}
+
+	return append(amounts, &TransferAmount{Address: address, Amount: amount})
+}
+
+// MilestoneTransfers returns the transactions that were included in the ledger by the milestone,
+// sorted by their net value (descending). Migrated funds are not part of the transfers.
+func (u *Manager) MilestoneTransfers(msIndex milestone.Index) ([]*Transfer, error) {
+	diff, err := u.MilestoneDiff(msIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	transfers := TransfersFromMilestoneDiff(diff)
+	SortTransfersByNetValue(transfers)
+
+	return transfers, nil
+}
+
+// TransfersFromMilestoneDiff groups the consumed and created outputs of the milestone diff by transaction,
+// in the order the transactions consumed their first output. Migrated funds are not part of the transfers.
+func TransfersFromMilestoneDiff(diff *MilestoneDiff) []*Transfer {
+	msIndex := diff.Index
+
+	transfersMap := make(map[iotago.TransactionID]*Transfer)
+	transfers := make([]*Transfer, 0)
+
+	for _, spent := range diff.Spents {
+		transactionID := *spent.TargetTransactionID()
+
+		transfer, exists := transfersMap[transactionID]
+		if !exists {
+			transfer = &Transfer{
+				TransactionID:  transactionID,
+				MilestoneIndex: msIndex,
+				Inputs:         make([]*TransferAmount, 0),
+				Outputs:        make([]*TransferAmount, 0),
+			}
+			transfersMap[transactionID] = transfer
+			transfers = append(transfers, transfer)
+		}
+
+		transfer.Inputs = addTransferAmount(transfer.Inputs, spent.Address(), spent.Amount())
+		transfer.TotalValue += spent.Amount()
+	}
+
+	for _, output := range diff.Outputs {
+		var transactionID iotago.TransactionID
+		copy(transactionID[:], output.OutputID()[:iotago.TransactionIDLength])
+
+		transfer, exists := transfersMap[transactionID]
+		if !exists {
+			// outputs without consumed inputs are migrated funds
+			continue
+		}
+
+		transfer.Outputs = addTransferAmount(transfer.Outputs, output.Address(), output.Amount())
+	}
+
+	for _, transfer := range transfers {
+		for _, output := range transfer.Outputs {
+			isRemainder := false
+			for _, input := range transfer.Inputs {
+				if input.Address.String() == output.Address.String() {
+					isRemainder = true
+
+					break
+				}
+			}
+
+			if !isRemainder {
+				transfer.NetValue += output.Amount
+			}
+		}
+	}
+
+	return transfers
+}
+
+// SortTransfersByNetValue sorts the transfers by their net value and total value (descending),
+// transfers with equal values are sorted by milestone index and transaction ID.
+func SortTransfersByNetValue(transfers []*Transfer) {
+	sort.Slice(transfers, func(i, j int) bool {
+		if transfers[i].NetValue != transfers[j].NetValue {
+			return transfers[i].NetValue > transfers[j].NetValue
+		}
+		if transfers[i].TotalValue != transfers[j].TotalValue {
+			return transfers[i].TotalValue > transfers[j].TotalValue
+		}
+		if transfers[i].MilestoneIndex != transfers[j].MilestoneIndex {
+			return transfers[i].MilestoneIndex < transfers[j].MilestoneIndex
+		}
+
+		return string(transfers[i].TransactionID[:]) < string(transfers[j].TransactionID[:])
+	})
+}
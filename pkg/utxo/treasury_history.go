@@ -0,0 +1,110 @@
+package utxo
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/inx-api-core-v1/pkg/milestone"
+	iotago "github.com/iotaledger/iota.go/v2"
+)
+
+// TreasuryHistoryEntry is a treasury output together with the milestones that created and consumed it.
+type TreasuryHistoryEntry struct {
+	// The treasury output.
+	TreasuryOutput *TreasuryOutput
+	// The index of the milestone that created the treasury output.
+	// Zero if the output was part of the genesis snapshot or the milestone diff is not available.
+	CreatedByMilestoneIndex milestone.Index
+	// The index of the milestone that consumed the treasury output. Zero if the output is unspent.
+	ConsumedByMilestoneIndex milestone.Index
+	// The receipt of the milestone that created the treasury output, nil if there is none.
+	Receipt *ReceiptTuple
+}
+
+// TreasuryHistory returns all spent and unspent treasury outputs sorted by the milestone that created them.
+// Every receipt consumes the previous treasury output and creates a new one with the remaining funds.
+func (u *Manager) TreasuryHistory() ([]*TreasuryHistoryEntry, error) {
+
+	entries := make(map[iotago.MilestoneID]*TreasuryHistoryEntry)
+	if err := u.ForEachTreasuryOutput(func(output *TreasuryOutput) bool {
+		entries[output.MilestoneID] = &TreasuryHistoryEntry{TreasuryOutput: output}
+
+		return true
+	}); err != nil {
+		return nil, fmt.Errorf("iterating over treasury outputs failed: %w", err)
+	}
+
+	var innerErr error
+	if err := u.ForEachReceiptTuple(func(rt *ReceiptTuple) bool {
+		treasuryTransaction, ok := rt.Receipt.Transaction.(*iotago.TreasuryTransaction)
+		if !ok {
+			innerErr = fmt.Errorf("%w: receipt in milestone %d contains no treasury transaction", ErrInvalidTreasuryState, rt.MilestoneIndex)
+
+			return false
+		}
+
+		treasuryInput, ok := treasuryTransaction.Input.(*iotago.TreasuryInput)
+		if !ok {
+			innerErr = fmt.Errorf("%w: treasury transaction in milestone %d contains no treasury input", ErrInvalidTreasuryState, rt.MilestoneIndex)
+
+			return false
+		}
+
+		if consumed, exists := entries[iotago.MilestoneID(*treasuryInput)]; exists {
+			consumed.ConsumedByMilestoneIndex = rt.MilestoneIndex
+		}
+
+		// the ID of the created treasury output is the ID of the milestone, which is only known by the milestone diff
+		diff, err := u.MilestoneDiff(rt.MilestoneIndex)
+		if err != nil {
+			if errors.Is(err, kvstore.ErrKeyNotFound) {
+				// the milestone diff is not available
+				return true
+			}
+			innerErr = fmt.Errorf("reading milestone diff %d failed: %w", rt.MilestoneIndex, err)
+
+			return false
+		}
+
+		if diff.TreasuryOutput == nil {
+			return true
+		}
+
+		if created, exists := entries[diff.TreasuryOutput.MilestoneID]; exists {
+			created.CreatedByMilestoneIndex = rt.MilestoneIndex
+			created.Receipt = rt
+		}
+
+		return true
+	}); err != nil {
+		return nil, fmt.Errorf("iterating over receipts failed: %w", err)
+	}
+
+	if innerErr != nil {
+		return nil, innerErr
+	}
+
+	history := make([]*TreasuryHistoryEntry, 0, len(entries))
+	for _, entry := range entries {
+		history = append(history, entry)
+	}
+
+	sort.Slice(history, func(i, j int) bool {
+		if history[i].CreatedByMilestoneIndex != history[j].CreatedByMilestoneIndex {
+			return history[i].CreatedByMilestoneIndex < history[j].CreatedByMilestoneIndex
+		}
+
+		// outputs with an unknown creating milestone are sorted by the milestone that consumed them, unspent last
+		consumedLeft, consumedRight := history[i].ConsumedByMilestoneIndex, history[j].ConsumedByMilestoneIndex
+		if consumedLeft == 0 || consumedRight == 0 {
+			return consumedRight == 0 && consumedLeft != 0
+		}
+
+		return consumedLeft < consumedRight
+	})
+
+	return history, nil
+}
@@ -123,3 +123,26 @@ func (u *Manager) UnspentTreasuryOutput() (*TreasuryOutput, error) {
 
 	return unspentTreasuryOutput, nil
 }
+
+// TreasuryOutputConsumer is a function that consumes a treasury output.
+// Returning false from this function indicates to abort the iteration.
+type TreasuryOutputConsumer func(output *TreasuryOutput) bool
+
+// ForEachTreasuryOutput iterates over all spent and unspent treasury outputs.
+func (u *Manager) ForEachTreasuryOutput(consumer TreasuryOutputConsumer) error {
+	var innerErr error
+	if err := u.utxoStorage.Iterate([]byte{UTXOStoreKeyPrefixTreasuryOutput}, func(key kvstore.Key, value kvstore.Value) bool {
+		output := &TreasuryOutput{}
+		if err := output.kvStorableLoad(u, key, value); err != nil {
+			innerErr = err
+
+			return false
+		}
+
+		return consumer(output)
+	}); err != nil {
+		return err
+	}
+
+	return innerErr
+}